@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/export"
+)
+
+var annotationsFormat string
+var annotationsOut string
+
+var annotationsCmd = &cobra.Command{
+	Use:   "annotations",
+	Short: "Manage the highlights and notes made while reading",
+}
+
+var annotationsExportCmd = &cobra.Command{
+	Use:   "export [fiction-id...]",
+	Short: "Export highlights and notes as Markdown, one file per fiction",
+	Long: `Export highlights and notes (H in the reader) as one Markdown file per
+fiction, with YAML front matter and chapter links, ready to drop into an
+Obsidian vault or Logseq graph. Pass fiction IDs to export only those.`,
+	ValidArgsFunction: completeFictionIDs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if annotationsFormat != "md" && annotationsFormat != "markdown" {
+			fmt.Printf("Unknown format %q (use md)\n", annotationsFormat)
+			os.Exit(1)
+		}
+
+		cfg := loadConfigOrExit()
+		var annotations []config.Annotation
+		if len(args) == 0 {
+			annotations = cfg.Annotations
+		}
+		for _, fictionID := range args {
+			annotations = append(annotations, cfg.FictionAnnotations(fictionID)...)
+		}
+		if len(annotations) == 0 {
+			fmt.Println("No highlights to export. Press H in the reader to highlight a passage.")
+			return
+		}
+
+		dir, err := config.ExpandHome(annotationsOut)
+		if err != nil {
+			fmt.Printf("Error exporting annotations: %v\n", err)
+			os.Exit(1)
+		}
+		files, err := export.WriteAnnotations(annotations, dir)
+		if err != nil {
+			fmt.Printf("Error exporting annotations: %v\n", err)
+			os.Exit(1)
+		}
+		for _, file := range files {
+			fmt.Println(file)
+		}
+		fmt.Printf("Exported %d highlights to %d files\n", len(annotations), len(files))
+	},
+}
+
+func init() {
+	annotationsExportCmd.Flags().StringVarP(&annotationsFormat, "format", "f", "md", "Output format: md")
+	annotationsExportCmd.Flags().StringVarP(&annotationsOut, "out", "o", ".", "Directory to write the files to")
+
+	annotationsCmd.AddCommand(annotationsExportCmd)
+	rootCmd.AddCommand(annotationsCmd)
+}
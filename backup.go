@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/backup"
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+var (
+	backupRemote string
+	backupDryRun bool
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up downloaded fictions and reading progress with rclone",
+	Long: `Back up downloaded fictions and reading progress to any rclone remote,
+including S3-compatible storage. Set "backup": {"remote": "name:path"} in
+config.json or pass --remote. Only changed files are transferred and nothing is
+ever deleted on either side.`,
+}
+
+var backupPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Upload the library and progress to the backup remote",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := backup.Push(backupTargetOrExit()); err != nil {
+			fmt.Printf("Error backing up: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Backup pushed.")
+	},
+}
+
+var backupPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Download the library and progress from the backup remote",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := backup.Pull(backupTargetOrExit()); err != nil {
+			fmt.Printf("Error restoring backup: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Backup pulled.")
+	},
+}
+
+func backupTargetOrExit() backup.Target {
+	cfg := loadConfigOrExit()
+	configPath, err := config.Path()
+	if err != nil {
+		fmt.Printf("Error locating config: %v\n", err)
+		os.Exit(1)
+	}
+
+	remote := cfg.Backup.Remote
+	if backupRemote != "" {
+		remote = backupRemote
+	}
+	return backup.Target{
+		Remote:     remote,
+		CacheDir:   openCacheOrExit().Dir(),
+		ConfigPath: configPath,
+		DryRun:     backupDryRun,
+	}
+}
+
+func init() {
+	backupCmd.PersistentFlags().StringVar(&backupRemote, "remote", "", `rclone remote path to use instead of the configured one, e.g. "s3:bucket/royal-road"`)
+	backupCmd.PersistentFlags().BoolVar(&backupDryRun, "dry-run", false, "show what would be transferred without copying anything")
+	backupCmd.AddCommand(backupPushCmd, backupPullCmd)
+	rootCmd.AddCommand(backupCmd)
+}
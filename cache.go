@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+)
+
+var cacheClearYes bool
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and manage the offline chapter cache",
+}
+
+var cacheLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List cached fictions",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		store := openCacheOrExit()
+		fictionIDs, err := store.Fictions()
+		if err != nil {
+			fmt.Printf("Error reading cache: %v\n", err)
+			os.Exit(1)
+		}
+		if len(fictionIDs) == 0 {
+			fmt.Println("The cache is empty.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTITLE\tCHAPTERS\tSIZE")
+		for _, fictionID := range fictionIDs {
+			title, total := "?", 0
+			if fiction, err := store.LoadFiction(fictionID); err == nil {
+				title, total = fiction.Title, len(fiction.Chapters)
+			}
+			size, _ := store.Size(fictionID)
+			fmt.Fprintf(w, "%d\t%s\t%d/%d\t%s\n", fictionID, title, len(store.CachedChapters(fictionID)), total, formatBytes(size))
+		}
+		w.Flush()
+	},
+}
+
+var cacheSizeCmd = &cobra.Command{
+	Use:               "size [fiction-id]",
+	Short:             "Show how much disk the cache (or one fiction) uses",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFictionID,
+	Run: func(cmd *cobra.Command, args []string) {
+		store := openCacheOrExit()
+
+		if len(args) == 1 {
+			fictionID := parseFictionIDOrExit(args[0])
+			if !store.HasFiction(fictionID) {
+				fmt.Printf("Fiction %d is not cached\n", fictionID)
+				os.Exit(1)
+			}
+			size, err := store.Size(fictionID)
+			if err != nil {
+				fmt.Printf("Error reading cache: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("%s\t%d chapters\n", formatBytes(size), len(store.CachedChapters(fictionID)))
+			return
+		}
+
+		size, err := store.TotalSize()
+		if err != nil {
+			fmt.Printf("Error reading cache: %v\n", err)
+			os.Exit(1)
+		}
+		fictionIDs, _ := store.Fictions()
+		usage := formatBytes(size)
+		if cfg, err := config.Load(); err == nil && cfg.Cache.Limit() > 0 {
+			usage += " of " + formatBytes(cfg.Cache.Limit())
+		}
+		fmt.Printf("%s\t%d fictions\t%s\n", usage, len(fictionIDs), store.Dir())
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:               "clear [fiction-id]",
+	Short:             "Evict one fiction, or everything, from the cache",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFictionID,
+	Run: func(cmd *cobra.Command, args []string) {
+		store := openCacheOrExit()
+
+		if len(args) == 1 {
+			fictionID := parseFictionIDOrExit(args[0])
+			if err := store.Remove(fictionID); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Removed fiction %d from the cache\n", fictionID)
+			return
+		}
+
+		if !cacheClearYes && !confirm("Remove every cached fiction?") {
+			fmt.Println("Nothing removed.")
+			return
+		}
+		if err := store.Clear(); err != nil {
+			fmt.Printf("Error clearing cache: %v\n", err)
+			os.Exit(1)
+		}
+		if err := network.ClearHTTPCache(); err != nil {
+			fmt.Printf("Error clearing the HTTP cache: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Cache cleared.")
+	},
+}
+
+// enforceCacheQuota evicts the least recently read fictions once the cache
+// outgrows cache.maxSizeMB. Fictions in keep, such as one just downloaded,
+// are never evicted.
+func enforceCacheQuota(store *cache.Store, keep ...int) {
+	cfg, err := config.Load()
+	if err != nil || cfg.Cache.Limit() == 0 {
+		return
+	}
+
+	titles := make(map[int]string)
+	for _, entry := range cfg.ReadingHistory {
+		if id, err := strconv.Atoi(entry.FictionID); err == nil {
+			titles[id] = entry.FictionTitle
+		}
+	}
+	evicted, err := store.EvictLRU(cfg.Cache.Limit(), func(fictionID int) time.Time {
+		return cfg.LastReadTime(strconv.Itoa(fictionID))
+	}, keep...)
+	for _, fictionID := range evicted {
+		title := titles[fictionID]
+		if title == "" {
+			title = "fiction " + strconv.Itoa(fictionID)
+		}
+		fmt.Printf("Evicted %s from the cache to stay under %d MB\n", title, cfg.Cache.MaxSizeMB)
+	}
+	if err != nil {
+		fmt.Printf("Error enforcing the cache limit: %v\n", err)
+	}
+}
+
+func parseFictionIDOrExit(arg string) int {
+	fictionID, err := strconv.Atoi(arg)
+	if err != nil {
+		fmt.Printf("Invalid fiction ID: %s\n", arg)
+		os.Exit(1)
+	}
+	return fictionID
+}
+
+// confirm asks a yes/no question on the terminal, defaulting to no.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func init() {
+	cacheClearCmd.Flags().BoolVarP(&cacheClearYes, "yes", "y", false, "Don't ask before clearing the whole cache")
+
+	cacheCmd.AddCommand(cacheLsCmd)
+	cacheCmd.AddCommand(cacheSizeCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
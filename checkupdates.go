@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/download"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+var checkUpdatesJSON bool
+
+// fictionUpdate is one fiction with chapters published since it was last read.
+type fictionUpdate struct {
+	FictionID      int    `json:"fictionId"`
+	Title          string `json:"title"`
+	KnownChapters  int    `json:"knownChapters"`
+	LiveChapters   int    `json:"liveChapters"`
+	NewChapters    int    `json:"newChapters"`
+	UnreadChapters int    `json:"unreadChapters"`
+	LatestChapter  string `json:"latestChapter"`
+}
+
+var checkUpdatesCmd = &cobra.Command{
+	Use:   "check-updates",
+	Short: "Report which books in your history have new chapters",
+	Long: `Compare the chapter count recorded when each book in your history was last
+read with the live chapter count, and list the books that have new chapters.
+Each fiction's RSS feed is checked first; its page is only fetched when the
+feed shows chapters newer than the last read.
+Dropped books are skipped. The newChapters hooks run once for each batch of new
+chapters.
+
+Exit status is 0 when everything is up to date, 1 when updates were found and
+2 when a check failed, so it can drive cron jobs and scripts.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfigOrExit()
+		client := network.NewClient(cfg)
+		delay := download.DefaultOptions().Delay
+
+		updates := []fictionUpdate{}
+		failed, changed := false, false
+		checked := 0
+		for i := range cfg.ReadingHistory {
+			entry := &cfg.ReadingHistory[i]
+			fictionID, err := strconv.Atoi(entry.FictionID)
+			if err != nil || entry.CurrentShelf() == config.ShelfDropped {
+				continue
+			}
+
+			if checked > 0 {
+				time.Sleep(delay)
+			}
+			checked++
+			// The feed is much smaller than the fiction page, which is only
+			// needed, for the chapter count, when the feed shows something new
+			// or can't be read
+			if items, err := client.GetFictionFeed(context.Background(), fictionID); err == nil && feedUpToDate(items, entry) {
+				continue
+			}
+			fiction, err := client.GetFiction(royalroad.Revalidate(context.Background()), fictionID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error checking %s: %v\n", entry.FictionTitle, err)
+				failed = true
+				continue
+			}
+
+			live := len(fiction.Chapters)
+			if live <= entry.TotalChapters {
+				continue
+			}
+			updates = append(updates, fictionUpdate{
+				FictionID:      fictionID,
+				Title:          fiction.Title,
+				KnownChapters:  entry.TotalChapters,
+				LiveChapters:   live,
+				NewChapters:    live - entry.TotalChapters,
+				UnreadChapters: entry.UnreadCount(chapterIDs(fiction)),
+				LatestChapter:  fiction.Chapters[live-1].Title,
+			})
+
+			// Hooks only hear about each chapter once, however often this runs
+			if notified := max(entry.TotalChapters, entry.NotifiedChapters); live > notified {
+				var chapters []int
+				for index := notified; index < live; index++ {
+					chapters = append(chapters, index)
+				}
+				runNewChapterHooks(fiction, chapters)
+				entry.NotifiedChapters = live
+				changed = true
+			}
+		}
+
+		if changed {
+			if err := cfg.Save(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving config: %v\n", err)
+				failed = true
+			}
+		}
+
+		if checkUpdatesJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(updates); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding updates: %v\n", err)
+				os.Exit(2)
+			}
+		} else if len(updates) == 0 {
+			fmt.Println("Everything is up to date.")
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tTITLE\tNEW\tUNREAD\tLATEST")
+			for _, u := range updates {
+				fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%s\n", u.FictionID, u.Title, u.NewChapters, u.UnreadChapters, u.LatestChapter)
+			}
+			w.Flush()
+		}
+
+		switch {
+		case failed:
+			os.Exit(2)
+		case len(updates) > 0:
+			os.Exit(1)
+		}
+	},
+}
+
+// feedUpToDate reports whether a fiction's feed shows no chapter published
+// since the entry's chapter count was taken: each recent chapter came out
+// before the book was last read, or has been read.
+func feedUpToDate(items []royalroad.FeedItem, entry *config.ReadingEntry) bool {
+	lastRead, err := time.ParseInLocation(config.TimeLayout, entry.LastRead, time.Local)
+	if err != nil {
+		return false
+	}
+	for _, item := range items {
+		if item.Published.IsZero() || (item.Published.After(lastRead) && !entry.HasRead(item.ChapterID)) {
+			return false
+		}
+	}
+	return true
+}
+
+// chapterIDs lists a fiction's chapter IDs in order.
+func chapterIDs(fiction *royalroad.Fiction) []int {
+	ids := make([]int, len(fiction.Chapters))
+	for i, chapter := range fiction.Chapters {
+		ids[i] = chapter.ID
+	}
+	return ids
+}
+
+func init() {
+	checkUpdatesCmd.Flags().BoolVar(&checkUpdatesJSON, "json", false, "print the updates as JSON")
+	rootCmd.AddCommand(checkUpdatesCmd)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// fictionCompletions lists the fiction IDs in the history and reading queue,
+// most recently read first, each described by its title. IDs already on the
+// command line are left out, as are ones that don't match what has been
+// typed so far by ID or by title.
+func fictionCompletions(args []string, toComplete string) []string {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+
+	query := strings.ToLower(toComplete)
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		seen[arg] = true
+	}
+	var completions []string
+	add := func(fictionID, title string) {
+		if seen[fictionID] {
+			return
+		}
+		seen[fictionID] = true
+		if !strings.HasPrefix(fictionID, toComplete) && !strings.Contains(strings.ToLower(title), query) {
+			return
+		}
+		completions = append(completions, fictionID+"\t"+title)
+	}
+
+	for _, entry := range config.SortEntries(cfg.ReadingHistory, config.SortRecent) {
+		add(entry.FictionID, entry.FictionTitle)
+	}
+	for _, queued := range cfg.Queue {
+		add(queued.FictionID, queued.Title+" (queued)")
+	}
+	return completions
+}
+
+// completeFictionID completes the single fiction ID a command takes.
+func completeFictionID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return fictionCompletions(args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFictionIDs completes any number of fiction IDs.
+func completeFictionIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return fictionCompletions(args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/network"
+)
+
+var cookiesCmd = &cobra.Command{
+	Use:   "cookies",
+	Short: "Manage the cookies sent to Royal Road",
+	Long: `Royal Road's cookies are kept in cookies.json beside config.json, so a
+session or a Cloudflare clearance lasts between runs.
+
+When requests fail with "blocked by a Cloudflare challenge", open
+royalroad.com in a browser, pass the check, and import the browser's cookies
+with 'cookies import'. Cloudflare ties its cf_clearance cookie to the browser
+it was issued to, so also send that browser's User-Agent, with --user-agent or
+"userAgent" in the "http" section of config.json.`,
+}
+
+var cookiesImportCmd = &cobra.Command{
+	Use:   "import [file|-]",
+	Short: "Import cookies from a browser's cookies.txt or a Cookie header",
+	Long: `Import cookies from a Netscape cookies.txt file, as saved by browser
+cookie export extensions, or from a Cookie header copied from the browser's
+developer tools, e.g. "cf_clearance=...; other=...". Only Royal Road's cookies
+are taken from a cookies.txt. With no file, or "-", cookies are read from
+standard input.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var input io.Reader = os.Stdin
+		if len(args) == 1 && args[0] != "-" {
+			file, err := os.Open(args[0])
+			if err != nil {
+				fmt.Printf("Error opening cookies: %v\n", err)
+				os.Exit(1)
+			}
+			defer file.Close()
+			input = file
+		} else if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			fmt.Println("Paste a Cookie header, then press Enter and Ctrl+D:")
+		}
+
+		cookies, err := network.ParseCookies(input)
+		if err != nil {
+			fmt.Printf("Error reading cookies: %v\n", err)
+			os.Exit(1)
+		}
+		jar := openJarOrExit()
+		if err := jar.Import(cookies); err != nil {
+			fmt.Printf("Error saving cookies: %v\n", err)
+			os.Exit(1)
+		}
+		names := make([]string, len(cookies))
+		for i, cookie := range cookies {
+			names[i] = cookie.Name
+		}
+		fmt.Printf("Imported %d cookies: %s\n", len(cookies), strings.Join(names, ", "))
+		if hasClearance(cookies) {
+			fmt.Println("Requests must also send the User-Agent of the browser the cookies came from (--user-agent or http.userAgent).")
+		}
+	},
+}
+
+var cookiesLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List the stored cookies",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cookies := openJarOrExit().List()
+		if len(cookies) == 0 {
+			fmt.Println("No cookies are stored.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tDOMAIN\tEXPIRES")
+		for _, cookie := range cookies {
+			expires := "end of session"
+			if !cookie.Expires.IsZero() {
+				expires = cookie.Expires.Local().Format("2006-01-02 15:04")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", cookie.Name, cookie.Domain, expires)
+		}
+		w.Flush()
+	},
+}
+
+var cookiesClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove every stored cookie",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := openJarOrExit().Clear(); err != nil {
+			fmt.Printf("Error clearing cookies: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Cookies cleared.")
+	},
+}
+
+func openJarOrExit() *network.Jar {
+	jar, err := network.OpenJar()
+	if err != nil {
+		fmt.Printf("Error opening the cookie jar: %v\n", err)
+		os.Exit(1)
+	}
+	return jar
+}
+
+func hasClearance(cookies []network.StoredCookie) bool {
+	for _, cookie := range cookies {
+		if cookie.Name == "cf_clearance" {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	cookiesCmd.AddCommand(cookiesImportCmd, cookiesLsCmd, cookiesClearCmd)
+	rootCmd.AddCommand(cookiesCmd)
+}
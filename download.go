@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/internal/download"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+)
+
+var downloadChapters string
+var downloadUpdate bool
+var downloadCheckEdits bool
+
+// Politeness settings shared by every command that downloads chapters
+var downloadOptions = download.DefaultOptions()
+
+var downloadCmd = &cobra.Command{
+	Use:               "download [fiction-id]",
+	Short:             "Download a fiction's chapters for offline reading",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFictionID,
+	Run: func(cmd *cobra.Command, args []string) {
+		fictionID, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Printf("Invalid fiction ID: %s\n", args[0])
+			os.Exit(1)
+		}
+
+		store := openCacheOrExit()
+		downloader := download.New(network.NewClient(nil), store, downloadOptions)
+
+		if downloadUpdate {
+			_, err := updateFiction(downloader, fictionID)
+			enforceCacheQuota(store, fictionID)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		fmt.Printf("Fetching fiction %d...\n", fictionID)
+		fiction, err := downloader.Fiction(context.Background(), fictionID)
+		if err != nil {
+			fmt.Printf("Error fetching fiction: %v\n", err)
+			os.Exit(1)
+		}
+
+		indices, err := download.ParseRange(downloadChapters, len(fiction.Chapters))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if downloadCheckEdits {
+			edited := 0
+			fmt.Printf("Checking %d chapters of %s by %s for edits\n", len(indices), fiction.Title, fiction.Author.Name)
+			err := downloader.CheckEdits(context.Background(), fiction, indices, func(p download.Progress) {
+				if p.Edited {
+					edited++
+				}
+				printProgress(p)
+			})
+			if err != nil {
+				fmt.Printf("\nSome chapters failed to download: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("\nDone. %d chapters were edited and have been re-downloaded.\n", edited)
+			return
+		}
+
+		fmt.Printf("Downloading %d chapters of %s by %s\n", len(indices), fiction.Title, fiction.Author.Name)
+		err = downloader.Chapters(context.Background(), fiction, indices, printProgress)
+		fmt.Println()
+		enforceCacheQuota(store, fictionID)
+		if err != nil {
+			fmt.Printf("Some chapters failed to download: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Done.")
+	},
+}
+
+func openCacheOrExit() *cache.Store {
+	store, err := cache.Open()
+	if err != nil {
+		fmt.Printf("Error opening cache: %v\n", err)
+		os.Exit(1)
+	}
+	return store
+}
+
+// printProgress redraws a single progress line: bar, count, and chapter title.
+func printProgress(p download.Progress) {
+	const barWidth = 30
+
+	filled := barWidth * p.Done / max(p.Total, 1)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+
+	status := ""
+	if p.Edited {
+		status = " (edited)"
+	} else if p.Cached {
+		status = " (cached)"
+	} else if p.Err != nil {
+		status = " (failed)"
+	}
+
+	title := runewidth.Truncate(p.Chapter.Title, 40, "…")
+
+	fmt.Printf("\r\033[K%s %d/%d %s%s", bar, p.Done, p.Total, title, status)
+}
+
+// addDownloadFlags registers the worker pool and rate limit flags.
+func addDownloadFlags(flags *pflag.FlagSet) {
+	flags.IntVar(&downloadOptions.Workers, "workers", downloadOptions.Workers, "Chapters to fetch in parallel")
+	flags.DurationVar(&downloadOptions.Delay, "delay", downloadOptions.Delay, "Pause each worker takes after every request")
+	flags.Float64Var(&downloadOptions.Rate, "rate", downloadOptions.Rate, "Maximum requests per second across all workers (0 for no limit)")
+}
+
+func init() {
+	downloadCmd.Flags().StringVar(&downloadChapters, "chapters", "", "Chapters to download, e.g. 1-50 or 1-10,20- (default all)")
+	downloadCmd.Flags().BoolVar(&downloadUpdate, "update", false, "Only fetch chapters published since the fiction was last downloaded")
+	downloadCmd.Flags().BoolVar(&downloadCheckEdits, "check-edits", false, "Re-fetch cached chapters and re-download any the author has edited")
+	addDownloadFlags(downloadCmd.Flags())
+	rootCmd.AddCommand(downloadCmd)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/download"
+	"github.com/jackowfish/royal-road-cli/internal/export"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+)
+
+var exportOutput string
+var exportChapters string
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a fiction to an ebook or text file",
+	Long: `Export a fiction to a file. Chapters are downloaded into the local cache
+first (already cached chapters are reused), so exports also work offline.`,
+}
+
+// newExportCmd builds an "export <format> [fiction-id]" subcommand. The default
+// output name uses extension (a bare directory name if empty). check, if set,
+// runs before anything is downloaded so missing tools fail fast.
+func newExportCmd(format, extension, short string, check func() error, write func(*export.Book, string) error) *cobra.Command {
+	return &cobra.Command{
+		Use:               format + " [fiction-id]",
+		Short:             short,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFictionID,
+		Run: func(cmd *cobra.Command, args []string) {
+			if check != nil {
+				if err := check(); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			book := prepareBook(args[0])
+
+			output := exportOutput
+			if output == "" {
+				output = export.Filename(book.Fiction.Title, extension)
+			}
+
+			if err := write(book, output); err != nil {
+				fmt.Printf("Error exporting %s: %v\n", format, err)
+				os.Exit(1)
+			}
+			fmt.Printf("Exported %d chapters of %s to %s\n", len(book.Chapters), book.Fiction.Title, output)
+		},
+	}
+}
+
+// prepareBook downloads the selected chapters into the cache and assembles them.
+func prepareBook(fictionArg string) *export.Book {
+	fictionID, err := strconv.Atoi(fictionArg)
+	if err != nil {
+		fmt.Printf("Invalid fiction ID: %s\n", fictionArg)
+		os.Exit(1)
+	}
+
+	store := openCacheOrExit()
+	downloader := download.New(network.NewClient(nil), store, downloadOptions)
+
+	fmt.Printf("Fetching fiction %d...\n", fictionID)
+	fiction, err := downloader.Fiction(context.Background(), fictionID)
+	if err != nil {
+		// Exporting a fully downloaded fiction shouldn't need the network
+		if cached, cacheErr := store.LoadFiction(fictionID); cacheErr == nil {
+			fmt.Printf("Using cached copy (%v)\n", err)
+			fiction = cached
+		} else {
+			fmt.Printf("Error fetching fiction: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	indices, err := download.ParseRange(exportChapters, len(fiction.Chapters))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := downloader.Chapters(context.Background(), fiction, indices, printProgress); err != nil {
+		fmt.Printf("\nSome chapters failed to download: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println()
+
+	book, err := export.LoadBook(store, fiction, indices)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	enforceCacheQuota(store, fictionID)
+	return book
+}
+
+func checkCalibre() error {
+	_, err := export.CalibrePath()
+	return err
+}
+
+func init() {
+	exportCmd.PersistentFlags().StringVarP(&exportOutput, "output", "o", "", "Output file (default derived from the title)")
+	exportCmd.PersistentFlags().StringVar(&exportChapters, "chapters", "", "Chapters to export, e.g. 1-50 (default all)")
+	addDownloadFlags(exportCmd.PersistentFlags())
+
+	exportCmd.AddCommand(newExportCmd("epub", "epub", "Export as EPUB", nil, export.WriteEPUB))
+	exportCmd.AddCommand(newExportCmd("mobi", "mobi", "Export as MOBI (requires Calibre)", checkCalibre, export.WriteKindle))
+	exportCmd.AddCommand(newExportCmd("azw3", "azw3", "Export as AZW3 (requires Calibre)", checkCalibre, export.WriteKindle))
+	exportCmd.AddCommand(newExportCmd("txt", "txt", "Export as a single plain-text file", nil, export.WriteText))
+	exportCmd.AddCommand(newExportCmd("md", "", "Export as a directory of Markdown files with YAML front matter", nil, export.WriteMarkdown))
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/search"
+	"github.com/jackowfish/royal-road-cli/internal/ui"
+)
+
+var (
+	grepFiction       int
+	grepLimit         int
+	grepCaseSensitive bool
+)
+
+var grepCmd = &cobra.Command{
+	Use:   "grep [phrase]",
+	Short: "Search the text of every downloaded chapter",
+	Long: `Search the text of every downloaded chapter. Without a phrase the
+interactive library search is opened, where any hit can be opened in the reader
+at the page it appears on.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			runProgram(ui.NewLibrarySearchModel())
+			return
+		}
+
+		store := openCacheOrExit()
+		hits, err := search.Library(store, strings.Join(args, " "), search.Options{
+			FictionID:     grepFiction,
+			Limit:         grepLimit,
+			CaseSensitive: grepCaseSensitive,
+		})
+		if err != nil {
+			fmt.Printf("Error searching cache: %v\n", err)
+			os.Exit(1)
+		}
+		if len(hits) == 0 {
+			fmt.Println("No matches in downloaded chapters.")
+			return
+		}
+
+		for _, hit := range hits {
+			fmt.Printf("%s › Ch %d: %s (%d%%)  [%d]\n", hit.FictionTitle, hit.ChapterIndex+1, hit.ChapterTitle, int(hit.Position*100), hit.FictionID)
+			fmt.Printf("    %s\n", hit.Snippet)
+		}
+		if grepLimit > 0 && len(hits) == grepLimit {
+			fmt.Printf("\nStopped after %d matches (raise --limit for more).\n", grepLimit)
+		}
+	},
+}
+
+func init() {
+	grepCmd.Flags().IntVar(&grepFiction, "fiction", 0, "only search this fiction ID")
+	grepCmd.Flags().IntVar(&grepLimit, "limit", 50, "maximum number of matches to print (0 for all)")
+	grepCmd.Flags().BoolVar(&grepCaseSensitive, "case-sensitive", false, "match case exactly")
+	rootCmd.AddCommand(grepCmd)
+}
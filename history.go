@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+var (
+	historyOutput   string
+	historyArchived bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the reading history, or move it between machines",
+	Long: `List the books in the reading history, most recently read first. Once the
+history holds more than history.maxEntries books (500 by default, 0 for no
+limit) the least recently read move to history-archive.json beside
+config.json; --archived lists those instead. Pinned and starred books are
+never archived, and a book read again comes back with its notes, shelf and
+read chapters.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		entries := loadConfigOrExit().ReadingHistory
+		if historyArchived {
+			var err error
+			if entries, err = config.LoadArchive(); err != nil {
+				fmt.Printf("Error reading the history archive: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if len(entries) == 0 {
+			if historyArchived {
+				fmt.Println("The history archive is empty.")
+			} else {
+				fmt.Println("Your reading history is empty.")
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTITLE\tSHELF\tCHAPTER\tLAST READ")
+		for _, entry := range entries {
+			chapter := strconv.Itoa(entry.CurrentChapter + 1)
+			if entry.TotalChapters > 0 {
+				chapter += "/" + strconv.Itoa(entry.TotalChapters)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", entry.FictionID, entry.FictionTitle,
+				config.ShelfName(entry.CurrentShelf()), chapter, entry.LastRead)
+		}
+		w.Flush()
+	},
+}
+
+var historyExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the reading history as JSON",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfigOrExit()
+		data, err := json.MarshalIndent(cfg.ExportHistory(time.Now()), "", "  ")
+		if err != nil {
+			fmt.Printf("Error encoding history: %v\n", err)
+			os.Exit(1)
+		}
+		data = append(data, '\n')
+
+		if historyOutput == "" {
+			os.Stdout.Write(data)
+			return
+		}
+		if err := os.WriteFile(historyOutput, data, 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", historyOutput, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Exported %d books to %s\n", len(cfg.ReadingHistory), historyOutput)
+	},
+}
+
+var historyImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Merge a reading history exported on another machine",
+	Long: `Merge a file written by 'history export' into this machine's reading
+history. For books in both, whichever was read more recently wins; books only
+in the file are added. Use - to read from stdin.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			fmt.Printf("Error reading %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+
+		var exported config.HistoryExport
+		if err := json.Unmarshal(data, &exported); err != nil {
+			fmt.Printf("Error parsing %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+		if exported.Version > config.HistoryExportVersion {
+			fmt.Printf("Error: %s was written by a newer version of royal-road-cli\n", args[0])
+			os.Exit(1)
+		}
+
+		cfg := loadConfigOrExit()
+		added, updated := cfg.MergeHistory(exported.ReadingHistory)
+		if err := cfg.Save(); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Merged history: %d added, %d updated, %d unchanged\n",
+			added, updated, len(exported.ReadingHistory)-added-updated)
+	},
+}
+
+func init() {
+	historyCmd.Flags().BoolVar(&historyArchived, "archived", false, "list the archived books instead")
+	historyExportCmd.Flags().StringVarP(&historyOutput, "output", "o", "", "Output file (default stdout)")
+	historyCmd.AddCommand(historyExportCmd, historyImportCmd)
+	rootCmd.AddCommand(historyCmd)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/download"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+var importShelf string
+
+var importCmd = &cobra.Command{
+	Use:     "import [file]",
+	Aliases: []string{"import-ids"},
+	Short:   "Add a reading list of fiction IDs or URLs to your library",
+	Long: `Read fiction IDs or Royal Road URLs from a file, one per line, and add each
+fiction to your library with its title and author fetched from the site. They
+go on the Read Later shelf unless --shelf says otherwise. Blank lines and lines
+starting with # are ignored, so lists pasted from recommendation threads work
+with little cleanup. Use - to read from stdin.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		switch importShelf {
+		case config.ShelfLater, config.ShelfReading, config.ShelfPaused:
+		default:
+			fmt.Printf("Invalid shelf %q: use later, reading or paused\n", importShelf)
+			os.Exit(1)
+		}
+
+		ids, err := readFictionIDs(args[0])
+		if err != nil {
+			fmt.Printf("Error reading %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+		if len(ids) == 0 {
+			fmt.Println("No fiction IDs or URLs found.")
+			return
+		}
+
+		cfg := loadConfigOrExit()
+		client := network.NewClient(cfg)
+		delay := download.DefaultOptions().Delay
+
+		added, skipped, failed := 0, 0, 0
+		for i, id := range ids {
+			fictionID := strconv.Itoa(id)
+			if cfg.GetEntry(fictionID) != nil {
+				fmt.Printf("[%d/%d] %d: already in your history\n", i+1, len(ids), id)
+				skipped++
+				continue
+			}
+
+			if i > 0 {
+				time.Sleep(delay)
+			}
+			fiction, err := client.GetFiction(context.Background(), id)
+			if err != nil {
+				fmt.Printf("[%d/%d] %d: %v\n", i+1, len(ids), id, err)
+				failed++
+				continue
+			}
+
+			entry := config.ReadingEntry{
+				FictionID:     fictionID,
+				FictionTitle:  fiction.Title,
+				Author:        fiction.Author.Name,
+				TotalChapters: len(fiction.Chapters),
+			}
+			if len(fiction.Chapters) > 0 {
+				entry.ChapterTitle = fiction.Chapters[0].Title
+			}
+			cfg.AddToShelf(entry, importShelf)
+			fmt.Printf("[%d/%d] %s by %s\n", i+1, len(ids), fiction.Title, fiction.Author.Name)
+			added++
+		}
+
+		if err := cfg.Save(); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("\nAdded %d to the %s shelf (%d already known, %d failed)\n", added, importShelf, skipped, failed)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// readFictionIDs collects the fiction IDs from a file of IDs and URLs,
+// dropping duplicates but keeping the file's order.
+func readFictionIDs(path string) ([]int, error) {
+	file := os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	seen := make(map[int]bool)
+	var ids []int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		id, err := strconv.Atoi(line)
+		if err != nil {
+			fictionID, _, ok := royalroad.ParseURL(line)
+			if !ok {
+				fmt.Printf("Skipping unrecognised line: %s\n", line)
+				continue
+			}
+			id = fictionID
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, scanner.Err()
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importShelf, "shelf", config.ShelfLater, "shelf to add the fictions to: later, reading or paused")
+	rootCmd.AddCommand(importCmd)
+}
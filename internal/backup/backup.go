@@ -0,0 +1,92 @@
+// Package backup copies the offline library and reading progress to and from
+// cloud storage with rclone, which covers S3-compatible object stores as well
+// as every other rclone backend.
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ErrRcloneMissing is returned when rclone isn't installed.
+var ErrRcloneMissing = errors.New("rclone was not found in PATH; install it from https://rclone.org/install/ and set up a remote with 'rclone config'")
+
+// ErrNoRemote is returned when no backup remote has been configured.
+var ErrNoRemote = errors.New(`no backup remote configured; set "backup": {"remote": "name:path"} in config.json or pass --remote`)
+
+// Target describes what is backed up and where.
+type Target struct {
+	Remote     string // rclone remote path, e.g. "s3:my-bucket/royal-road"
+	CacheDir   string // Local fictions cache
+	ConfigPath string // Local config.json with reading progress
+	DryRun     bool   // Only report what would be transferred
+}
+
+func (t Target) fictionsRemote() string {
+	return strings.TrimSuffix(t.Remote, "/") + "/fictions"
+}
+
+func (t Target) configRemote() string {
+	return strings.TrimSuffix(t.Remote, "/") + "/config.json"
+}
+
+// Push uploads everything that changed since the last push. Files are never
+// deleted on the remote, and remote files newer than the local copy are left
+// alone, so a push from a stale machine can't roll progress back.
+func Push(target Target) error {
+	if err := target.check(); err != nil {
+		return err
+	}
+	if err := rclone(target, "copy", target.CacheDir, target.fictionsRemote()); err != nil {
+		return err
+	}
+	return rclone(target, "copyto", target.ConfigPath, target.configRemote())
+}
+
+// Pull downloads everything that changed on the remote, with the same rules as
+// Push in the other direction.
+func Pull(target Target) error {
+	if err := target.check(); err != nil {
+		return err
+	}
+	if err := rclone(target, "copy", target.fictionsRemote(), target.CacheDir); err != nil {
+		return err
+	}
+	return rclone(target, "copyto", target.configRemote(), target.ConfigPath)
+}
+
+func (t Target) check() error {
+	if t.Remote == "" {
+		return ErrNoRemote
+	}
+	if !strings.Contains(t.Remote, ":") {
+		return fmt.Errorf("backup remote %q should look like name:path", t.Remote)
+	}
+	return nil
+}
+
+// rclone runs one transfer. --update skips files that are newer at the
+// destination; rclone only copies files whose size or modification time
+// differ, which makes repeated backups incremental.
+func rclone(target Target, command, src, dst string) error {
+	path, err := exec.LookPath("rclone")
+	if err != nil {
+		return ErrRcloneMissing
+	}
+
+	args := []string{command, src, dst, "--update", "-v"}
+	if target.DryRun {
+		args = append(args, "--dry-run")
+	}
+
+	cmd := exec.Command(path, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("rclone %s %s %s failed: %w", command, src, dst, err)
+	}
+	return nil
+}
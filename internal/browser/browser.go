@@ -0,0 +1,32 @@
+// Package browser opens URLs in the user's default web browser.
+package browser
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+)
+
+// ErrUnsupported is returned when no way of opening a browser is available.
+var ErrUnsupported = errors.New("opening a browser needs xdg-open, open or rundll32")
+
+// Open opens url in the default browser without waiting for it.
+func Open(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return ErrUnsupported
+		}
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
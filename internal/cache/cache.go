@@ -0,0 +1,248 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jackowfish/royal-road-cli/internal/render"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// Store keeps downloaded fictions and chapters on disk so they can be read
+// without a network connection. The layout is:
+//
+//	<cache dir>/royal-road-cli/fictions/<fiction id>/fiction.json
+//	<cache dir>/royal-road-cli/fictions/<fiction id>/chapters/<chapter id>.json.gz
+//	<cache dir>/royal-road-cli/fictions/<fiction id>/manifest.json
+//
+// A Store is safe for concurrent use.
+type Store struct {
+	dir string
+	mu  sync.Mutex // guards manifest read-modify-write cycles
+}
+
+func Open() (*Store, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+
+	dir := filepath.Join(cacheDir, "royal-road-cli", "fictions")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &Store{dir: dir}, nil
+}
+
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+func (s *Store) fictionDir(fictionID int) string {
+	return filepath.Join(s.dir, strconv.Itoa(fictionID))
+}
+
+// Chapters are stored gzip-compressed; chapter HTML shrinks to roughly a
+// fifth of its size. Uncompressed .json files from older versions are still
+// read and are replaced the next time the chapter is saved.
+func (s *Store) chapterPath(fictionID, chapterID int) string {
+	return filepath.Join(s.fictionDir(fictionID), "chapters", strconv.Itoa(chapterID)+".json.gz")
+}
+
+func (s *Store) legacyChapterPath(fictionID, chapterID int) string {
+	return filepath.Join(s.fictionDir(fictionID), "chapters", strconv.Itoa(chapterID)+".json")
+}
+
+func (s *Store) SaveFiction(fiction *royalroad.Fiction) error {
+	return writeJSON(filepath.Join(s.fictionDir(fiction.ID), "fiction.json"), fiction)
+}
+
+func (s *Store) LoadFiction(fictionID int) (*royalroad.Fiction, error) {
+	fiction := &royalroad.Fiction{}
+	if err := readJSON(filepath.Join(s.fictionDir(fictionID), "fiction.json"), fiction); err != nil {
+		return nil, err
+	}
+	return fiction, nil
+}
+
+func (s *Store) HasFiction(fictionID int) bool {
+	_, err := os.Stat(filepath.Join(s.fictionDir(fictionID), "fiction.json"))
+	return err == nil
+}
+
+// SaveChapter stores a chapter and records its content hash. A chapter whose
+// content differs from the previously cached copy is flagged as edited.
+func (s *Store) SaveChapter(fictionID, chapterID int, chapter *royalroad.Chapter) error {
+	previous := s.cachedHash(fictionID, chapterID)
+	if err := writeGzipJSON(s.chapterPath(fictionID, chapterID), chapter); err != nil {
+		return err
+	}
+	_ = os.Remove(s.legacyChapterPath(fictionID, chapterID))
+	return s.recordHash(fictionID, chapterID, previous, ChapterHash(chapter), render.WordCount(chapter.Content))
+}
+
+func (s *Store) LoadChapter(fictionID, chapterID int) (*royalroad.Chapter, error) {
+	chapter := &royalroad.Chapter{}
+	err := readGzipJSON(s.chapterPath(fictionID, chapterID), chapter)
+	if os.IsNotExist(err) {
+		err = readJSON(s.legacyChapterPath(fictionID, chapterID), chapter)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return chapter, nil
+}
+
+func (s *Store) HasChapter(fictionID, chapterID int) bool {
+	if _, err := os.Stat(s.chapterPath(fictionID, chapterID)); err == nil {
+		return true
+	}
+	_, err := os.Stat(s.legacyChapterPath(fictionID, chapterID))
+	return err == nil
+}
+
+// Fictions returns the IDs of every fiction in the cache.
+func (s *Store) Fictions() ([]int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int
+	for _, entry := range entries {
+		if id, err := strconv.Atoi(entry.Name()); err == nil && entry.IsDir() && s.HasFiction(id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// Size returns the disk space used by one cached fiction in bytes.
+func (s *Store) Size(fictionID int) (int64, error) {
+	return dirSize(s.fictionDir(fictionID))
+}
+
+// TotalSize returns the disk space used by the whole cache in bytes.
+func (s *Store) TotalSize() (int64, error) {
+	return dirSize(s.dir)
+}
+
+// Remove evicts a fiction and all of its chapters from the cache.
+func (s *Store) Remove(fictionID int) error {
+	if !s.HasFiction(fictionID) {
+		return fmt.Errorf("fiction %d is not cached", fictionID)
+	}
+	return os.RemoveAll(s.fictionDir(fictionID))
+}
+
+// Clear evicts every cached fiction.
+func (s *Store) Clear() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(s.dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// CachedChapters returns the set of chapter IDs stored for a fiction.
+func (s *Store) CachedChapters(fictionID int) map[int]bool {
+	cached := make(map[int]bool)
+	files, err := os.ReadDir(filepath.Join(s.fictionDir(fictionID), "chapters"))
+	if err != nil {
+		return cached
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(strings.TrimSuffix(file.Name(), ".gz"), ".json")
+		if id, err := strconv.Atoi(name); err == nil {
+			cached[id] = true
+		}
+	}
+	return cached
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
+
+func writeGzipJSON(path string, v interface{}) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(v); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return writeFile(path, buf.Bytes())
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temp file first so an interrupted download never leaves a
+	// truncated chapter behind.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func readGzipJSON(path string, v interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	return json.NewDecoder(zr).Decode(v)
+}
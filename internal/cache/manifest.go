@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// manifest holds per-chapter bookkeeping for a cached fiction.
+type manifest struct {
+	Chapters map[int]chapterInfo `json:"chapters"`
+}
+
+type chapterInfo struct {
+	Hash   string `json:"hash"`
+	Edited bool   `json:"edited,omitempty"` // Content changed since it was first cached, not yet read
+	Words  int    `json:"words,omitempty"`
+}
+
+func (s *Store) manifestPath(fictionID int) string {
+	return filepath.Join(s.fictionDir(fictionID), "manifest.json")
+}
+
+func (s *Store) loadManifest(fictionID int) *manifest {
+	m := &manifest{}
+	if err := readJSON(s.manifestPath(fictionID), m); err != nil && !os.IsNotExist(err) {
+		// A damaged manifest only loses edit flags; start over
+		m = &manifest{}
+	}
+	if m.Chapters == nil {
+		m.Chapters = make(map[int]chapterInfo)
+	}
+	return m
+}
+
+// ChapterHash fingerprints everything a reader sees in a chapter.
+func ChapterHash(chapter *royalroad.Chapter) string {
+	sum := sha256.Sum256([]byte(chapter.PreNote + "\x00" + chapter.Content + "\x00" + chapter.PostNote))
+	return hex.EncodeToString(sum[:16])
+}
+
+// cachedHash returns the hash of the cached copy of a chapter, or "" if it
+// isn't cached. Chapters cached before hashes were recorded are hashed from
+// their stored content.
+func (s *Store) cachedHash(fictionID, chapterID int) string {
+	s.mu.Lock()
+	info, known := s.loadManifest(fictionID).Chapters[chapterID]
+	s.mu.Unlock()
+	if known {
+		return info.Hash
+	}
+
+	cached, err := s.LoadChapter(fictionID, chapterID)
+	if err != nil {
+		return ""
+	}
+	return ChapterHash(cached)
+}
+
+// recordHash stores a chapter's new hash and word count, flagging it as
+// edited if there was a different previous hash.
+func (s *Store) recordHash(fictionID, chapterID int, previous, hash string, words int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.loadManifest(fictionID)
+	info := m.Chapters[chapterID]
+	if info.Hash == hash && info.Words == words {
+		return nil
+	}
+	m.Chapters[chapterID] = chapterInfo{
+		Hash:   hash,
+		Edited: info.Edited || (previous != "" && previous != hash),
+		Words:  words,
+	}
+	return writeJSON(s.manifestPath(fictionID), m)
+}
+
+// ChapterChanged reports whether a freshly fetched chapter differs from the
+// cached copy.
+func (s *Store) ChapterChanged(fictionID, chapterID int, chapter *royalroad.Chapter) bool {
+	previous := s.cachedHash(fictionID, chapterID)
+	return previous != "" && previous != ChapterHash(chapter)
+}
+
+// EditedChapters returns the IDs of chapters the author changed after they
+// were cached and that haven't been read since.
+func (s *Store) EditedChapters(fictionID int) map[int]bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	edited := make(map[int]bool)
+	for id, info := range s.loadManifest(fictionID).Chapters {
+		if info.Edited {
+			edited[id] = true
+		}
+	}
+	return edited
+}
+
+// ClearEdited removes a chapter's edited flag, e.g. once it has been read.
+func (s *Store) ClearEdited(fictionID, chapterID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.loadManifest(fictionID)
+	info, ok := m.Chapters[chapterID]
+	if !ok || !info.Edited {
+		return nil
+	}
+	info.Edited = false
+	m.Chapters[chapterID] = info
+	return writeJSON(s.manifestPath(fictionID), m)
+}
+
+// ChapterWords returns the word counts of the cached chapters that have one,
+// by chapter ID.
+func (s *Store) ChapterWords(fictionID int) map[int]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	words := make(map[int]int)
+	for id, info := range s.loadManifest(fictionID).Chapters {
+		if info.Words > 0 {
+			words[id] = info.Words
+		}
+	}
+	return words
+}
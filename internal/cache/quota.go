@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"os"
+	"sort"
+	"time"
+)
+
+// EvictLRU removes whole fictions, least recently read first, until the cache
+// fits in limit bytes. lastRead reports when a fiction was last opened; the
+// zero time (never read) is evicted before anything else. Fictions listed in
+// keep are never evicted. It returns the IDs of the evicted fictions.
+func (s *Store) EvictLRU(limit int64, lastRead func(fictionID int) time.Time, keep ...int) ([]int, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	total, err := s.TotalSize()
+	if err != nil || total <= limit {
+		return nil, err
+	}
+
+	fictionIDs, err := s.Fictions()
+	if err != nil {
+		return nil, err
+	}
+	kept := make(map[int]bool, len(keep))
+	for _, id := range keep {
+		kept[id] = true
+	}
+
+	type candidate struct {
+		id       int
+		lastRead time.Time
+	}
+	var candidates []candidate
+	for _, id := range fictionIDs {
+		if !kept[id] {
+			candidates = append(candidates, candidate{id: id, lastRead: lastRead(id)})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].lastRead.Before(candidates[j].lastRead)
+	})
+
+	var evicted []int
+	for _, c := range candidates {
+		if total <= limit {
+			break
+		}
+		size, err := s.Size(c.id)
+		if err != nil {
+			return evicted, err
+		}
+		if err := os.RemoveAll(s.fictionDir(c.id)); err != nil {
+			return evicted, err
+		}
+		total -= size
+		evicted = append(evicted, c.id)
+	}
+	return evicted, nil
+}
@@ -0,0 +1,60 @@
+// Package clipboard copies text to the system clipboard.
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// tools are the clipboard programs tried in order on each platform.
+func tools() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	}
+	var tools [][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		tools = append(tools, []string{"wl-copy"})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		tools = append(tools, []string{"xclip", "-selection", "clipboard"}, []string{"xsel", "--clipboard", "--input"})
+	}
+	// Windows' clip.exe is on the PATH under WSL
+	return append(tools, []string{"clip.exe"})
+}
+
+// Copy puts text on the clipboard with the platform's clipboard tool, or
+// failing that with an OSC 52 escape sequence written to w, which most
+// terminals (and tmux) honor even over SSH.
+func Copy(w io.Writer, text string) error {
+	for _, tool := range tools() {
+		path, err := exec.LookPath(tool[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, tool[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err == nil {
+			return nil
+		}
+	}
+	return WriteOSC52(w, text)
+}
+
+// WriteOSC52 asks the terminal to set the clipboard, wrapped for tmux when
+// running inside it.
+func WriteOSC52(w io.Writer, text string) error {
+	sequence := fmt.Sprintf("\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	if os.Getenv("TMUX") != "" {
+		sequence = "\x1bPtmux;" + strings.ReplaceAll(sequence, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	_, err := io.WriteString(w, sequence)
+	return err
+}
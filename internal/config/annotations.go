@@ -0,0 +1,52 @@
+package config
+
+// Annotation is a highlighted passage of a chapter with an optional note.
+// StartWord and EndWord locate the passage by word offsets into the chapter
+// text, so it is found again at any text width. Start and End are the same
+// as fractions of the chapter, for annotations made before the word offsets
+// were kept.
+type Annotation struct {
+	FictionID    string  `json:"fictionId"`
+	FictionTitle string  `json:"fictionTitle"`
+	ChapterIndex int     `json:"chapterIndex"`
+	ChapterID    int     `json:"chapterId"`
+	ChapterTitle string  `json:"chapterTitle"`
+	Start        float64 `json:"start"`
+	End          float64 `json:"end"`
+	StartWord    int     `json:"startWord,omitempty"`
+	EndWord      int     `json:"endWord,omitempty"` // Zero if unknown
+	Text         string  `json:"text"`
+	Note         string  `json:"note,omitempty"`
+	CreatedAt    string  `json:"createdAt"`
+}
+
+// AddAnnotation stores an annotation, replacing one on the same passage.
+func (c *Config) AddAnnotation(annotation Annotation) {
+	for i, existing := range c.Annotations {
+		if existing.FictionID == annotation.FictionID && existing.ChapterIndex == annotation.ChapterIndex &&
+			existing.Text == annotation.Text {
+			c.Annotations[i] = annotation
+			return
+		}
+	}
+	c.Annotations = append(c.Annotations, annotation)
+}
+
+// RemoveAnnotationAt removes the i-th annotation.
+func (c *Config) RemoveAnnotationAt(i int) {
+	if i >= 0 && i < len(c.Annotations) {
+		c.Annotations = append(c.Annotations[:i], c.Annotations[i+1:]...)
+	}
+}
+
+// FictionAnnotations returns the annotations for a fiction in the order they
+// were made.
+func (c *Config) FictionAnnotations(fictionID string) []Annotation {
+	var annotations []Annotation
+	for _, annotation := range c.Annotations {
+		if annotation.FictionID == fictionID {
+			annotations = append(annotations, annotation)
+		}
+	}
+	return annotations
+}
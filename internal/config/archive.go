@@ -0,0 +1,150 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultHistoryLimit is how many books the history keeps in config.json
+// before the least recently read move to the archive.
+const DefaultHistoryLimit = 500
+
+// HistorySettings bound the reading history kept in config.json.
+type HistorySettings struct {
+	MaxEntries int `json:"maxEntries"` // Older books beyond this move to history-archive.json (0 for no limit)
+}
+
+// ArchivePath returns the location of the history archive, beside
+// config.json. It has the same format as 'history export', so 'history
+// import' can bring books back.
+func ArchivePath() (string, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "history-archive.json"), nil
+}
+
+// LoadArchive returns the archived books, most recently archived first. A
+// missing archive is empty.
+func LoadArchive() ([]ReadingEntry, error) {
+	path, err := ArchivePath()
+	if err != nil {
+		return nil, err
+	}
+	return readArchive(path)
+}
+
+func readArchive(path string) ([]ReadingEntry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var archive HistoryExport
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, err
+	}
+	return archive.ReadingHistory, nil
+}
+
+// overHistoryLimit reports whether the history has outgrown
+// History.MaxEntries, so archiveHistory has something to do.
+func (c *Config) overHistoryLimit() bool {
+	return c.History.MaxEntries > 0 && len(c.ReadingHistory) > c.History.MaxEntries
+}
+
+// archiveHistory moves the least recently read books beyond
+// History.MaxEntries to the archive, never a pinned or starred one. Load
+// calls it, rather than Save, so the archive is only read when the history
+// has grown past the limit.
+func (c *Config) archiveHistory() error {
+	if !c.overHistoryLimit() {
+		return nil
+	}
+	path, err := ArchivePath()
+	if err != nil {
+		return err
+	}
+	archived, err := readArchive(path)
+	if err != nil {
+		return err
+	}
+
+	limit := c.History.MaxEntries
+	room := limit
+	for _, entry := range c.ReadingHistory {
+		if entry.Pinned || entry.Starred {
+			room--
+		}
+	}
+	var moved []ReadingEntry
+	movedIDs := make(map[string]bool)
+	history := make([]ReadingEntry, 0, limit)
+	for _, entry := range c.ReadingHistory {
+		if entry.Pinned || entry.Starred || room > 0 {
+			if !entry.Pinned && !entry.Starred {
+				room--
+			}
+			history = append(history, entry)
+			continue
+		}
+		moved = append(moved, entry)
+		movedIDs[entry.FictionID] = true
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+	// An older copy of a book archived again is replaced
+	for _, old := range archived {
+		if !movedIDs[old.FictionID] {
+			moved = append(moved, old)
+		}
+	}
+
+	// Only drop books from the history once they are safely in the archive
+	if err := writeArchive(path, moved); err != nil {
+		return err
+	}
+	c.ReadingHistory = history
+	return nil
+}
+
+// restoreArchived takes a book out of the archive when it comes back to the
+// history, returning its whole entry (notes, star, pin, shelf and chapters
+// read), or nil if it isn't archived.
+func (c *Config) restoreArchived(fictionID string) *ReadingEntry {
+	path, err := ArchivePath()
+	if err != nil {
+		return nil
+	}
+	archived, err := readArchive(path)
+	if err != nil {
+		return nil
+	}
+	for i, entry := range archived {
+		if entry.FictionID == fictionID {
+			// If the archive can't be rewritten the old copy stays there too,
+			// and is replaced the next time the book is archived
+			_ = writeArchive(path, append(archived[:i:i], archived[i+1:]...))
+			return &entry
+		}
+	}
+	return nil
+}
+
+func writeArchive(path string, entries []ReadingEntry) error {
+	data, err := json.MarshalIndent(HistoryExport{
+		Version:        HistoryExportVersion,
+		ExportedAt:     time.Now().Format(time.RFC3339),
+		ReadingHistory: entries,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
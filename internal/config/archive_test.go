@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// useTempHome points the config, and the archive beside it, at a fresh
+// directory for the rest of the test.
+func useTempHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path, err := ArchivePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestArchiveHistory(t *testing.T) {
+	tests := []struct {
+		name         string
+		limit        int
+		history      []ReadingEntry
+		archived     []ReadingEntry // Already in the archive
+		wantHistory  string
+		wantArchived string
+	}{
+		{
+			name:         "under the limit",
+			limit:        3,
+			history:      []ReadingEntry{{FictionID: "1"}, {FictionID: "2"}},
+			wantHistory:  "1,2",
+			wantArchived: "",
+		},
+		{
+			name:         "no limit",
+			limit:        0,
+			history:      []ReadingEntry{{FictionID: "1"}, {FictionID: "2"}, {FictionID: "3"}},
+			wantHistory:  "1,2,3",
+			wantArchived: "",
+		},
+		{
+			name:         "least recent moved",
+			limit:        2,
+			history:      []ReadingEntry{{FictionID: "1"}, {FictionID: "2"}, {FictionID: "3"}, {FictionID: "4"}},
+			wantHistory:  "1,2",
+			wantArchived: "3,4",
+		},
+		{
+			name:         "pinned and starred kept",
+			limit:        3,
+			history:      []ReadingEntry{{FictionID: "1"}, {FictionID: "2"}, {FictionID: "3", Pinned: true}, {FictionID: "4"}, {FictionID: "5", Starred: true}},
+			wantHistory:  "1,3,5",
+			wantArchived: "2,4",
+		},
+		{
+			name:         "added in front of the archive",
+			limit:        1,
+			history:      []ReadingEntry{{FictionID: "1"}, {FictionID: "2"}},
+			archived:     []ReadingEntry{{FictionID: "9"}},
+			wantHistory:  "1",
+			wantArchived: "2,9",
+		},
+		{
+			name:         "older archived copy replaced",
+			limit:        1,
+			history:      []ReadingEntry{{FictionID: "1"}, {FictionID: "2", CurrentChapter: 7}},
+			archived:     []ReadingEntry{{FictionID: "9"}, {FictionID: "2", CurrentChapter: 3}},
+			wantHistory:  "1",
+			wantArchived: "2,9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := useTempHome(t)
+			if tt.archived != nil {
+				if err := writeArchive(path, tt.archived); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg := &Config{ReadingHistory: tt.history, History: HistorySettings{MaxEntries: tt.limit}}
+			if err := cfg.archiveHistory(); err != nil {
+				t.Fatalf("archiveHistory() error = %v", err)
+			}
+			if got := ids(cfg.ReadingHistory); got != tt.wantHistory {
+				t.Errorf("history = %s, want %s", got, tt.wantHistory)
+			}
+			archived, err := readArchive(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ids(archived); got != tt.wantArchived {
+				t.Errorf("archive = %s, want %s", got, tt.wantArchived)
+			}
+			for _, entry := range archived {
+				if entry.FictionID == "2" && entry.CurrentChapter == 3 {
+					t.Errorf("archive kept the older copy of fiction 2")
+				}
+			}
+		})
+	}
+}
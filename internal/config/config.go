@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -11,7 +13,20 @@ type Config struct {
 	Reading         Reading         `json:"reading"`
 	LastFiction     string          `json:"lastFiction"`
 	Bookmarks       []Bookmark      `json:"bookmarks"`
+	Annotations     []Annotation    `json:"annotations,omitempty"`
 	ReadingHistory  []ReadingEntry  `json:"readingHistory"`
+	ReadingOrders   []ReadingOrder  `json:"readingOrders"`
+	Queue           []QueueEntry    `json:"queue,omitempty"` // To-be-read list, next first
+	Sessions        []ReadingSession `json:"sessions"`
+	RecentFictionIDs []string       `json:"recentFictionIds"` // Most recent first
+	LastCleanup     string          `json:"lastCleanup,omitempty"` // When stale books were last reviewed
+	LastRefresh     string          `json:"lastRefresh,omitempty"` // When the history's chapter counts were last re-fetched
+	Backup          Backup          `json:"backup"`
+	Hooks           Hooks           `json:"hooks"`
+	Cache           CacheSettings   `json:"cache"`
+	History         HistorySettings `json:"history"`
+	HTTP            HTTPSettings    `json:"http"`
+	Translation     Translation     `json:"translation"`
 }
 
 type Theme struct {
@@ -24,15 +39,93 @@ type Reading struct {
 	TextWidth     int  `json:"textWidth"`
 	ShowProgress  bool `json:"showProgress"`
 	WrapText      bool `json:"wrapText"`
+	IdleMinutes   int  `json:"idleMinutes"` // Inactivity before reading time stops counting (0 disables)
+	Pager         string `json:"pager"`     // External pager command, e.g. "less -R" (empty uses the built-in pager)
+	AbandonMonths int    `json:"abandonMonths"` // Months unopened before a book is suggested for Paused/Dropped (0 disables)
+	WordsPerMinute int   `json:"wordsPerMinute"` // Reading speed used for time estimates (0 measures it as you read)
+	NewBookStart  string `json:"newBookStart"` // Where unread fictions open: "first", "latest" or "ask"
+	RSVPWordsPerMinute int `json:"rsvpWordsPerMinute"` // Speed of RSVP speed reading (0 uses 300)
+	ScrollMode    bool   `json:"scrollMode"`    // Scroll line by line instead of flipping pages
+	LineSpacing   int    `json:"lineSpacing"`   // Blank lines between the lines of a paragraph (0 for single spacing)
+	ParagraphGap  int    `json:"paragraphGap"`  // Blank lines between paragraphs
+	ParagraphIndent int  `json:"paragraphIndent"` // Spaces before the first line of each paragraph
+	ImageProtocol string `json:"imageProtocol"` // Inline images: "kitty", "iterm", "sixel", "none" or "auto" (empty detects the terminal)
+	QuotesFile    string `json:"quotesFile"`    // Markdown file quotes are captured to (empty for quotes.md next to config.json)
+	TTSEngine     string `json:"ttsEngine"`     // Text-to-speech program: "say", "sapi", "espeak-ng" or "piper" (empty picks one)
+	TTSVoice      string `json:"ttsVoice"`      // Voice name, or the .onnx model file for piper (empty for the default)
+	TTSWordsPerMinute int `json:"ttsWordsPerMinute"` // Speaking speed (0 uses 180)
+	AuthorNotes   string `json:"authorNotes"`   // Author's notes: "show" (default), "collapse" to one line, or "hide"
 }
 
+// Backup configures where 'backup push' and 'backup pull' copy the library.
+type Backup struct {
+	Remote string `json:"remote"` // rclone remote path, e.g. "s3:my-bucket/royal-road" (empty disables)
+}
+
+// CacheSettings bound the offline chapter cache.
+type CacheSettings struct {
+	MaxSizeMB int `json:"maxSizeMB"` // Least recently read fictions are evicted above this (0 for no limit)
+}
+
+// Limit returns the cache size limit in bytes, or 0 for no limit.
+func (c CacheSettings) Limit() int64 {
+	return int64(max(c.MaxSizeMB, 0)) << 20
+}
+
+// HTTPSettings configure requests to Royal Road.
+type HTTPSettings struct {
+	CacheMinutes      int               `json:"cacheMinutes"`      // Pages fetched again within this are read from disk without asking the site (0 disables the HTTP cache)
+	RequestsPerSecond float64           `json:"requestsPerSecond"` // Most requests sent per second, across every screen and download (0 for no limit)
+	MaxRetries        int               `json:"maxRetries"`        // Retries of requests refused with 429, failed with a 5xx or timed out (0 disables)
+	UserAgent         string            `json:"userAgent"`         // User-Agent header (empty identifies as royal-road-cli)
+	Proxy             string            `json:"proxy"`             // http://, https:// or socks5:// proxy URL (empty uses $HTTPS_PROXY and friends)
+	Headers           map[string]string `json:"headers"`           // Extra headers sent with every request
+}
+
+// CacheTTL returns how long fetched pages are reused, or 0 if they aren't.
+func (h HTTPSettings) CacheTTL() time.Duration {
+	return time.Duration(max(h.CacheMinutes, 0)) * time.Minute
+}
+
+// Hooks are shell commands run on library events; see package hooks for the
+// environment variables each one receives.
+type Hooks struct {
+	NewChapters []string `json:"newChapters"` // Run once per fiction found to have new chapters
+}
+
+// Translation configures the T key in the reader.
+type Translation struct {
+	Backend        string `json:"backend"`        // "libretranslate" or "deepl" (empty disables)
+	URL            string `json:"url"`            // API base URL, for a self-hosted LibreTranslate (empty uses the public service)
+	APIKey         string `json:"apiKey"`
+	TargetLanguage string `json:"targetLanguage"` // Language code, e.g. "en" or "de"
+	Display        string `json:"display"`        // "overlay" replaces the page, "side" shows both side by side
+}
+
+// Values for Reading.AuthorNotes.
+const (
+	NotesShow     = "show"
+	NotesCollapse = "collapse"
+	NotesHide     = "hide"
+)
+
+// Values for Reading.NewBookStart.
+const (
+	StartFirst  = "first"
+	StartLatest = "latest"
+	StartAsk    = "ask"
+)
+
 type Bookmark struct {
-	FictionID    string `json:"fictionId"`
-	FictionTitle string `json:"fictionTitle"`
-	ChapterIndex int    `json:"chapterIndex"`
-	ChapterTitle string `json:"chapterTitle"`
-	Position     int    `json:"position"`
-	CreatedAt    string `json:"createdAt"`
+	FictionID    string  `json:"fictionId"`
+	FictionTitle string  `json:"fictionTitle"`
+	ChapterIndex int     `json:"chapterIndex"`
+	ChapterTitle string  `json:"chapterTitle"`
+	Position     int     `json:"position"`             // Page within the chapter (0-based)
+	Progress     float64 `json:"progress,omitempty"`   // Position within the chapter (0.0-1.0), kept across resizes
+	WordOffset   int     `json:"wordOffset,omitempty"` // Words of the chapter text above the bookmark; survives re-wrapping
+	Label        string  `json:"label,omitempty"`
+	CreatedAt    string  `json:"createdAt"`
 }
 
 type ReadingEntry struct {
@@ -42,8 +135,22 @@ type ReadingEntry struct {
 	CurrentChapter int     `json:"currentChapter"`
 	ChapterTitle   string  `json:"chapterTitle"`
 	ChapterProgress float64 `json:"chapterProgress"`  // Percentage through chapter (0.0-1.0)
+	ChapterWordOffset int   `json:"chapterWordOffset,omitempty"` // Words of the chapter text above the saved spot; survives re-wrapping
 	LastRead       string  `json:"lastRead"`
 	TotalChapters  int     `json:"totalChapters"`
+	Shelf          string  `json:"shelf,omitempty"` // See ShelfReading and friends; empty means reading
+	NotifiedChapters int   `json:"notifiedChapters,omitempty"` // Chapter count check-updates last ran hooks for
+	ReadChapters   []int   `json:"readChapters"` // IDs of the chapters read; null for entries from before this was tracked
+	FinishedAt     string  `json:"finishedAt,omitempty"`  // When the current read-through was finished
+	Completions    []Completion `json:"completions,omitempty"` // Earlier read-throughs, kept when starting over
+	AddedAt        string  `json:"addedAt,omitempty"`   // When the fiction joined the history
+	ShelvedAt      string  `json:"shelvedAt,omitempty"` // When it was last moved to another shelf
+	Pinned         bool    `json:"pinned,omitempty"`    // Listed first in the history screen
+	Notes          string  `json:"notes,omitempty"`     // The reader's own notes on the book
+	Starred        bool    `json:"starred,omitempty"`   // Local favorite, separate from Royal Road's
+	LiveChapters   int     `json:"liveChapters,omitempty"`  // Chapter count on the site as of the last refresh
+	FictionStatus  string  `json:"fictionStatus,omitempty"` // e.g. ONGOING or COMPLETED, as of the last refresh
+	LatestChapter  string  `json:"latestChapter,omitempty"` // Title of the newest chapter, as of the last refresh
 }
 
 func DefaultConfig() *Config {
@@ -57,10 +164,20 @@ func DefaultConfig() *Config {
 			TextWidth:    78,
 			ShowProgress: true,
 			WrapText:     true,
+			IdleMinutes:  5,
+			AbandonMonths: 3,
+			WordsPerMinute: 250,
+			NewBookStart:  StartFirst,
+			ParagraphGap:  1,
 		},
 		LastFiction:    "",
 		Bookmarks:      []Bookmark{},
 		ReadingHistory: []ReadingEntry{},
+		ReadingOrders:  []ReadingOrder{},
+		Sessions:       []ReadingSession{},
+		RecentFictionIDs: []string{},
+		History:        HistorySettings{MaxEntries: DefaultHistoryLimit},
+		HTTP:           HTTPSettings{CacheMinutes: 10, RequestsPerSecond: 2, MaxRetries: 3},
 	}
 }
 
@@ -85,6 +202,12 @@ func Load() (*Config, error) {
 	if err := json.Unmarshal(data, config); err != nil {
 		return DefaultConfig(), err
 	}
+	if config.overHistoryLimit() {
+		// If the archive can't be written, everything stays in config.json
+		if config.archiveHistory() == nil {
+			_ = config.Save()
+		}
+	}
 
 	return config, nil
 }
@@ -99,7 +222,6 @@ func (c *Config) Save() error {
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return err
 	}
-
 	data, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
 		return err
@@ -108,9 +230,10 @@ func (c *Config) Save() error {
 	return os.WriteFile(configPath, data, 0644)
 }
 
+// AddBookmark adds a bookmark, replacing any on the same page.
 func (c *Config) AddBookmark(bookmark Bookmark) {
 	for i, existing := range c.Bookmarks {
-		if existing.FictionID == bookmark.FictionID && existing.ChapterIndex == bookmark.ChapterIndex {
+		if existing.FictionID == bookmark.FictionID && existing.ChapterIndex == bookmark.ChapterIndex && existing.Position == bookmark.Position {
 			c.Bookmarks[i] = bookmark
 			return
 		}
@@ -118,37 +241,96 @@ func (c *Config) AddBookmark(bookmark Bookmark) {
 	c.Bookmarks = append(c.Bookmarks, bookmark)
 }
 
+// RemoveBookmark removes every bookmark in a chapter.
 func (c *Config) RemoveBookmark(fictionID string, chapterIndex int) {
-	for i, bookmark := range c.Bookmarks {
-		if bookmark.FictionID == fictionID && bookmark.ChapterIndex == chapterIndex {
-			c.Bookmarks = append(c.Bookmarks[:i], c.Bookmarks[i+1:]...)
-			return
+	kept := c.Bookmarks[:0]
+	for _, bookmark := range c.Bookmarks {
+		if bookmark.FictionID != fictionID || bookmark.ChapterIndex != chapterIndex {
+			kept = append(kept, bookmark)
 		}
 	}
+	c.Bookmarks = kept
+}
+
+// RemoveBookmarkAt removes the i-th bookmark.
+func (c *Config) RemoveBookmarkAt(i int) {
+	if i >= 0 && i < len(c.Bookmarks) {
+		c.Bookmarks = append(c.Bookmarks[:i], c.Bookmarks[i+1:]...)
+	}
 }
 
+// UpdateReadingProgress records where a fiction is being read and moves it
+// to the front of the history. An existing entry only takes the progress
+// fields from entry; its shelf, chapters read, notes and the rest stay as
+// they are.
 func (c *Config) UpdateReadingProgress(entry ReadingEntry) {
 	// Update existing entry or add new one
-	for i, existing := range c.ReadingHistory {
-		if existing.FictionID == entry.FictionID {
-			// Update existing entry and move to front (most recent)
-			c.ReadingHistory[i] = entry
-			if i != 0 {
-				// Move to front
-				c.ReadingHistory = append([]ReadingEntry{entry}, append(c.ReadingHistory[:i], c.ReadingHistory[i+1:]...)...)
-			}
-			c.LastFiction = entry.FictionID
-			return
+	for i := range c.ReadingHistory {
+		existing := &c.ReadingHistory[i]
+		if existing.FictionID != entry.FictionID {
+			continue
 		}
+		existing.FictionTitle = entry.FictionTitle
+		existing.Author = entry.Author
+		existing.CurrentChapter = entry.CurrentChapter
+		existing.ChapterTitle = entry.ChapterTitle
+		existing.ChapterProgress = entry.ChapterProgress
+		existing.ChapterWordOffset = entry.ChapterWordOffset
+		existing.LastRead = entry.LastRead
+		existing.TotalChapters = entry.TotalChapters
+		if entry.LiveChapters > 0 {
+			existing.LiveChapters = entry.LiveChapters
+			existing.FictionStatus = entry.FictionStatus
+			existing.LatestChapter = entry.LatestChapter
+		}
+		
+		if i != 0 {
+			// Move to front (most recent)
+			updated := *existing
+			c.ReadingHistory = append([]ReadingEntry{updated}, append(c.ReadingHistory[:i], c.ReadingHistory[i+1:]...)...)
+		}
+		c.LastFiction = entry.FictionID
+		return
+	}
+	
+	if archived := c.restoreArchived(entry.FictionID); archived != nil {
+		// A book read again after being archived keeps what was recorded
+		c.ReadingHistory = append([]ReadingEntry{*archived}, c.ReadingHistory...)
+		c.UpdateReadingProgress(entry)
+		return
 	}
 	
 	// Add new entry at the beginning (most recent first)
+	if entry.AddedAt == "" {
+		entry.AddedAt = time.Now().Format(TimeLayout)
+	}
 	c.ReadingHistory = append([]ReadingEntry{entry}, c.ReadingHistory...)
 	c.LastFiction = entry.FictionID
 }
 
+// maxRecentFictionIDs bounds how many typed fiction IDs are remembered.
+const maxRecentFictionIDs = 20
+
+// AddRecentFictionID remembers a fiction ID entered by hand, moving it to the
+// front if it was already known.
+func (c *Config) AddRecentFictionID(fictionID string) {
+	recent := []string{fictionID}
+	for _, id := range c.RecentFictionIDs {
+		if id != fictionID && len(recent) < maxRecentFictionIDs {
+			recent = append(recent, id)
+		}
+	}
+	c.RecentFictionIDs = recent
+}
+
 func (c *Config) GetReadingHistoryPage(page, pageSize int) ([]ReadingEntry, int, bool, bool) {
-	total := len(c.ReadingHistory)
+	return PageEntries(c.ReadingHistory, page, pageSize)
+}
+
+// PageEntries returns one page of entries along with the total page count and
+// whether there are next/previous pages. Pages are 1-based.
+func PageEntries(entries []ReadingEntry, page, pageSize int) ([]ReadingEntry, int, bool, bool) {
+	total := len(entries)
 	if total == 0 {
 		return []ReadingEntry{}, 0, false, false
 	}
@@ -170,16 +352,52 @@ func (c *Config) GetReadingHistoryPage(page, pageSize int) ([]ReadingEntry, int,
 	hasNext := page < totalPages
 	hasPrev := page > 1
 	
-	return c.ReadingHistory[start:end], totalPages, hasNext, hasPrev
+	return entries[start:end], totalPages, hasNext, hasPrev
 }
 
+// GetLastReadEntry returns the most recently read fiction that is still on
+// the reading shelf.
 func (c *Config) GetLastReadEntry() *ReadingEntry {
-	if len(c.ReadingHistory) > 0 {
-		return &c.ReadingHistory[0]
+	for i, entry := range c.ReadingHistory {
+		if entry.CurrentShelf() == ShelfReading {
+			return &c.ReadingHistory[i]
+		}
 	}
 	return nil
 }
 
+// Path returns the location of config.json, which also holds reading progress.
+func Path() (string, error) {
+	return getConfigPath()
+}
+
+// QuotesPath returns where captured quotes are appended: Reading.QuotesFile,
+// with a leading ~ expanded, or quotes.md beside config.json.
+func (c *Config) QuotesPath() (string, error) {
+	path := c.Reading.QuotesFile
+	if path == "" {
+		configPath, err := getConfigPath()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(filepath.Dir(configPath), "quotes.md"), nil
+	}
+	return ExpandHome(path)
+}
+
+// ExpandHome expands a leading ~ that the shell left alone, as in a config
+// value or --out=~/notes.
+func ExpandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, path[1:]), nil
+}
+
 func getConfigPath() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// TogglePin pins a fiction to the top of the history screen, or unpins it,
+// reporting whether it is now pinned.
+func (c *Config) TogglePin(fictionID string) bool {
+	entry := c.GetEntry(fictionID)
+	if entry == nil {
+		return false
+	}
+	entry.Pinned = !entry.Pinned
+	return entry.Pinned
+}
+
+// ToggleStar stars a fiction in the history, or unstars it, reporting
+// whether it is now starred.
+func (c *Config) ToggleStar(fictionID string) bool {
+	entry := c.GetEntry(fictionID)
+	if entry == nil {
+		return false
+	}
+	entry.Starred = !entry.Starred
+	return entry.Starred
+}
+
+// PinnedFirst moves pinned entries ahead of the rest, keeping the order
+// within each group.
+func PinnedFirst(entries []ReadingEntry) []ReadingEntry {
+	sorted := make([]ReadingEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Pinned {
+			sorted = append(sorted, entry)
+		}
+	}
+	for _, entry := range entries {
+		if !entry.Pinned {
+			sorted = append(sorted, entry)
+		}
+	}
+	return sorted
+}
+
+// ClearHistory forgets every book in the reading history. Bookmarks,
+// annotations and stats are kept.
+func (c *Config) ClearHistory() {
+	c.ReadingHistory = []ReadingEntry{}
+	c.LastFiction = ""
+}
+
+// Orders the history screen can be sorted in.
+const (
+	SortRecent   = "last read"
+	SortTitle    = "title"
+	SortAuthor   = "author"
+	SortProgress = "progress"
+	SortShelf    = "shelf" // Grouped in ShelfOrder, pinned books first in each
+)
+
+// HistorySorts lists the sort orders in the order the history cycles them.
+var HistorySorts = []string{SortRecent, SortShelf, SortTitle, SortAuthor, SortProgress}
+
+// Which entries the history screen shows.
+const (
+	StatusAll        = "all"
+	StatusInProgress = "in progress"
+	StatusFinished   = "finished"
+	StatusStarred    = "starred"
+)
+
+// HistoryStatuses lists the status filters in the order the history cycles them.
+var HistoryStatuses = []string{StatusAll, StatusInProgress, StatusFinished, StatusStarred}
+
+// Completed returns how far through the book the reader is, from 0 to 1.
+// Finished books count as complete.
+func (e ReadingEntry) Completed() float64 {
+	if e.CurrentShelf() == ShelfFinished {
+		return 1
+	}
+	if e.TotalChapters <= 0 {
+		return 0
+	}
+	return min((float64(e.CurrentChapter)+e.ChapterProgress)/float64(e.TotalChapters), 1)
+}
+
+// SortEntries returns a copy of entries in the given order. The history is
+// already most recent first, so SortRecent keeps the order as is; ties in
+// the other orders are broken the same way.
+func SortEntries(entries []ReadingEntry, order string) []ReadingEntry {
+	sorted := append([]ReadingEntry(nil), entries...)
+	var less func(a, b ReadingEntry) bool
+	switch order {
+	case SortTitle:
+		less = func(a, b ReadingEntry) bool {
+			return strings.ToLower(a.FictionTitle) < strings.ToLower(b.FictionTitle)
+		}
+	case SortAuthor:
+		less = func(a, b ReadingEntry) bool {
+			return strings.ToLower(a.Author) < strings.ToLower(b.Author)
+		}
+	case SortShelf:
+		less = func(a, b ReadingEntry) bool {
+			if shelfRank(a) != shelfRank(b) {
+				return shelfRank(a) < shelfRank(b)
+			}
+			return a.Pinned && !b.Pinned
+		}
+	case SortProgress:
+		// Furthest along first
+		less = func(a, b ReadingEntry) bool {
+			return a.Completed() > b.Completed()
+		}
+	default:
+		return sorted
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+func shelfRank(entry ReadingEntry) int {
+	for i, shelf := range ShelfOrder {
+		if entry.CurrentShelf() == shelf {
+			return i
+		}
+	}
+	return len(ShelfOrder)
+}
+
+// FilterByStatus keeps the entries matching one of the HistoryStatuses.
+func FilterByStatus(entries []ReadingEntry, status string) []ReadingEntry {
+	if status == StatusAll || status == "" {
+		return entries
+	}
+	var filtered []ReadingEntry
+	for _, entry := range entries {
+		if status == StatusStarred {
+			if entry.Starred {
+				filtered = append(filtered, entry)
+			}
+		} else if (entry.CurrentShelf() == ShelfFinished) == (status == StatusFinished) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// FindByTitle returns the history entries whose title is query, ignoring
+// case, or failing that those whose title contains it.
+func (c *Config) FindByTitle(query string) []ReadingEntry {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	var exact, partial []ReadingEntry
+	for _, entry := range c.ReadingHistory {
+		title := strings.ToLower(entry.FictionTitle)
+		switch {
+		case title == query:
+			exact = append(exact, entry)
+		case strings.Contains(title, query):
+			partial = append(partial, entry)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+	return partial
+}
+
+// SetBookNotes replaces the notes kept on a fiction in the history,
+// reporting whether it's there.
+func (c *Config) SetBookNotes(fictionID, notes string) bool {
+	entry := c.GetEntry(fictionID)
+	if entry == nil {
+		return false
+	}
+	entry.Notes = notes
+	return true
+}
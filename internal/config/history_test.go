@@ -0,0 +1,109 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// ids lists the fiction IDs of entries, joined for easy comparison.
+func ids(entries []ReadingEntry) string {
+	var list []string
+	for _, entry := range entries {
+		list = append(list, entry.FictionID)
+	}
+	return strings.Join(list, ",")
+}
+
+func TestFilterByStatus(t *testing.T) {
+	entries := []ReadingEntry{
+		{FictionID: "1"},
+		{FictionID: "2", Shelf: ShelfFinished},
+		{FictionID: "3", Shelf: ShelfPaused, Starred: true},
+		{FictionID: "4", Shelf: ShelfFinished, Starred: true},
+		{FictionID: "5", Shelf: ShelfLater},
+	}
+
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{status: StatusAll, want: "1,2,3,4,5"},
+		{status: "", want: "1,2,3,4,5"},
+		{status: StatusInProgress, want: "1,3,5"},
+		{status: StatusFinished, want: "2,4"},
+		{status: StatusStarred, want: "3,4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := ids(FilterByStatus(entries, tt.status)); got != tt.want {
+				t.Errorf("FilterByStatus(%q) = %s, want %s", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortEntries(t *testing.T) {
+	entries := []ReadingEntry{
+		{FictionID: "1", FictionTitle: "beneath the dragoneye moons", Author: "Selkie", Shelf: ShelfFinished, CurrentChapter: 0, TotalChapters: 10},
+		{FictionID: "2", FictionTitle: "Azarinth Healer", Author: "Rhaegar", Shelf: ShelfPaused, CurrentChapter: 5, TotalChapters: 10},
+		{FictionID: "3", FictionTitle: "Cradle", Author: "will wight", CurrentChapter: 8, ChapterProgress: 0.5, TotalChapters: 10},
+		{FictionID: "4", FictionTitle: "Delve", Author: "SenescentSoul", Pinned: true, CurrentChapter: 2, TotalChapters: 10},
+		{FictionID: "5", FictionTitle: "azarinth healer", Author: "rhaegar", CurrentChapter: 0, TotalChapters: 0},
+	}
+
+	tests := []struct {
+		order string
+		want  string
+	}{
+		{order: SortRecent, want: "1,2,3,4,5"},
+		{order: "unknown", want: "1,2,3,4,5"},
+		// Ties, ignoring case, keep the most recent first
+		{order: SortTitle, want: "2,5,1,3,4"},
+		{order: SortAuthor, want: "2,5,1,4,3"},
+		// Finished counts as all the way through; no chapters as none
+		{order: SortProgress, want: "1,3,2,4,5"},
+		{order: SortShelf, want: "4,3,5,2,1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			if got := ids(SortEntries(entries, tt.order)); got != tt.want {
+				t.Errorf("SortEntries(%q) = %s, want %s", tt.order, got, tt.want)
+			}
+		})
+	}
+
+	if got := ids(entries); got != "1,2,3,4,5" {
+		t.Errorf("SortEntries changed its argument to %s", got)
+	}
+}
+
+func TestFindByTitle(t *testing.T) {
+	cfg := &Config{ReadingHistory: []ReadingEntry{
+		{FictionID: "1", FictionTitle: "Mother of Learning"},
+		{FictionID: "2", FictionTitle: "The Wandering Inn"},
+		{FictionID: "3", FictionTitle: "Learning Curve"},
+		{FictionID: "4", FictionTitle: "mother of learning"},
+	}}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "Mother of Learning", want: "1,4"},
+		{query: "  MOTHER OF LEARNING ", want: "1,4"},
+		{query: "learning", want: "1,3,4"},
+		{query: "Inn", want: "2"},
+		{query: "Cradle", want: ""},
+		{query: "   ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := ids(cfg.FindByTitle(tt.query)); got != tt.want {
+				t.Errorf("FindByTitle(%q) = %s, want %s", tt.query, got, tt.want)
+			}
+		})
+	}
+}
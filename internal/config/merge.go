@@ -0,0 +1,92 @@
+package config
+
+import (
+	"sort"
+	"time"
+)
+
+// HistoryExport is the file format of 'history export'.
+type HistoryExport struct {
+	Version        int            `json:"version"`
+	ExportedAt     string         `json:"exportedAt"`
+	ReadingHistory []ReadingEntry `json:"readingHistory"`
+}
+
+// HistoryExportVersion is bumped whenever HistoryExport changes incompatibly.
+const HistoryExportVersion = 1
+
+// ExportHistory snapshots the reading history for another machine, or just
+// the given fictions in it.
+func (c *Config) ExportHistory(now time.Time, fictionIDs ...string) HistoryExport {
+	entries := c.ReadingHistory
+	if len(fictionIDs) > 0 {
+		entries = nil
+		for _, id := range fictionIDs {
+			if entry := c.GetEntry(id); entry != nil {
+				entries = append(entries, *entry)
+			}
+		}
+	}
+	return HistoryExport{
+		Version:        HistoryExportVersion,
+		ExportedAt:     now.Format(time.RFC3339),
+		ReadingHistory: entries,
+	}
+}
+
+// MergeHistory folds entries from another machine into the history. For a
+// fiction on both sides the entry read most recently wins, though chapters
+// read on either side stay read; fictions only in entries are added. The
+// history is then re-ordered most recent first, with never-read entries kept
+// at the end in their existing order.
+func (c *Config) MergeHistory(entries []ReadingEntry) (added, updated int) {
+	for _, incoming := range entries {
+		if incoming.FictionID == "" {
+			continue
+		}
+		existing := c.GetEntry(incoming.FictionID)
+		if existing == nil {
+			c.ReadingHistory = append(c.ReadingHistory, incoming)
+			added++
+			continue
+		}
+		read := mergeReadChapters(existing.ReadChapters, incoming.ReadChapters)
+		if entryTime(incoming).After(entryTime(*existing)) {
+			*existing = incoming
+			updated++
+		}
+		existing.ReadChapters = read
+	}
+
+	sort.SliceStable(c.ReadingHistory, func(i, j int) bool {
+		return entryTime(c.ReadingHistory[i]).After(entryTime(c.ReadingHistory[j]))
+	})
+	if len(c.ReadingHistory) > 0 {
+		c.LastFiction = c.ReadingHistory[0].FictionID
+	}
+	return added, updated
+}
+
+// mergeReadChapters combines two lists of chapters read.
+func mergeReadChapters(a, b []int) []int {
+	if a == nil && b == nil {
+		return nil
+	}
+	merged := append([]int{}, a...)
+	seen := make(map[int]bool, len(a))
+	for _, id := range a {
+		seen[id] = true
+	}
+	for _, id := range b {
+		if !seen[id] {
+			merged = append(merged, id)
+			seen[id] = true
+		}
+	}
+	return merged
+}
+
+func entryTime(entry ReadingEntry) time.Time {
+	lastRead, _ := time.ParseInLocation(TimeLayout, entry.LastRead, time.Local)
+	return lastRead
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ReadingOrder is an ordered list of related fictions (a series, its side
+// stories, ...) that can be shared as a JSON or Markdown manifest.
+type ReadingOrder struct {
+	Name    string             `json:"name"`
+	Entries []ReadingOrderItem `json:"entries"`
+}
+
+type ReadingOrderItem struct {
+	FictionID string `json:"fictionId"`
+	Title     string `json:"title"`
+	Note      string `json:"note,omitempty"`
+}
+
+var fictionURLRegex = regexp.MustCompile(`royalroad\.com/fiction/(\d+)`)
+
+// AddReadingOrder stores an order, replacing any existing order with the same name.
+func (c *Config) AddReadingOrder(order ReadingOrder) {
+	for i, existing := range c.ReadingOrders {
+		if strings.EqualFold(existing.Name, order.Name) {
+			c.ReadingOrders[i] = order
+			return
+		}
+	}
+	c.ReadingOrders = append(c.ReadingOrders, order)
+}
+
+func (c *Config) GetReadingOrder(name string) *ReadingOrder {
+	for i, order := range c.ReadingOrders {
+		if strings.EqualFold(order.Name, name) {
+			return &c.ReadingOrders[i]
+		}
+	}
+	return nil
+}
+
+// NextInReadingOrder returns the order containing fictionID and the entry that
+// follows it, or nil if the fiction is last (or not part of any order).
+func (c *Config) NextInReadingOrder(fictionID string) (*ReadingOrder, *ReadingOrderItem) {
+	for i, order := range c.ReadingOrders {
+		for j, item := range order.Entries {
+			if item.FictionID == fictionID && j < len(order.Entries)-1 {
+				return &c.ReadingOrders[i], &c.ReadingOrders[i].Entries[j+1]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Markdown renders the order as a numbered list of fiction links.
+func (o *ReadingOrder) Markdown() string {
+	var md strings.Builder
+	md.WriteString(fmt.Sprintf("# %s\n\n", o.Name))
+	for i, item := range o.Entries {
+		md.WriteString(fmt.Sprintf("%d. [%s](https://www.royalroad.com/fiction/%s)", i+1, item.Title, item.FictionID))
+		if item.Note != "" {
+			md.WriteString(" — " + item.Note)
+		}
+		md.WriteString("\n")
+	}
+	return md.String()
+}
+
+// ParseReadingOrder reads a manifest in either the JSON format written by
+// export or a Markdown list containing Royal Road fiction links.
+func ParseReadingOrder(data []byte) (*ReadingOrder, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "{") {
+		order := &ReadingOrder{}
+		if err := json.Unmarshal(data, order); err != nil {
+			return nil, fmt.Errorf("invalid reading order JSON: %w", err)
+		}
+		if len(order.Entries) == 0 {
+			return nil, fmt.Errorf("reading order has no entries")
+		}
+		return order, nil
+	}
+
+	order := &ReadingOrder{}
+	linkRegex := regexp.MustCompile(`\[([^\]]*)\]`)
+	for _, line := range strings.Split(trimmed, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") && order.Name == "" {
+			order.Name = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+			continue
+		}
+
+		matches := fictionURLRegex.FindStringSubmatch(line)
+		if len(matches) < 2 {
+			continue
+		}
+
+		item := ReadingOrderItem{FictionID: matches[1]}
+		if title := linkRegex.FindStringSubmatch(line); len(title) > 1 {
+			item.Title = title[1]
+		}
+		if idx := strings.Index(line, " — "); idx >= 0 {
+			item.Note = strings.TrimSpace(line[idx+len(" — "):])
+		}
+		order.Entries = append(order.Entries, item)
+	}
+
+	if len(order.Entries) == 0 {
+		return nil, fmt.Errorf("no Royal Road fiction links found")
+	}
+	if order.Name == "" {
+		order.Name = "Imported order"
+	}
+	return order, nil
+}
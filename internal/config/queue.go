@@ -0,0 +1,67 @@
+package config
+
+import "time"
+
+// QueueEntry is a fiction waiting on the reading queue.
+type QueueEntry struct {
+	FictionID string `json:"fictionId"`
+	Title     string `json:"title"`
+	Author    string `json:"author,omitempty"`
+	AddedAt   string `json:"addedAt"`
+}
+
+// Enqueue adds a fiction to the end of the reading queue. It returns false
+// if the fiction is already queued.
+func (c *Config) Enqueue(fictionID, title, author string) bool {
+	if c.QueueIndex(fictionID) >= 0 {
+		return false
+	}
+	c.Queue = append(c.Queue, QueueEntry{
+		FictionID: fictionID,
+		Title:     title,
+		Author:    author,
+		AddedAt:   time.Now().Format(TimeLayout),
+	})
+	return true
+}
+
+// QueueIndex returns a fiction's position in the queue, or -1.
+func (c *Config) QueueIndex(fictionID string) int {
+	for i, entry := range c.Queue {
+		if entry.FictionID == fictionID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Dequeue removes a fiction from the queue, reporting whether it was queued.
+func (c *Config) Dequeue(fictionID string) bool {
+	i := c.QueueIndex(fictionID)
+	if i < 0 {
+		return false
+	}
+	c.Queue = append(c.Queue[:i], c.Queue[i+1:]...)
+	return true
+}
+
+// MoveInQueue swaps the queue entry at i with its neighbor step places away
+// and returns the entry's new index, which is i if it can't move.
+func (c *Config) MoveInQueue(i, step int) int {
+	j := i + step
+	if i < 0 || i >= len(c.Queue) || j < 0 || j >= len(c.Queue) {
+		return i
+	}
+	c.Queue[i], c.Queue[j] = c.Queue[j], c.Queue[i]
+	return j
+}
+
+// PopQueue removes and returns the fiction at the front of the queue.
+func (c *Config) PopQueue() (QueueEntry, bool) {
+	if len(c.Queue) == 0 {
+		return QueueEntry{}, false
+	}
+	next := c.Queue[0]
+	c.Queue = c.Queue[1:]
+	return next, true
+}
@@ -0,0 +1,80 @@
+package config
+
+// Chapters read are tracked by chapter ID, so jumping around a fiction (or
+// the author inserting chapters) doesn't throw the counts off. Entries saved
+// before this have no ReadChapters and count everything before
+// CurrentChapter as read.
+
+// HasRead reports whether a chapter has been read.
+func (e ReadingEntry) HasRead(chapterID int) bool {
+	for _, id := range e.ReadChapters {
+		if id == chapterID {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadSet returns the chapters read, by ID.
+func (e ReadingEntry) ReadSet() map[int]bool {
+	read := make(map[int]bool, len(e.ReadChapters))
+	for _, id := range e.ReadChapters {
+		read[id] = true
+	}
+	return read
+}
+
+// UnreadCount counts the chapters in chapterIDs, in order, that haven't been
+// read. Without chapterIDs it estimates from the entry's chapter count,
+// including chapters found by the last metadata refresh.
+func (e ReadingEntry) UnreadCount(chapterIDs []int) int {
+	if chapterIDs == nil {
+		total := max(e.TotalChapters, e.LiveChapters)
+		if e.ReadChapters == nil {
+			return max(total-e.CurrentChapter-1, 0)
+		}
+		return max(total-len(e.ReadChapters), 0)
+	}
+	if e.ReadChapters == nil {
+		return max(len(chapterIDs)-e.CurrentChapter-1, 0)
+	}
+	read := e.ReadSet()
+	unread := 0
+	for _, id := range chapterIDs {
+		if !read[id] {
+			unread++
+		}
+	}
+	return unread
+}
+
+// MarkChaptersRead marks chapters as read, or unread, in a fiction's history
+// entry. It returns false if the fiction isn't in the history.
+func (c *Config) MarkChaptersRead(fictionID string, chapterIDs []int, read bool) bool {
+	entry := c.GetEntry(fictionID)
+	if entry == nil {
+		return false
+	}
+	set := entry.ReadSet()
+	for _, id := range chapterIDs {
+		if read && !set[id] {
+			entry.ReadChapters = append(entry.ReadChapters, id)
+			set[id] = true
+		} else if !read && set[id] {
+			delete(set, id)
+		}
+	}
+	if !read {
+		kept := []int{}
+		for _, id := range entry.ReadChapters {
+			if set[id] {
+				kept = append(kept, id)
+			}
+		}
+		entry.ReadChapters = kept
+	}
+	if entry.ReadChapters == nil {
+		entry.ReadChapters = []int{}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package config
+
+import "time"
+
+// metadataRefreshInterval is how old the history's chapter counts may get
+// before the history screen refreshes them by itself.
+const metadataRefreshInterval = 6 * time.Hour
+
+// RefreshDue reports whether the history's metadata hasn't been refreshed
+// recently.
+func (c *Config) RefreshDue(now time.Time) bool {
+	last, err := time.ParseInLocation(TimeLayout, c.LastRefresh, time.Local)
+	return err != nil || now.Sub(last) >= metadataRefreshInterval
+}
+
+// MarkRefreshed records that the history's metadata was just refreshed.
+func (c *Config) MarkRefreshed(now time.Time) {
+	c.LastRefresh = now.Format(TimeLayout)
+}
+
+// RecordMetadata stores what a fiction's page currently says about it,
+// leaving the reader's progress alone. It returns false if the fiction isn't
+// in the history.
+func (c *Config) RecordMetadata(fictionID string, chapters int, status, latestChapter string) bool {
+	entry := c.GetEntry(fictionID)
+	if entry == nil {
+		return false
+	}
+	entry.LiveChapters = chapters
+	entry.FictionStatus = status
+	entry.LatestChapter = latestChapter
+	return true
+}
+
+// NewChapters counts the chapters published since the book was last read,
+// as of the last refresh.
+func (e ReadingEntry) NewChapters() int {
+	return max(e.LiveChapters-e.TotalChapters, 0)
+}
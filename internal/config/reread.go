@@ -0,0 +1,49 @@
+package config
+
+import "time"
+
+// Completion records one read-through of a fiction that was finished.
+type Completion struct {
+	FinishedAt string `json:"finishedAt"`
+}
+
+// MarkFinished moves a fiction to the Finished shelf, noting when. It
+// returns false if the fiction isn't in the history.
+func (c *Config) MarkFinished(fictionID string, now time.Time) bool {
+	entry := c.GetEntry(fictionID)
+	if entry == nil {
+		return false
+	}
+	entry.Shelf = ShelfFinished
+	entry.FinishedAt = now.Format(TimeLayout)
+	entry.ShelvedAt = entry.FinishedAt
+	return true
+}
+
+// StartOver resets a fiction to the start for a re-read. A finished
+// read-through is kept in Completions so completion dates and counts
+// survive. It returns false if the fiction isn't in the history.
+func (c *Config) StartOver(fictionID string) bool {
+	entry := c.GetEntry(fictionID)
+	if entry == nil {
+		return false
+	}
+	if entry.CurrentShelf() == ShelfFinished || entry.FinishedAt != "" {
+		finishedAt := entry.FinishedAt
+		if finishedAt == "" {
+			// Finished before completion dates were kept
+			finishedAt = entry.LastRead
+		}
+		entry.Completions = append(entry.Completions, Completion{FinishedAt: finishedAt})
+	}
+	entry.CurrentChapter = 0
+	entry.ChapterTitle = ""
+	entry.ChapterProgress = 0
+	entry.ReadChapters = []int{}
+	entry.FinishedAt = ""
+	if entry.Shelf != ShelfReading && entry.Shelf != "" {
+		entry.ShelvedAt = time.Now().Format(TimeLayout)
+	}
+	entry.Shelf = ShelfReading
+	return true
+}
@@ -0,0 +1,173 @@
+package config
+
+import "time"
+
+// Shelves a reading history entry can sit on. Entries without a shelf are
+// treated as being on ShelfReading.
+const (
+	ShelfReading  = "reading"
+	ShelfFinished = "finished"
+	ShelfPaused   = "paused"
+	ShelfDropped  = "dropped"
+	ShelfLater    = "later" // Read Later: picked out but not started properly
+)
+
+// cleanupInterval is how long a dismissed cleanup prompt stays quiet.
+const cleanupInterval = 7 * 24 * time.Hour
+
+// ShelfOrder lists the shelves in the order the library shows them.
+var ShelfOrder = []string{ShelfReading, ShelfLater, ShelfPaused, ShelfFinished, ShelfDropped}
+
+// ShelfName is a shelf's display name.
+func ShelfName(shelf string) string {
+	switch shelf {
+	case ShelfFinished:
+		return "Finished"
+	case ShelfPaused:
+		return "Paused"
+	case ShelfDropped:
+		return "Dropped"
+	case ShelfLater:
+		return "Read Later"
+	}
+	return "Reading"
+}
+
+// CurrentShelf returns the entry's shelf, defaulting to ShelfReading.
+func (e ReadingEntry) CurrentShelf() string {
+	if e.Shelf == "" {
+		return ShelfReading
+	}
+	return e.Shelf
+}
+
+// SetShelf moves a fiction in the reading history to another shelf. It
+// returns false if the fiction isn't in the history.
+func (c *Config) SetShelf(fictionID, shelf string) bool {
+	for i, entry := range c.ReadingHistory {
+		if entry.FictionID == fictionID {
+			c.ReadingHistory[i].Shelf = shelf
+			c.ReadingHistory[i].ShelvedAt = time.Now().Format(TimeLayout)
+			return true
+		}
+	}
+	return false
+}
+
+// MoveToShelf puts a fiction in the reading history on another shelf,
+// recording it as finished when that shelf is ShelfFinished. It returns false
+// if the fiction isn't in the history.
+func (c *Config) MoveToShelf(fictionID, shelf string, now time.Time) bool {
+	if shelf == ShelfFinished {
+		return c.MarkFinished(fictionID, now)
+	}
+	entry := c.GetEntry(fictionID)
+	if entry == nil {
+		return false
+	}
+	entry.Shelf = shelf
+	entry.ShelvedAt = now.Format(TimeLayout)
+	return true
+}
+
+// AddToShelf appends a fiction to the end of the history on the given shelf,
+// leaving the recently-read order alone. It returns false if the fiction is
+// already in the history.
+func (c *Config) AddToShelf(entry ReadingEntry, shelf string) bool {
+	if c.GetEntry(entry.FictionID) != nil {
+		return false
+	}
+	if archived := c.restoreArchived(entry.FictionID); archived != nil {
+		entry = *archived
+	}
+	entry.Shelf = shelf
+	now := time.Now().Format(TimeLayout)
+	if entry.AddedAt == "" {
+		entry.AddedAt = now
+	}
+	entry.ShelvedAt = now
+	c.ReadingHistory = append(c.ReadingHistory, entry)
+	return true
+}
+
+// RemoveEntry deletes a fiction from the reading history.
+func (c *Config) RemoveEntry(fictionID string) {
+	for i, entry := range c.ReadingHistory {
+		if entry.FictionID == fictionID {
+			c.ReadingHistory = append(c.ReadingHistory[:i], c.ReadingHistory[i+1:]...)
+			if c.LastFiction == fictionID {
+				c.LastFiction = ""
+			}
+			return
+		}
+	}
+}
+
+// GetEntry returns the reading history entry for a fiction, or nil.
+func (c *Config) GetEntry(fictionID string) *ReadingEntry {
+	for i, entry := range c.ReadingHistory {
+		if entry.FictionID == fictionID {
+			return &c.ReadingHistory[i]
+		}
+	}
+	return nil
+}
+
+// LastReadTime returns when a fiction was last opened, or the zero time if it
+// isn't in the history or was never started.
+func (c *Config) LastReadTime(fictionID string) time.Time {
+	entry := c.GetEntry(fictionID)
+	if entry == nil {
+		return time.Time{}
+	}
+	lastRead, _ := time.ParseInLocation(TimeLayout, entry.LastRead, time.Local)
+	return lastRead
+}
+
+// ContinueEntries returns the history entries still being read, most recent
+// first. Finished fictions aren't offered for continuing.
+func (c *Config) ContinueEntries() []ReadingEntry {
+	var entries []ReadingEntry
+	for _, entry := range c.ReadingHistory {
+		if entry.CurrentShelf() == ShelfReading {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// StaleEntries returns books on the reading shelf that haven't been opened in
+// Reading.AbandonMonths, oldest first.
+func (c *Config) StaleEntries(now time.Time) []ReadingEntry {
+	if c.Reading.AbandonMonths <= 0 {
+		return nil
+	}
+	cutoff := now.AddDate(0, -c.Reading.AbandonMonths, 0)
+
+	var stale []ReadingEntry
+	for i := len(c.ReadingHistory) - 1; i >= 0; i-- {
+		entry := c.ReadingHistory[i]
+		if entry.CurrentShelf() != ShelfReading {
+			continue
+		}
+		lastRead, err := time.ParseInLocation(TimeLayout, entry.LastRead, time.Local)
+		if err == nil && lastRead.Before(cutoff) {
+			stale = append(stale, entry)
+		}
+	}
+	return stale
+}
+
+// CleanupDue reports whether the periodic stale-book review should be offered.
+func (c *Config) CleanupDue(now time.Time) bool {
+	if last, err := time.ParseInLocation(TimeLayout, c.LastCleanup, time.Local); err == nil && now.Sub(last) < cleanupInterval {
+		return false
+	}
+	return len(c.StaleEntries(now)) > 0
+}
+
+// MarkCleanupDone records that stale books were just reviewed (or the review
+// was dismissed), silencing the prompt for a while.
+func (c *Config) MarkCleanupDone(now time.Time) {
+	c.LastCleanup = now.Format(TimeLayout)
+}
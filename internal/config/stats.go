@@ -0,0 +1,59 @@
+package config
+
+import "time"
+
+// TimeLayout is the timestamp format used for everything persisted in the config.
+const TimeLayout = "2006-01-02 15:04"
+
+// ReadingSession is one program run's worth of reading activity.
+type ReadingSession struct {
+	Start    string   `json:"start"`
+	Seconds  int      `json:"seconds"`
+	Pages    int      `json:"pages"`
+	Chapters int      `json:"chapters"`
+	Words    int      `json:"words,omitempty"`
+	Fictions []string `json:"fictions,omitempty"`
+}
+
+func (s ReadingSession) Duration() time.Duration {
+	return time.Duration(s.Seconds) * time.Second
+}
+
+func (c *Config) RecordSession(session ReadingSession) {
+	c.Sessions = append(c.Sessions, session)
+}
+
+// ReadingTimeOn sums the sessions that started on the same calendar day as day.
+func (c *Config) ReadingTimeOn(day time.Time) time.Duration {
+	var total time.Duration
+	for _, session := range c.Sessions {
+		start, err := time.ParseInLocation(TimeLayout, session.Start, time.Local)
+		if err != nil {
+			continue
+		}
+		if start.Year() == day.Year() && start.YearDay() == day.YearDay() {
+			total += session.Duration()
+		}
+	}
+	return total
+}
+
+// ReadingSpeed sums the words read and the time spent over the sessions
+// that counted words, for measuring reading speed.
+func (c *Config) ReadingSpeed() (words int, elapsed time.Duration) {
+	for _, session := range c.Sessions {
+		if session.Words > 0 {
+			words += session.Words
+			elapsed += session.Duration()
+		}
+	}
+	return words, elapsed
+}
+
+func (c *Config) TotalReadingTime() time.Duration {
+	var total time.Duration
+	for _, session := range c.Sessions {
+		total += session.Duration()
+	}
+	return total
+}
@@ -0,0 +1,225 @@
+package download
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// Progress is reported once per chapter as a download proceeds.
+type Progress struct {
+	Done    int
+	Total   int
+	Chapter royalroad.FictionChapter
+	Cached  bool // already in the cache, nothing was fetched
+	Edited  bool // re-fetched and found to differ from the cached copy
+	Err     error
+}
+
+// Options control how hard the downloader leans on Royal Road.
+type Options struct {
+	Workers int           // Chapters fetched in parallel
+	Delay   time.Duration // Pause each worker takes after every request
+	Rate    float64       // Maximum requests per second across all workers (0 for no limit)
+}
+
+// DefaultOptions are polite enough for long fictions while still finishing a
+// few hundred chapters in minutes.
+func DefaultOptions() Options {
+	return Options{
+		Workers: 4,
+		Delay:   250 * time.Millisecond,
+		Rate:    2,
+	}
+}
+
+// Downloader fetches fictions and their chapters into the local cache.
+type Downloader struct {
+	client  *royalroad.Client
+	store   *cache.Store
+	options Options
+}
+
+func New(client *royalroad.Client, store *cache.Store, options Options) *Downloader {
+	if options.Workers < 1 {
+		options.Workers = 1
+	}
+	return &Downloader{
+		client:  client,
+		store:   store,
+		options: options,
+	}
+}
+
+// Fiction fetches the fiction page and stores it in the cache.
+func (d *Downloader) Fiction(ctx context.Context, fictionID int) (*royalroad.Fiction, error) {
+	fiction, err := d.client.GetFiction(ctx, fictionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.store.SaveFiction(fiction); err != nil {
+		return nil, fmt.Errorf("failed to cache fiction: %w", err)
+	}
+	return fiction, nil
+}
+
+// Chapters downloads the chapters at the given indices, skipping any that are
+// already cached. Chapters are fetched by a pool of workers sharing one rate
+// limit; progress is called from one goroutine at a time. Individual chapter
+// failures are reported through progress and counted; the first error is
+// returned once every chapter has been attempted. Cancelling ctx stops
+// dispatching new chapters; chapters already saved stay cached.
+func (d *Downloader) Chapters(ctx context.Context, fiction *royalroad.Fiction, indices []int, progress func(Progress)) error {
+	return d.fetch(ctx, fiction, indices, false, progress)
+}
+
+// CheckEdits re-fetches the chapters at the given indices, including cached
+// ones, and re-caches any whose content changed. Changed chapters are reported
+// with Progress.Edited and flagged in the cache until they're next read.
+func (d *Downloader) CheckEdits(ctx context.Context, fiction *royalroad.Fiction, indices []int, progress func(Progress)) error {
+	return d.fetch(ctx, fiction, indices, true, progress)
+}
+
+func (d *Downloader) fetch(ctx context.Context, fiction *royalroad.Fiction, indices []int, recheck bool, progress func(Progress)) error {
+	var (
+		mu       sync.Mutex
+		done     int
+		firstErr error
+		wg       sync.WaitGroup
+	)
+
+	limiter := royalroad.NewRateLimiter(d.options.Rate)
+	jobs := make(chan int)
+
+	report := func(index int, p Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		p.Done = done
+		if p.Err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("chapter %d (%s): %w", index+1, p.Chapter.Title, p.Err)
+		}
+		if progress != nil {
+			progress(p)
+		}
+	}
+
+	for w := 0; w < min(d.options.Workers, max(len(indices), 1)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				chapter := fiction.Chapters[index]
+				p := Progress{Total: len(indices), Chapter: chapter}
+
+				cached := d.store.HasChapter(fiction.ID, chapter.ID)
+				if cached && !recheck {
+					p.Cached = true
+					report(index, p)
+					continue
+				}
+
+				limiter.Wait(ctx)
+				if content, err := d.client.GetChapter(ctx, chapter.ID); err != nil {
+					p.Err = err
+				} else if cached && !d.store.ChapterChanged(fiction.ID, chapter.ID, content) {
+					p.Cached = true
+				} else if err := d.store.SaveChapter(fiction.ID, chapter.ID, content); err != nil {
+					p.Err = fmt.Errorf("failed to cache chapter: %w", err)
+				} else {
+					p.Edited = cached
+				}
+				report(index, p)
+				pause(ctx, d.options.Delay)
+			}
+		}()
+	}
+
+dispatch:
+	for _, index := range indices {
+		select {
+		case jobs <- index:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return firstErr
+}
+
+// ParseRange turns a 1-based chapter spec such as "1-50" or "1-10,15,20-" into
+// 0-based chapter indices. An empty spec selects every chapter.
+func ParseRange(spec string, total int) ([]int, error) {
+	if strings.TrimSpace(spec) == "" {
+		indices := make([]int, total)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices, nil
+	}
+
+	seen := make(map[int]bool)
+	var indices []int
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		start, end := part, part
+		if idx := strings.Index(part, "-"); idx >= 0 {
+			start, end = part[:idx], part[idx+1:]
+		}
+
+		from, err := parseBound(start, 1)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chapter range %q", part)
+		}
+		to, err := parseBound(end, total)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chapter range %q", part)
+		}
+		if from < 1 || to > total || from > to {
+			return nil, fmt.Errorf("chapter range %q is outside 1-%d", part, total)
+		}
+
+		for n := from; n <= to; n++ {
+			if !seen[n-1] {
+				seen[n-1] = true
+				indices = append(indices, n-1)
+			}
+		}
+	}
+
+	return indices, nil
+}
+
+func parseBound(raw string, fallback int) (int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(raw)
+}
+
+// pause waits between a worker's requests, returning early when ctx is
+// cancelled.
+func pause(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
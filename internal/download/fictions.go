@@ -0,0 +1,56 @@
+package download
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// FictionResult is the outcome of fetching one fiction page.
+type FictionResult struct {
+	ID      int
+	Fiction *royalroad.Fiction
+	Err     error
+}
+
+// Fictions fetches fiction pages with a pool of workers sharing one rate
+// limit, as Chapters does, without caching them. Results are in the order of
+// fictionIDs; fictions left unfetched when ctx is cancelled carry its error.
+func Fictions(ctx context.Context, client *royalroad.Client, fictionIDs []int, options Options) []FictionResult {
+	results := make([]FictionResult, len(fictionIDs))
+	limiter := royalroad.NewRateLimiter(options.Rate)
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(max(options.Workers, 1), max(len(fictionIDs), 1)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				limiter.Wait(ctx)
+				fiction, err := client.GetFiction(royalroad.Revalidate(ctx), fictionIDs[i])
+				results[i] = FictionResult{ID: fictionIDs[i], Fiction: fiction, Err: err}
+				pause(ctx, options.Delay)
+			}
+		}()
+	}
+
+	dispatched := 0
+dispatch:
+	for i := range fictionIDs {
+		select {
+		case jobs <- i:
+			dispatched++
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i := dispatched; i < len(fictionIDs); i++ {
+		results[i] = FictionResult{ID: fictionIDs[i], Err: ctx.Err()}
+	}
+	return results
+}
@@ -0,0 +1,70 @@
+package download
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// Update is the result of comparing a cached fiction with the live site.
+type Update struct {
+	Fiction     *royalroad.Fiction // Live fiction
+	NewChapters []int              // Indices into Fiction.Chapters published since the last download
+}
+
+// CheckUpdate works out which chapters of a downloaded fiction are new
+// compared with the cached chapter list. The fiction's RSS feed is checked
+// first; the full fiction page is only fetched when the feed announces a
+// chapter that isn't cached (or the feed can't be read). When nothing is new
+// the returned Update carries the cached fiction. Nothing is written to the
+// cache; see ApplyUpdate.
+func (d *Downloader) CheckUpdate(ctx context.Context, fictionID int) (*Update, error) {
+	cached, err := d.store.LoadFiction(fictionID)
+	if err != nil {
+		return nil, fmt.Errorf("fiction %d hasn't been downloaded yet", fictionID)
+	}
+
+	known := make(map[int]bool, len(cached.Chapters))
+	for _, chapter := range cached.Chapters {
+		known[chapter.ID] = true
+	}
+
+	if items, err := d.client.GetFictionFeed(ctx, fictionID); err == nil && len(items) > 0 {
+		unseen := false
+		for _, item := range items {
+			if !known[item.ChapterID] {
+				unseen = true
+				break
+			}
+		}
+		if !unseen {
+			return &Update{Fiction: cached}, nil
+		}
+	}
+
+	fiction, err := d.client.GetFiction(royalroad.Revalidate(ctx), fictionID)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &Update{Fiction: fiction}
+	for i, chapter := range fiction.Chapters {
+		if !known[chapter.ID] {
+			update.NewChapters = append(update.NewChapters, i)
+		}
+	}
+	return update, nil
+}
+
+// ApplyUpdate downloads an update's new chapters and only then stores the new
+// chapter list, so chapters that fail are picked up again by the next update.
+func (d *Downloader) ApplyUpdate(ctx context.Context, update *Update, progress func(Progress)) error {
+	if err := d.Chapters(ctx, update.Fiction, update.NewChapters, progress); err != nil {
+		return err
+	}
+	if err := d.store.SaveFiction(update.Fiction); err != nil {
+		return fmt.Errorf("failed to cache fiction: %w", err)
+	}
+	return nil
+}
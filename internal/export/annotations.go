@@ -0,0 +1,87 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// WriteAnnotations writes one Markdown file per fiction to dir with its
+// highlights and notes, and returns the files written. Files are named by
+// fiction ID and title, so fictions that share a title don't overwrite each
+// other.
+func WriteAnnotations(annotations []config.Annotation, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	var order []string
+	byFiction := make(map[string][]config.Annotation)
+	for _, annotation := range annotations {
+		if _, ok := byFiction[annotation.FictionID]; !ok {
+			order = append(order, annotation.FictionID)
+		}
+		byFiction[annotation.FictionID] = append(byFiction[annotation.FictionID], annotation)
+	}
+
+	var written []string
+	for _, fictionID := range order {
+		fictionAnnotations := byFiction[fictionID]
+		path := filepath.Join(dir, fictionID+"-"+Filename(fictionAnnotations[0].FictionTitle, "md"))
+		if err := os.WriteFile(path, []byte(AnnotationsMarkdown(fictionAnnotations)), 0644); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// AnnotationsMarkdown renders one fiction's highlights in reading order,
+// grouped under a heading per chapter, with YAML front matter that Obsidian
+// and Logseq pick up as page properties.
+func AnnotationsMarkdown(annotations []config.Annotation) string {
+	sorted := append([]config.Annotation(nil), annotations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ChapterIndex != sorted[j].ChapterIndex {
+			return sorted[i].ChapterIndex < sorted[j].ChapterIndex
+		}
+		return sorted[i].Start < sorted[j].Start
+	})
+	first := sorted[0]
+
+	var md strings.Builder
+	md.WriteString("---\n")
+	md.WriteString("title: " + yamlString(first.FictionTitle+" highlights") + "\n")
+	md.WriteString("fiction: " + yamlString(first.FictionTitle) + "\n")
+	md.WriteString("fiction_id: " + first.FictionID + "\n")
+	md.WriteString(fmt.Sprintf("highlights: %d\n", len(sorted)))
+	md.WriteString("source: https://www.royalroad.com/fiction/" + first.FictionID + "\n")
+	md.WriteString("tags: " + yamlList([]string{"royal-road", "highlights"}) + "\n")
+	md.WriteString("---\n\n")
+	md.WriteString("# " + first.FictionTitle + "\n")
+
+	lastChapter := -1
+	for _, annotation := range sorted {
+		if annotation.ChapterIndex != lastChapter {
+			lastChapter = annotation.ChapterIndex
+			md.WriteString(fmt.Sprintf("\n## Chapter %d: %s\n", annotation.ChapterIndex+1, annotation.ChapterTitle))
+		}
+		md.WriteString("\n" + quoteMarkdown(annotation.Text) + "\n")
+		if annotation.Note != "" {
+			md.WriteString("\n" + strings.TrimSpace(annotation.Note) + "\n")
+		}
+
+		reference := fmt.Sprintf("[Chapter %d](https://www.royalroad.com/fiction/%s/_/chapter/%d/_)",
+			annotation.ChapterIndex+1, annotation.FictionID, annotation.ChapterID)
+		if created, err := time.Parse(time.RFC3339, annotation.CreatedAt); err == nil {
+			reference += " · " + created.Format("2006-01-02")
+		}
+		md.WriteString("\n— " + reference + "\n")
+	}
+	return md.String()
+}
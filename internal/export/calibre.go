@@ -0,0 +1,59 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrCalibreMissing is returned when Calibre's ebook-convert isn't installed.
+var ErrCalibreMissing = errors.New("Calibre's ebook-convert was not found in PATH; install Calibre from https://calibre-ebook.com/download or export to EPUB instead")
+
+// CalibrePath locates ebook-convert, including the default macOS app bundle
+// location which isn't on PATH.
+func CalibrePath() (string, error) {
+	if path, err := exec.LookPath("ebook-convert"); err == nil {
+		return path, nil
+	}
+
+	macPath := "/Applications/calibre.app/Contents/MacOS/ebook-convert"
+	if _, err := os.Stat(macPath); err == nil {
+		return macPath, nil
+	}
+
+	return "", ErrCalibreMissing
+}
+
+// WriteKindle generates an EPUB and converts it to a Kindle format (mobi or
+// azw3, chosen by the extension of path) with ebook-convert.
+func WriteKindle(book *Book, path string) error {
+	converter, err := CalibrePath()
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "royal-road-cli-export")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	epubPath := filepath.Join(tmpDir, "book.epub")
+	if err := WriteEPUB(book, epubPath); err != nil {
+		return fmt.Errorf("failed to generate EPUB: %w", err)
+	}
+
+	output, err := exec.Command(converter, epubPath, path).CombinedOutput()
+	if err != nil {
+		// ebook-convert is chatty; only the last lines explain what went wrong
+		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+		if len(lines) > 5 {
+			lines = lines[len(lines)-5:]
+		}
+		return fmt.Errorf("ebook-convert failed: %w\n%s", err, strings.Join(lines, "\n"))
+	}
+	return nil
+}
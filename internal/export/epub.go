@@ -0,0 +1,209 @@
+package export
+
+import (
+	"archive/zip"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const epubStylesheet = `body { font-family: serif; line-height: 1.4; }
+h1 { text-align: center; margin: 1em 0; }
+.author { text-align: center; font-style: italic; }
+blockquote.author-note { font-style: italic; color: #555; border-left: 2px solid #999; margin: 1em 0; padding-left: 0.8em; }
+`
+
+// WriteEPUB writes the book as an EPUB 3 file (with an NCX table of contents
+// for older readers and Calibre) to path.
+func WriteEPUB(book *Book, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := writeEPUB(book, file); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	return file.Close()
+}
+
+func writeEPUB(book *Book, w io.Writer) error {
+	archive := zip.NewWriter(w)
+
+	// The mimetype entry must come first and be stored uncompressed.
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mimetype, "application/epub+zip"); err != nil {
+		return err
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"META-INF/container.xml", containerXML},
+		{"OEBPS/style.css", epubStylesheet},
+		{"OEBPS/title.xhtml", titlePage(book)},
+		{"OEBPS/nav.xhtml", navDocument(book)},
+		{"OEBPS/toc.ncx", ncxDocument(book)},
+		{"OEBPS/content.opf", packageDocument(book)},
+	}
+	for i, chapter := range book.Chapters {
+		files = append(files, struct {
+			name    string
+			content string
+		}{"OEBPS/" + chapterFile(i), chapterDocument(chapter)})
+	}
+
+	for _, f := range files {
+		entry, err := archive.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, f.content); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+func chapterFile(i int) string {
+	return fmt.Sprintf("chapter-%04d.xhtml", i+1)
+}
+
+func bookIdentifier(book *Book) string {
+	return fmt.Sprintf("urn:royalroad:fiction:%d", book.Fiction.ID)
+}
+
+func xhtmlDocument(title, body string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head>
+  <title>%s</title>
+  <link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+%s
+</body>
+</html>
+`, html.EscapeString(title), body)
+}
+
+func titlePage(book *Book) string {
+	var body strings.Builder
+	body.WriteString("<h1>" + html.EscapeString(book.Fiction.Title) + "</h1>\n")
+	body.WriteString(`<p class="author">by ` + html.EscapeString(book.Fiction.Author.Name) + "</p>\n")
+	for _, paragraph := range strings.Split(book.Fiction.Description, "\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			body.WriteString("<p>" + html.EscapeString(paragraph) + "</p>\n")
+		}
+	}
+	body.WriteString(fmt.Sprintf("<p><em>https://www.royalroad.com/fiction/%d</em></p>\n", book.Fiction.ID))
+	return xhtmlDocument(book.Fiction.Title, body.String())
+}
+
+func chapterDocument(chapter Chapter) string {
+	var body strings.Builder
+	body.WriteString("<h1>" + html.EscapeString(chapter.Title) + "</h1>\n")
+	if chapter.Content.PreNote != "" {
+		body.WriteString(`<blockquote class="author-note"><p>` + html.EscapeString(chapter.Content.PreNote) + "</p></blockquote>\n")
+	}
+	body.WriteString(toXHTML(chapter.Content.Content))
+	if chapter.Content.PostNote != "" {
+		body.WriteString("\n" + `<blockquote class="author-note"><p>` + html.EscapeString(chapter.Content.PostNote) + "</p></blockquote>")
+	}
+	return xhtmlDocument(chapter.Title, body.String())
+}
+
+func navDocument(book *Book) string {
+	var body strings.Builder
+	body.WriteString(`<nav epub:type="toc" id="toc">` + "\n<h1>Contents</h1>\n<ol>\n")
+	for i, chapter := range book.Chapters {
+		body.WriteString(fmt.Sprintf(`  <li><a href="%s">%s</a></li>`+"\n", chapterFile(i), html.EscapeString(chapter.Title)))
+	}
+	body.WriteString("</ol>\n</nav>")
+	return xhtmlDocument("Contents", body.String())
+}
+
+func ncxDocument(book *Book) string {
+	var points strings.Builder
+	for i, chapter := range book.Chapters {
+		points.WriteString(fmt.Sprintf(`    <navPoint id="navpoint-%d" playOrder="%d">
+      <navLabel><text>%s</text></navLabel>
+      <content src="%s"/>
+    </navPoint>
+`, i+1, i+1, html.EscapeString(chapter.Title), chapterFile(i)))
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <head>
+    <meta name="dtb:uid" content="%s"/>
+  </head>
+  <docTitle><text>%s</text></docTitle>
+  <navMap>
+%s  </navMap>
+</ncx>
+`, bookIdentifier(book), html.EscapeString(book.Fiction.Title), points.String())
+}
+
+func packageDocument(book *Book) string {
+	var manifest, spine strings.Builder
+	for i := range book.Chapters {
+		id := fmt.Sprintf("chapter-%d", i+1)
+		manifest.WriteString(fmt.Sprintf(`    <item id="%s" href="%s" media-type="application/xhtml+xml"/>`+"\n", id, chapterFile(i)))
+		spine.WriteString(fmt.Sprintf(`    <itemref idref="%s"/>`+"\n", id))
+	}
+
+	var subjects strings.Builder
+	for _, tag := range book.Fiction.Tags {
+		subjects.WriteString("    <dc:subject>" + html.EscapeString(tag) + "</dc:subject>\n")
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="book-id">%s</dc:identifier>
+    <dc:title>%s</dc:title>
+    <dc:creator>%s</dc:creator>
+    <dc:language>en</dc:language>
+    <dc:source>https://www.royalroad.com/fiction/%d</dc:source>
+%s    <meta property="dcterms:modified">%s</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="style" href="style.css" media-type="text/css"/>
+    <item id="title" href="title.xhtml" media-type="application/xhtml+xml"/>
+%s  </manifest>
+  <spine toc="ncx">
+    <itemref idref="title"/>
+%s  </spine>
+</package>
+`,
+		bookIdentifier(book),
+		html.EscapeString(book.Fiction.Title),
+		html.EscapeString(book.Fiction.Author.Name),
+		book.Fiction.ID,
+		subjects.String(),
+		time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		manifest.String(),
+		spine.String())
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// Book is a fiction together with the chapter content to be exported.
+type Book struct {
+	Fiction  *royalroad.Fiction
+	Chapters []Chapter
+}
+
+type Chapter struct {
+	Number  int // 1-based position in the fiction
+	Title   string
+	Content *royalroad.Chapter
+}
+
+// LoadBook assembles the chapters at the given indices from the cache. The
+// chapters are expected to have been downloaded already.
+func LoadBook(store *cache.Store, fiction *royalroad.Fiction, indices []int) (*Book, error) {
+	book := &Book{Fiction: fiction}
+	for _, index := range indices {
+		info := fiction.Chapters[index]
+		content, err := store.LoadChapter(fiction.ID, info.ID)
+		if err != nil {
+			return nil, fmt.Errorf("chapter %d (%s) is not cached: %w", index+1, info.Title, err)
+		}
+		book.Chapters = append(book.Chapters, Chapter{
+			Number:  index + 1,
+			Title:   info.Title,
+			Content: content,
+		})
+	}
+	return book, nil
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// Filename derives a filesystem-friendly name from a title, e.g.
+// "Mother of Learning" -> "mother-of-learning.epub". An empty ext gives a
+// bare name suitable for a directory.
+func Filename(title, ext string) string {
+	name := strings.Trim(unsafeFilenameChars.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if name == "" {
+		name = "fiction"
+	}
+	if ext == "" {
+		return name
+	}
+	return name + "." + ext
+}
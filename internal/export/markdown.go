@@ -0,0 +1,266 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// WriteMarkdown writes one Markdown file per chapter plus an index.md into
+// dir. Every file starts with YAML front matter describing the fiction, so the
+// output drops straight into static site generators and note vaults.
+func WriteMarkdown(book *Book, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	var index strings.Builder
+	index.WriteString(fictionFrontMatter(book))
+	index.WriteString(fmt.Sprintf("# %s\n\n*by %s*\n\n", book.Fiction.Title, book.Fiction.Author.Name))
+	if book.Fiction.Description != "" {
+		index.WriteString(book.Fiction.Description + "\n\n")
+	}
+	index.WriteString("## Chapters\n\n")
+
+	for i, chapter := range book.Chapters {
+		name := markdownChapterFile(chapter)
+		index.WriteString(fmt.Sprintf("%d. [%s](%s)\n", chapter.Number, escapeMarkdownLinkText(chapter.Title), name))
+
+		var doc strings.Builder
+		doc.WriteString(chapterFrontMatter(book, i))
+		doc.WriteString("# " + chapter.Title + "\n\n")
+		if chapter.Content.PreNote != "" {
+			doc.WriteString(quoteMarkdown("**Author's Note:** "+chapter.Content.PreNote) + "\n\n")
+		}
+		doc.WriteString(toMarkdown(chapter.Content.Content))
+		if chapter.Content.PostNote != "" {
+			doc.WriteString("\n\n" + quoteMarkdown("**Author's Note:** "+chapter.Content.PostNote))
+		}
+		doc.WriteString("\n")
+
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(doc.String()), 0644); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filepath.Join(dir, "index.md"), []byte(index.String()), 0644)
+}
+
+func markdownChapterFile(chapter Chapter) string {
+	return fmt.Sprintf("%04d-%s", chapter.Number, Filename(chapter.Title, "md"))
+}
+
+// yamlString quotes a value for YAML front matter. Go's quoting rules produce
+// valid YAML double-quoted scalars.
+func yamlString(s string) string {
+	return strconv.Quote(s)
+}
+
+func yamlList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = yamlString(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func fictionFrontMatter(book *Book) string {
+	f := book.Fiction
+	var fm strings.Builder
+	fm.WriteString("---\n")
+	fm.WriteString("title: " + yamlString(f.Title) + "\n")
+	fm.WriteString("author: " + yamlString(f.Author.Name) + "\n")
+	fm.WriteString(fmt.Sprintf("fiction_id: %d\n", f.ID))
+	if f.Status != "" {
+		fm.WriteString("status: " + yamlString(f.Status) + "\n")
+	}
+	fm.WriteString("tags: " + yamlList(f.Tags) + "\n")
+	fm.WriteString(fmt.Sprintf("chapters: %d\n", len(book.Chapters)))
+	fm.WriteString(fmt.Sprintf("source: https://www.royalroad.com/fiction/%d\n", f.ID))
+	fm.WriteString("---\n\n")
+	return fm.String()
+}
+
+func chapterFrontMatter(book *Book, i int) string {
+	f := book.Fiction
+	chapter := book.Chapters[i]
+	info := f.Chapters[chapter.Number-1]
+
+	var fm strings.Builder
+	fm.WriteString("---\n")
+	fm.WriteString("title: " + yamlString(chapter.Title) + "\n")
+	fm.WriteString("fiction: " + yamlString(f.Title) + "\n")
+	fm.WriteString("author: " + yamlString(f.Author.Name) + "\n")
+	fm.WriteString(fmt.Sprintf("fiction_id: %d\n", f.ID))
+	fm.WriteString(fmt.Sprintf("chapter_id: %d\n", info.ID))
+	fm.WriteString(fmt.Sprintf("chapter_number: %d\n", chapter.Number))
+	if !info.Release.IsZero() {
+		fm.WriteString("published: " + info.Release.Format("2006-01-02") + "\n")
+	}
+	fm.WriteString("tags: " + yamlList(f.Tags) + "\n")
+	fm.WriteString(fmt.Sprintf("source: https://www.royalroad.com/fiction/%d/_/chapter/%d/_\n", f.ID, info.ID))
+	if i > 0 {
+		fm.WriteString("previous: " + yamlString(markdownChapterFile(book.Chapters[i-1])) + "\n")
+	}
+	if i < len(book.Chapters)-1 {
+		fm.WriteString("next: " + yamlString(markdownChapterFile(book.Chapters[i+1])) + "\n")
+	}
+	fm.WriteString("---\n\n")
+	return fm.String()
+}
+
+func quoteMarkdown(text string) string {
+	return "> " + strings.ReplaceAll(strings.TrimSpace(text), "\n", "\n> ")
+}
+
+func escapeMarkdownLinkText(text string) string {
+	return strings.NewReplacer("[", "\\[", "]", "\\]").Replace(text)
+}
+
+var (
+	markdownSpaceLines = regexp.MustCompile(`(?m)^[ \t]+$`)
+	markdownBlankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+func tidyMarkdown(text string) string {
+	text = markdownSpaceLines.ReplaceAllString(text, "")
+	return strings.TrimSpace(markdownBlankLines.ReplaceAllString(text, "\n\n"))
+}
+
+// toMarkdown converts chapter HTML into Markdown, keeping paragraphs, breaks,
+// emphasis, quotes, headings, lists and images.
+func toMarkdown(fragment string) string {
+	nodes, err := nethtml.ParseFragment(strings.NewReader(fragment), &nethtml.Node{
+		Type:     nethtml.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return fragment
+	}
+
+	var out strings.Builder
+	for _, node := range nodes {
+		writeMarkdown(&out, node)
+	}
+	return tidyMarkdown(out.String())
+}
+
+func writeMarkdown(out *strings.Builder, node *nethtml.Node) {
+	if node.Type == nethtml.TextNode {
+		out.WriteString(collapseWhitespace(node.Data))
+		return
+	}
+	if node.Type != nethtml.ElementNode {
+		writeMarkdownChildren(out, node)
+		return
+	}
+
+	switch tag := strings.ToLower(node.Data); tag {
+	case "script", "style", "noscript":
+	case "br":
+		out.WriteString("  \n")
+	case "hr":
+		out.WriteString("\n\n---\n\n")
+	case "p", "div":
+		out.WriteString("\n\n")
+		writeMarkdownChildren(out, node)
+		out.WriteString("\n\n")
+	case "em", "i":
+		writeMarkdownWrapped(out, node, "*")
+	case "strong", "b":
+		writeMarkdownWrapped(out, node, "**")
+	case "s", "strike", "del":
+		writeMarkdownWrapped(out, node, "~~")
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level, _ := strconv.Atoi(tag[1:])
+		out.WriteString("\n\n" + strings.Repeat("#", level) + " ")
+		writeMarkdownChildren(out, node)
+		out.WriteString("\n\n")
+	case "blockquote":
+		var inner strings.Builder
+		writeMarkdownChildren(&inner, node)
+		out.WriteString("\n\n" + quoteMarkdown(tidyMarkdown(inner.String())) + "\n\n")
+	case "li":
+		out.WriteString("\n- ")
+		writeMarkdownChildren(out, node)
+	case "ul", "ol":
+		out.WriteString("\n")
+		writeMarkdownChildren(out, node)
+		out.WriteString("\n\n")
+	case "tr":
+		out.WriteString("\n|")
+		writeMarkdownChildren(out, node)
+	case "td", "th":
+		out.WriteString(" ")
+		writeMarkdownChildren(out, node)
+		out.WriteString(" |")
+	case "img":
+		alt, src := "", ""
+		for _, attr := range node.Attr {
+			switch attr.Key {
+			case "alt":
+				alt = attr.Val
+			case "src":
+				src = attr.Val
+			}
+		}
+		if src != "" {
+			out.WriteString(fmt.Sprintf("![%s](%s)", escapeMarkdownLinkText(alt), src))
+		}
+	default:
+		writeMarkdownChildren(out, node)
+	}
+}
+
+func writeMarkdownChildren(out *strings.Builder, node *nethtml.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeMarkdown(out, child)
+	}
+}
+
+// writeMarkdownWrapped surrounds inline content with a marker, keeping any
+// surrounding whitespace outside so the emphasis stays valid Markdown.
+func writeMarkdownWrapped(out *strings.Builder, node *nethtml.Node, marker string) {
+	var inner strings.Builder
+	writeMarkdownChildren(&inner, node)
+	text := inner.String()
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		out.WriteString(text)
+		return
+	}
+	if strings.HasPrefix(text, " ") {
+		out.WriteString(" ")
+	}
+	out.WriteString(marker + trimmed + marker)
+	if strings.HasSuffix(text, " ") {
+		out.WriteString(" ")
+	}
+}
+
+// collapseWhitespace squashes source formatting whitespace the way a browser
+// would, keeping a single space at either end if there was any.
+func collapseWhitespace(text string) string {
+	collapsed := strings.Join(strings.Fields(text), " ")
+	if collapsed == "" {
+		if text != "" {
+			return " "
+		}
+		return ""
+	}
+	if unicode.IsSpace(rune(text[0])) {
+		collapsed = " " + collapsed
+	}
+	if unicode.IsSpace(rune(text[len(text)-1])) {
+		collapsed += " "
+	}
+	return collapsed
+}
@@ -0,0 +1,35 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/jackowfish/royal-road-cli/internal/render"
+)
+
+// WriteText writes the whole book as a single UTF-8 text file, cleaning each
+// chapter the same way the reader does.
+func WriteText(book *Book, path string) error {
+	var out strings.Builder
+	out.WriteString(book.Fiction.Title + "\n")
+	out.WriteString("by " + book.Fiction.Author.Name + "\n")
+
+	for _, chapter := range book.Chapters {
+		heading := fmt.Sprintf("Chapter %d: %s", chapter.Number, chapter.Title)
+		out.WriteString("\n\n" + heading + "\n")
+		out.WriteString(strings.Repeat("=", runewidth.StringWidth(heading)) + "\n\n")
+
+		if chapter.Content.PreNote != "" {
+			out.WriteString("Author's Note: " + render.CleanHTML(chapter.Content.PreNote) + "\n\n")
+		}
+		out.WriteString(render.CleanHTML(chapter.Content.Content) + "\n")
+		if chapter.Content.PostNote != "" {
+			out.WriteString("\nAuthor's Note: " + render.CleanHTML(chapter.Content.PostNote) + "\n")
+		}
+	}
+
+	return os.WriteFile(path, []byte(out.String()), 0644)
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"html"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedElements are kept when converting chapter HTML to XHTML. Anything
+// else is unwrapped so its text survives without the markup.
+var allowedElements = map[string]bool{
+	"p": true, "br": true, "hr": true, "div": true, "span": true,
+	"em": true, "i": true, "strong": true, "b": true, "u": true,
+	"s": true, "strike": true, "del": true, "sup": true, "sub": true,
+	"blockquote": true, "h1": true, "h2": true, "h3": true, "h4": true,
+	"h5": true, "h6": true, "ul": true, "ol": true, "li": true,
+	"table": true, "thead": true, "tbody": true, "tr": true, "td": true, "th": true,
+}
+
+var voidElements = map[string]bool{"br": true, "hr": true}
+
+// droppedElements are removed along with their content.
+var droppedElements = map[string]bool{"script": true, "style": true, "noscript": true}
+
+// SanitizeHTML reduces chapter HTML to the same attribute-free, well-formed
+// markup used in EPUBs, which is safe to embed in any web page.
+func SanitizeHTML(fragment string) string {
+	return toXHTML(fragment)
+}
+
+// toXHTML converts an HTML fragment into well-formed XHTML with all
+// attributes stripped, as required by EPUB content documents.
+func toXHTML(fragment string) string {
+	nodes, err := nethtml.ParseFragment(strings.NewReader(fragment), &nethtml.Node{
+		Type:     nethtml.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return "<p>" + html.EscapeString(fragment) + "</p>"
+	}
+
+	var out strings.Builder
+	for _, node := range nodes {
+		writeXHTML(&out, node)
+	}
+	return out.String()
+}
+
+func writeXHTML(out *strings.Builder, node *nethtml.Node) {
+	switch node.Type {
+	case nethtml.TextNode:
+		out.WriteString(html.EscapeString(node.Data))
+		return
+	case nethtml.ElementNode:
+		tag := strings.ToLower(node.Data)
+		if droppedElements[tag] {
+			return
+		}
+		if tag == "img" {
+			writeImagePlaceholder(out, node)
+			return
+		}
+		if !allowedElements[tag] {
+			writeChildren(out, node)
+			return
+		}
+		if voidElements[tag] {
+			out.WriteString("<" + tag + "/>")
+			return
+		}
+		out.WriteString("<" + tag + ">")
+		writeChildren(out, node)
+		out.WriteString("</" + tag + ">")
+	default:
+		writeChildren(out, node)
+	}
+}
+
+func writeChildren(out *strings.Builder, node *nethtml.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeXHTML(out, child)
+	}
+}
+
+// Remote images aren't embedded; keep the alt text so the reader knows one was there.
+func writeImagePlaceholder(out *strings.Builder, node *nethtml.Node) {
+	alt := "image"
+	for _, attr := range node.Attr {
+		if attr.Key == "alt" && strings.TrimSpace(attr.Val) != "" {
+			alt = strings.TrimSpace(attr.Val)
+		}
+	}
+	out.WriteString("<em>[Image: " + html.EscapeString(alt) + "]</em>")
+}
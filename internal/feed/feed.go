@@ -0,0 +1,76 @@
+// Package feed writes the chapters published across the library as an RSS
+// 2.0 feed for feed readers.
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// Entry is one published chapter.
+type Entry struct {
+	FictionID    int
+	FictionTitle string
+	ChapterTitle string
+	Link         string
+	Published    time.Time
+}
+
+type rss struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	Channel channel  `xml:"channel"`
+}
+
+type channel struct {
+	Title         string `xml:"title"`
+	Link          string `xml:"link"`
+	Description   string `xml:"description"`
+	LastBuildDate string `xml:"lastBuildDate"`
+	Items         []item `xml:"item"`
+}
+
+type item struct {
+	Title    string `xml:"title"`
+	Link     string `xml:"link"`
+	GUID     string `xml:"guid"`
+	Category string `xml:"category"`
+	PubDate  string `xml:"pubDate,omitempty"`
+}
+
+// WriteRSS writes entries, in the order given, as an RSS 2.0 document.
+func WriteRSS(w io.Writer, title string, entries []Entry, now time.Time) error {
+	doc := rss{
+		Version: "2.0",
+		Channel: channel{
+			Title:         title,
+			Link:          "https://www.royalroad.com/my/follows",
+			Description:   "New chapters of the fictions in your royal-road-cli library",
+			LastBuildDate: now.Format(time.RFC1123Z),
+		},
+	}
+	for _, entry := range entries {
+		it := item{
+			Title:    entry.FictionTitle + ": " + entry.ChapterTitle,
+			Link:     entry.Link,
+			GUID:     entry.Link,
+			Category: entry.FictionTitle,
+		}
+		if !entry.Published.IsZero() {
+			it.PubDate = entry.Published.Format(time.RFC1123Z)
+		}
+		doc.Channel.Items = append(doc.Channel.Items, it)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,102 @@
+// Package hooks runs user-configured shell commands when something happens in
+// the library, such as new chapters being found. Event details are passed in
+// RRC_* environment variables so hooks can forward them to ntfy, Pushover or
+// anything else.
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// Timeout bounds how long a single hook may run.
+const Timeout = time.Minute
+
+// NewChapters describes chapters published since a fiction was last checked.
+type NewChapters struct {
+	Fiction  *royalroad.Fiction
+	Chapters []int // Indices into Fiction.Chapters, oldest first
+}
+
+// Env returns the variables a new-chapters hook receives:
+//
+//	RRC_EVENT           new-chapters
+//	RRC_FICTION_ID      fiction ID
+//	RRC_FICTION_TITLE   fiction title
+//	RRC_AUTHOR          author name
+//	RRC_FICTION_URL     fiction page
+//	RRC_NEW_COUNT       number of new chapters
+//	RRC_CHAPTER_ID      newest chapter's ID
+//	RRC_CHAPTER_TITLE   newest chapter's title
+//	RRC_CHAPTER_NUMBER  newest chapter's 1-based number
+//	RRC_CHAPTER_URL     newest chapter's page
+//	RRC_CHAPTER_TITLES  every new chapter title, one per line
+func (e NewChapters) Env() []string {
+	fiction := e.Fiction
+	env := []string{
+		"RRC_EVENT=new-chapters",
+		"RRC_FICTION_ID=" + strconv.Itoa(fiction.ID),
+		"RRC_FICTION_TITLE=" + fiction.Title,
+		"RRC_AUTHOR=" + fiction.Author.Name,
+		fmt.Sprintf("RRC_FICTION_URL=%s/fiction/%d", royalroad.DefaultBaseURL, fiction.ID),
+		"RRC_NEW_COUNT=" + strconv.Itoa(len(e.Chapters)),
+	}
+	if len(e.Chapters) == 0 {
+		return env
+	}
+
+	titles := make([]string, len(e.Chapters))
+	for i, index := range e.Chapters {
+		titles[i] = fiction.Chapters[index].Title
+	}
+	newest := e.Chapters[len(e.Chapters)-1]
+	chapter := fiction.Chapters[newest]
+	return append(env,
+		"RRC_CHAPTER_ID="+strconv.Itoa(chapter.ID),
+		"RRC_CHAPTER_TITLE="+chapter.Title,
+		"RRC_CHAPTER_NUMBER="+strconv.Itoa(newest+1),
+		fmt.Sprintf("RRC_CHAPTER_URL=%s/fiction/%d/_/chapter/%d/_", royalroad.DefaultBaseURL, fiction.ID, chapter.ID),
+		"RRC_CHAPTER_TITLES="+strings.Join(titles, "\n"),
+	)
+}
+
+// Run executes each command through the shell with env added to the current
+// environment. Hook output goes to stderr so it never mixes with a command's
+// machine-readable stdout. Every command is run even if an earlier one fails;
+// the failures are returned.
+func Run(commands []string, env []string) []error {
+	var errs []error
+	for _, command := range commands {
+		if strings.TrimSpace(command) == "" {
+			continue
+		}
+		if err := run(command, env); err != nil {
+			errs = append(errs, fmt.Errorf("hook %q: %w", command, err))
+		}
+	}
+	return errs
+}
+
+func run(command string, env []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,321 @@
+package network
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// StoredCookie is a cookie as kept in cookies.json.
+type StoredCookie struct {
+	Name     string    `json:"name"`
+	Value    string    `json:"value"`
+	Domain   string    `json:"domain"`
+	Path     string    `json:"path"`
+	HostOnly bool      `json:"hostOnly,omitempty"` // Sent to Domain only, not its subdomains
+	Expires  time.Time `json:"expires"`            // Zero for a session cookie
+	Secure   bool      `json:"secure,omitempty"`
+	HTTPOnly bool      `json:"httpOnly,omitempty"`
+}
+
+func (c StoredCookie) key() string {
+	return c.Domain + ";" + c.Path + ";" + c.Name
+}
+
+func (c StoredCookie) expired() bool {
+	return !c.Expires.IsZero() && c.Expires.Before(time.Now())
+}
+
+// Jar is a cookie jar saved to disk whenever the site sets a cookie, so a
+// Cloudflare clearance or a session survives between runs. It is safe for
+// concurrent use.
+type Jar struct {
+	path    string
+	mu      sync.Mutex
+	jar     *cookiejar.Jar
+	cookies map[string]StoredCookie
+}
+
+var (
+	cookieJar     *Jar
+	cookieJarOnce sync.Once
+)
+
+// CookiesPath returns where the cookie jar is kept, beside config.json.
+func CookiesPath() (string, error) {
+	configPath, err := config.Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "cookies.json"), nil
+}
+
+// OpenJar loads the cookie jar, dropping cookies that have expired. A missing
+// file is an empty jar.
+func OpenJar() (*Jar, error) {
+	path, err := CookiesPath()
+	if err != nil {
+		return nil, err
+	}
+	jar, _ := cookiejar.New(nil)
+	j := &Jar{path: path, jar: jar, cookies: make(map[string]StoredCookie)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stored []StoredCookie
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, cookie := range stored {
+		if !cookie.expired() {
+			j.add(cookie)
+		}
+	}
+	return j, nil
+}
+
+// sharedJar returns the jar every client uses, or nil if it can't be loaded.
+func sharedJar() *Jar {
+	cookieJarOnce.Do(func() {
+		cookieJar, _ = OpenJar()
+	})
+	return cookieJar
+}
+
+// Cookies implements http.CookieJar.
+func (j *Jar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.jar.Cookies(u)
+}
+
+// SetCookies implements http.CookieJar, saving the jar to disk.
+func (j *Jar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, cookie := range cookies {
+		stored := StoredCookie{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Domain:   strings.TrimPrefix(strings.ToLower(cookie.Domain), "."),
+			Path:     cookie.Path,
+			Expires:  cookie.Expires,
+			Secure:   cookie.Secure,
+			HTTPOnly: cookie.HttpOnly,
+		}
+		if stored.Domain == "" {
+			stored.Domain, stored.HostOnly = u.Hostname(), true
+		}
+		if stored.Path == "" {
+			stored.Path = "/"
+		}
+		switch {
+		case cookie.MaxAge < 0:
+			stored.Expires = time.Unix(1, 0)
+		case cookie.MaxAge > 0:
+			stored.Expires = time.Now().Add(time.Duration(cookie.MaxAge) * time.Second)
+		}
+		if stored.expired() {
+			delete(j.cookies, stored.key())
+		} else {
+			j.cookies[stored.key()] = stored
+		}
+	}
+	j.jar.SetCookies(u, cookies)
+	// A failed save only costs the cookies on the next run
+	_ = j.save()
+}
+
+// Import adds cookies copied from a browser, replacing any with the same name
+// whatever their domain, and saves the jar.
+func (j *Jar) Import(cookies []StoredCookie) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, cookie := range cookies {
+		for _, old := range j.cookies {
+			if old.Name == cookie.Name {
+				j.remove(old)
+			}
+		}
+		j.add(cookie)
+	}
+	return j.save()
+}
+
+// List returns the cookies in the jar, sorted by domain and name.
+func (j *Jar) List() []StoredCookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var cookies []StoredCookie
+	for _, cookie := range j.cookies {
+		if !cookie.expired() {
+			cookies = append(cookies, cookie)
+		}
+	}
+	sort.Slice(cookies, func(a, b int) bool {
+		if cookies[a].Domain != cookies[b].Domain {
+			return cookies[a].Domain < cookies[b].Domain
+		}
+		return cookies[a].Name < cookies[b].Name
+	})
+	return cookies
+}
+
+// Clear empties the jar and removes its file.
+func (j *Jar) Clear() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.jar, _ = cookiejar.New(nil)
+	j.cookies = make(map[string]StoredCookie)
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// add puts a stored cookie back into the in-memory jar. Callers hold j.mu,
+// or own j exclusively.
+func (j *Jar) add(cookie StoredCookie) {
+	j.cookies[cookie.key()] = cookie
+	httpCookie := &http.Cookie{
+		Name:     cookie.Name,
+		Value:    cookie.Value,
+		Path:     cookie.Path,
+		Expires:  cookie.Expires,
+		Secure:   cookie.Secure,
+		HttpOnly: cookie.HTTPOnly,
+	}
+	if !cookie.HostOnly {
+		httpCookie.Domain = cookie.Domain
+	}
+	j.jar.SetCookies(&url.URL{Scheme: "https", Host: cookie.Domain, Path: "/"}, []*http.Cookie{httpCookie})
+}
+
+// remove drops a cookie from the jar. Callers hold j.mu.
+func (j *Jar) remove(cookie StoredCookie) {
+	delete(j.cookies, cookie.key())
+	httpCookie := &http.Cookie{Name: cookie.Name, Path: cookie.Path, MaxAge: -1}
+	if !cookie.HostOnly {
+		httpCookie.Domain = cookie.Domain
+	}
+	j.jar.SetCookies(&url.URL{Scheme: "https", Host: cookie.Domain, Path: "/"}, []*http.Cookie{httpCookie})
+}
+
+// save writes the jar to a temporary file first so that a crash never leaves
+// half a jar behind. Cookies can be credentials, so only the user may read it.
+func (j *Jar) save() error {
+	stored := make([]StoredCookie, 0, len(j.cookies))
+	for _, cookie := range j.cookies {
+		stored = append(stored, cookie)
+	}
+	sort.Slice(stored, func(a, b int) bool { return stored[a].key() < stored[b].key() })
+	data, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// ParseCookies reads cookies exported from a browser: either a Netscape
+// cookies.txt file, as written by most cookie export extensions, or a Cookie
+// header such as "cf_clearance=...; other=...". Only Royal Road's cookies are
+// kept from a cookies.txt; a Cookie header's are all taken to be Royal Road's.
+func ParseCookies(r io.Reader) ([]StoredCookie, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return nil, fmt.Errorf("no cookies given")
+	}
+
+	site, _ := url.Parse(royalroad.DefaultBaseURL)
+	host := site.Hostname()
+	if !strings.Contains(text, "\t") {
+		text = strings.TrimPrefix(text, "Cookie:")
+		var cookies []StoredCookie
+		for _, pair := range strings.Split(text, ";") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			name, value, ok := strings.Cut(pair, "=")
+			if !ok || name == "" {
+				return nil, fmt.Errorf("invalid cookie %q: expected name=value", pair)
+			}
+			cookies = append(cookies, StoredCookie{Name: name, Value: value, Domain: host, Path: "/", HostOnly: true})
+		}
+		if len(cookies) == 0 {
+			return nil, fmt.Errorf("no cookies given")
+		}
+		return cookies, nil
+	}
+
+	var cookies []StoredCookie
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.TrimRight(scanner.Text(), "\r")
+		httpOnly := strings.HasPrefix(fields, "#HttpOnly_")
+		fields = strings.TrimPrefix(fields, "#HttpOnly_")
+		if fields == "" || strings.HasPrefix(fields, "#") {
+			continue
+		}
+		parts := strings.Split(fields, "\t")
+		if len(parts) != 7 {
+			return nil, fmt.Errorf("line %d: expected 7 tab-separated fields, got %d", line, len(parts))
+		}
+		cookie := StoredCookie{
+			Name:     parts[5],
+			Value:    parts[6],
+			Domain:   strings.TrimPrefix(strings.ToLower(parts[0]), "."),
+			Path:     parts[2],
+			HostOnly: strings.EqualFold(parts[1], "FALSE"),
+			Secure:   strings.EqualFold(parts[3], "TRUE"),
+			HTTPOnly: httpOnly,
+		}
+		if expires, err := strconv.ParseInt(parts[4], 10, 64); err == nil && expires > 0 {
+			cookie.Expires = time.Unix(expires, 0)
+		}
+		if host != cookie.Domain && !strings.HasSuffix(host, "."+cookie.Domain) {
+			continue
+		}
+		if !cookie.expired() {
+			cookies = append(cookies, cookie)
+		}
+	}
+	if len(cookies) == 0 {
+		return nil, fmt.Errorf("no unexpired cookies for %s found", host)
+	}
+	return cookies, nil
+}
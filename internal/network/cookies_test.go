@@ -0,0 +1,84 @@
+package network
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCookies(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []StoredCookie
+		wantErr string
+	}{
+		{
+			name:  "cookie header",
+			input: "cf_clearance=abc; .AspNetCore.Identity=def",
+			want: []StoredCookie{
+				{Name: "cf_clearance", Value: "abc", Domain: "www.royalroad.com", Path: "/", HostOnly: true},
+				{Name: ".AspNetCore.Identity", Value: "def", Domain: "www.royalroad.com", Path: "/", HostOnly: true},
+			},
+		},
+		{
+			name:  "cookie header with its name",
+			input: "Cookie: a=1;",
+			want:  []StoredCookie{{Name: "a", Value: "1", Domain: "www.royalroad.com", Path: "/", HostOnly: true}},
+		},
+		{
+			name:  "value containing =",
+			input: "token=a=b",
+			want:  []StoredCookie{{Name: "token", Value: "a=b", Domain: "www.royalroad.com", Path: "/", HostOnly: true}},
+		},
+		{
+			name: "cookies.txt keeps only Royal Road's",
+			input: "# Netscape HTTP Cookie File\n" +
+				".royalroad.com\tTRUE\t/\tTRUE\t0\tcf_clearance\tabc\n" +
+				"#HttpOnly_www.royalroad.com\tFALSE\t/\tFALSE\t0\tsession\tdef\r\n" +
+				".example.com\tTRUE\t/\tFALSE\t0\tother\tghi\n",
+			want: []StoredCookie{
+				{Name: "cf_clearance", Value: "abc", Domain: "royalroad.com", Path: "/", Secure: true},
+				{Name: "session", Value: "def", Domain: "www.royalroad.com", Path: "/", HostOnly: true, HTTPOnly: true},
+			},
+		},
+		{
+			name: "cookies.txt skips expired cookies",
+			input: ".royalroad.com\tTRUE\t/\tFALSE\t1\told\tabc\n" +
+				".royalroad.com\tTRUE\t/\tFALSE\t0\tnew\tdef\n",
+			want: []StoredCookie{{Name: "new", Value: "def", Domain: "royalroad.com", Path: "/"}},
+		},
+		{name: "empty", input: "  \n", wantErr: "no cookies given"},
+		{name: "only separators", input: "; ;", wantErr: "no cookies given"},
+		{name: "pair without =", input: "a=1; b", wantErr: `invalid cookie "b"`},
+		{name: "pair without a name", input: "=1", wantErr: `invalid cookie "=1"`},
+		{name: "short cookies.txt line", input: "royalroad.com\tTRUE\t/\n", wantErr: "line 1: expected 7 tab-separated fields, got 3"},
+		{
+			name:    "cookies.txt for another site",
+			input:   ".example.com\tTRUE\t/\tFALSE\t0\tother\tghi\n",
+			wantErr: "no unexpired cookies for www.royalroad.com found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCookies(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseCookies() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCookies() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseCookies() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("cookie %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
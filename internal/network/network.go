@@ -0,0 +1,156 @@
+// Package network builds the Royal Road client used by every command and
+// screen, configured by the "http" section of config.json.
+package network
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// httpCacheMaxAge is how long a page stays cached after it was last fetched;
+// older ones are pruned once per run.
+const httpCacheMaxAge = 30 * 24 * time.Hour
+
+var (
+	httpCache     *royalroad.HTTPCache
+	httpCacheOnce sync.Once
+	limiter       *royalroad.RateLimiter
+	limiterOnce   sync.Once
+)
+
+// Overrides are the --user-agent, --proxy and --header flags, which win over
+// the config.
+type Overrides struct {
+	UserAgent string
+	Proxy     string
+	Headers   []string // "Name: value"
+}
+
+var overrides Overrides
+
+// SetOverrides applies the command-line flags to every client made after.
+func SetOverrides(o Overrides) {
+	overrides = o
+}
+
+// HTTPCacheDir returns where fetched pages are kept, beside the offline
+// chapter cache.
+func HTTPCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "royal-road-cli", "http"), nil
+}
+
+// ClearHTTPCache removes every cached page.
+func ClearHTTPCache() error {
+	dir, err := HTTPCacheDir()
+	if err != nil {
+		return err
+	}
+	return royalroad.NewHTTPCache(dir, 0).Clear()
+}
+
+// NewClient returns a client set up from cfg, loading the config if cfg is
+// nil. Clients share one HTTP cache, so pages fetched by one screen are
+// reused by the next, one rate limit, so that together they stay polite, and
+// the cookie jar in cookies.json.
+func NewClient(cfg *config.Config) *royalroad.Client {
+	if cfg == nil {
+		cfg, _ = config.Load()
+	}
+
+	limiterOnce.Do(func() {
+		limiter = royalroad.NewRateLimiter(cfg.HTTP.RequestsPerSecond)
+	})
+	retries := royalroad.DefaultRetryPolicy()
+	retries.MaxRetries = max(cfg.HTTP.MaxRetries, 0)
+
+	opts := []royalroad.Option{
+		royalroad.WithRateLimiter(limiter),
+		royalroad.WithRetries(retries),
+	}
+	if jar := sharedJar(); jar != nil {
+		opts = append(opts, royalroad.WithCookieJar(jar))
+	}
+	if ttl := cfg.HTTP.CacheTTL(); ttl > 0 {
+		httpCacheOnce.Do(func() {
+			if dir, err := HTTPCacheDir(); err == nil {
+				httpCache = royalroad.NewHTTPCache(dir, ttl)
+				go httpCache.Prune(httpCacheMaxAge)
+			}
+		})
+		if httpCache != nil {
+			opts = append(opts, royalroad.WithHTTPCache(httpCache))
+		}
+	}
+
+	userAgent := cfg.HTTP.UserAgent
+	if overrides.UserAgent != "" {
+		userAgent = overrides.UserAgent
+	}
+	if userAgent != "" {
+		opts = append(opts, royalroad.WithUserAgent(userAgent))
+	}
+	// Merged first so that a --header replaces the config's header of the
+	// same name rather than being sent beside it
+	headers := make(http.Header)
+	for key, value := range cfg.HTTP.Headers {
+		headers.Set(key, value)
+	}
+	for _, header := range overrides.Headers {
+		key, value, _ := strings.Cut(header, ":")
+		headers.Set(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+	for key, values := range headers {
+		opts = append(opts, royalroad.WithHeader(key, values[0]))
+	}
+
+	proxy := cfg.HTTP.Proxy
+	if overrides.Proxy != "" {
+		proxy = overrides.Proxy
+	}
+	if proxy != "" {
+		opts = append(opts, royalroad.WithHTTPClient(proxyClient(proxy)))
+	}
+	return royalroad.NewClient(opts...)
+}
+
+// ParseProxy checks a proxy URL, which needs an http, https or socks5 scheme
+// and a host.
+func ParseProxy(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy %q: %w", raw, err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy %q: the scheme must be http, https or socks5", raw)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q: no host", raw)
+	}
+	return proxyURL, nil
+}
+
+// proxyClient sends every request through proxy. An invalid proxy fails each
+// request with the reason rather than quietly connecting directly.
+func proxyClient(proxy string) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	proxyURL, err := ParseProxy(proxy)
+	transport.Proxy = func(*http.Request) (*url.URL, error) {
+		return proxyURL, err
+	}
+	return &http.Client{Timeout: 30 * time.Second, Transport: transport}
+}
@@ -0,0 +1,56 @@
+// Package notify shows native desktop notifications using whatever the
+// platform ships with: notify-send on Linux and BSD, osascript on macOS and
+// a PowerShell toast on Windows.
+package notify
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnsupported is returned when no notification tool is available.
+var ErrUnsupported = errors.New("desktop notifications need notify-send (libnotify), osascript or PowerShell")
+
+// Send shows a notification with a title and a body.
+func Send(title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := "display notification " + appleScriptString(body) + " with title " + appleScriptString(title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", toastScript(title, body))
+	default:
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return ErrUnsupported
+		}
+		cmd = exec.Command("notify-send", "--app-name=royal-road-cli", title, body)
+	}
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return ErrUnsupported
+		}
+		return err
+	}
+	return nil
+}
+
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func toastScript(title, body string) string {
+	return `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode(` + powerShellString(title) + `)) > $null
+$text.Item(1).AppendChild($template.CreateTextNode(` + powerShellString(body) + `)) > $null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('royal-road-cli').Show($toast)`
+}
@@ -0,0 +1,45 @@
+package render
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Image is an <img> found in chapter HTML.
+type Image struct {
+	URL string
+	Alt string
+}
+
+// ImagePlaceholder is the line shown in place of an image.
+func ImagePlaceholder(alt string) string {
+	alt = strings.Join(strings.Fields(alt), " ")
+	if alt == "" {
+		return "[Image]"
+	}
+	return "[Image: " + alt + "]"
+}
+
+// Images lists the images in chapter HTML in document order, the same order
+// as their placeholders in CleanHTML's output. Relative sources are resolved
+// against base.
+func Images(htmlContent string, base string) []Image {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil
+	}
+	baseURL, _ := url.Parse(base)
+
+	var images []Image
+	doc.Find("img").Each(func(_ int, s *goquery.Selection) {
+		src, _ := s.Attr("src")
+		alt, _ := s.Attr("alt")
+		if ref, err := url.Parse(strings.TrimSpace(src)); err == nil && baseURL != nil {
+			src = baseURL.ResolveReference(ref).String()
+		}
+		images = append(images, Image{URL: src, Alt: alt})
+	})
+	return images
+}
@@ -0,0 +1,375 @@
+// Package render turns Royal Road chapter HTML into plain text for the
+// terminal reader and plain-text exports.
+package render
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mattn/go-runewidth"
+	nethtml "golang.org/x/net/html"
+)
+
+var tagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// QuotePrefix marks each line of a blockquote, once per level of nesting.
+const QuotePrefix = "│ "
+
+// SceneBreak stands in for <hr>.
+const SceneBreak = "* * *"
+
+// CleanHTML renders chapter HTML as plain text by walking the DOM. Paragraphs
+// are separated by blank lines and <br> becomes a line break within a
+// paragraph. <hr> becomes SceneBreak, <img> an ImagePlaceholder, blockquote
+// lines start with QuotePrefix, and an intentionally empty paragraph (such as
+// <p>&nbsp;</p>) is kept as an empty paragraph, i.e. an extra blank line.
+func CleanHTML(htmlContent string) string {
+	return renderHTML(htmlContent, false)
+}
+
+// Styled renders chapter HTML like CleanHTML, but keeps <em>, <strong>, <u>
+// and <s> as ANSI italic, bold, underline and strikethrough. Wrap keeps the
+// styles intact across line breaks.
+func Styled(htmlContent string) string {
+	return renderHTML(htmlContent, true)
+}
+
+func renderHTML(htmlContent string, styled bool) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return strings.TrimSpace(html.UnescapeString(tagRegex.ReplaceAllString(htmlContent, " ")))
+	}
+
+	r := &textRenderer{styled: styled, styles: make(map[string]int)}
+	r.walk(doc.Find("body"))
+	r.flush()
+	for len(r.paragraphs) > 0 && r.paragraphs[len(r.paragraphs)-1] == "" {
+		r.paragraphs = r.paragraphs[:len(r.paragraphs)-1]
+	}
+	return strings.Join(r.paragraphs, "\n\n")
+}
+
+// textRenderer collects paragraphs while walking the DOM. Inline content
+// accumulates in current until a block element ends the paragraph.
+type textRenderer struct {
+	paragraphs []string
+	current    strings.Builder
+	quoteDepth int
+
+	styled  bool
+	styles  map[string]int // open elements per inline style
+	pending string         // style codes from a line with no visible text
+}
+
+// blockElements start and end a paragraph.
+var blockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "header": true, "footer": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "table": true, "tr": true, "pre": true,
+	"figure": true, "figcaption": true,
+}
+
+func (r *textRenderer) walk(s *goquery.Selection) {
+	s.Contents().Each(func(_ int, child *goquery.Selection) {
+		node := child.Get(0)
+		switch node.Type {
+		case nethtml.TextNode:
+			r.text(node.Data)
+		case nethtml.ElementNode:
+			r.element(goquery.NodeName(child), child)
+		}
+	})
+}
+
+func (r *textRenderer) element(tag string, s *goquery.Selection) {
+	switch {
+	case tag == "script" || tag == "style" || tag == "noscript":
+	case tag == "br":
+		r.current.WriteString("\n")
+	case tag == "hr":
+		r.flush()
+		r.paragraphs = append(r.paragraphs, SceneBreak)
+	case tag == "img":
+		r.flush()
+		alt, _ := s.Attr("alt")
+		r.addParagraph([]string{ImagePlaceholder(alt)})
+	case tag == "blockquote":
+		r.flush()
+		r.quoteDepth++
+		r.walk(s)
+		r.flush()
+		r.quoteDepth--
+	case tag == "td" || tag == "th":
+		r.walk(s)
+		r.current.WriteString("  ")
+	case r.styled && inlineStyles[tag].on != "":
+		style := inlineStyles[tag]
+		if r.styles[style.on]++; r.styles[style.on] == 1 {
+			r.current.WriteString(style.on)
+		}
+		r.walk(s)
+		if r.styles[style.on]--; r.styles[style.on] == 0 {
+			r.current.WriteString(style.off)
+		}
+	case blockElements[tag]:
+		r.flush()
+		before := len(r.paragraphs)
+		if tag == "li" {
+			r.current.WriteString("• ")
+		}
+		r.walk(s)
+		r.flush()
+		if len(r.paragraphs) == before && (tag == "p" || tag == "div") {
+			r.blank()
+		}
+	default:
+		r.walk(s)
+	}
+}
+
+// text adds inline text, collapsing source whitespace the way a browser would.
+func (r *textRenderer) text(data string) {
+	collapsed := strings.Join(strings.Fields(data), " ")
+	if collapsed == "" {
+		if data != "" {
+			r.current.WriteString(" ")
+		}
+		return
+	}
+	if startsWithSpace(data) {
+		r.current.WriteString(" ")
+	}
+	r.current.WriteString(collapsed)
+	if endsWithSpace(data) {
+		r.current.WriteString(" ")
+	}
+}
+
+// flush ends the current paragraph. Blank lines left by consecutive <br>s
+// split it into separate paragraphs.
+func (r *textRenderer) flush() {
+	text := r.current.String()
+	r.current.Reset()
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = trimLine(r.pending + line)
+		r.pending = ""
+		if StripANSI(line) == "" {
+			// Keep its style codes for the next line so none are lost
+			r.pending = strings.Join(ansiRegex.FindAllString(line, -1), "")
+			r.addParagraph(lines)
+			lines = nil
+			continue
+		}
+		lines = append(lines, line)
+	}
+	r.addParagraph(lines)
+}
+
+func (r *textRenderer) addParagraph(lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	prefix := strings.Repeat(QuotePrefix, r.quoteDepth)
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	r.paragraphs = append(r.paragraphs, strings.Join(lines, "\n"))
+}
+
+// blank records an intentionally empty paragraph; runs of them collapse into
+// one, and they are dropped at the start of the text.
+func (r *textRenderer) blank() {
+	if n := len(r.paragraphs); n > 0 && r.paragraphs[n-1] != "" {
+		r.paragraphs = append(r.paragraphs, "")
+	}
+}
+
+func startsWithSpace(s string) bool {
+	c, _ := utf8.DecodeRuneInString(s)
+	return strings.ContainsRune(" \t\n\r\f\u00a0", c)
+}
+
+func endsWithSpace(s string) bool {
+	c, _ := utf8.DecodeLastRuneInString(s)
+	return strings.ContainsRune(" \t\n\r\f\u00a0", c)
+}
+
+// WordCount counts the words in chapter HTML as the reader would show them.
+func WordCount(htmlContent string) int {
+	return len(strings.Fields(html.UnescapeString(tagRegex.ReplaceAllString(htmlContent, " "))))
+}
+
+// Layout controls the spacing Wrap adds around lines and paragraphs.
+type Layout struct {
+	LineSpacing     int // Blank lines after each line within a paragraph
+	ParagraphGap    int // Blank lines between paragraphs
+	ParagraphIndent int // Spaces before the first line of each paragraph
+}
+
+// DefaultLayout is single-spaced with a blank line between paragraphs.
+var DefaultLayout = Layout{ParagraphGap: 1}
+
+// Wrap word-wraps text from CleanHTML or Styled to width columns using
+// DefaultLayout.
+func Wrap(text string, width int) string {
+	return WrapLayout(text, width, DefaultLayout)
+}
+
+// WrapLayout word-wraps text from CleanHTML or Styled to width columns.
+// Paragraphs are separated by layout's gap (or its line spacing if larger),
+// each empty paragraph adds one more blank line, line breaks within a
+// paragraph are kept, and wrapped blockquote lines repeat their QuotePrefix.
+// Scene breaks and image placeholders are never indented.
+//
+// Widths are measured in terminal columns, so wide CJK characters count
+// double and combining marks not at all, and words too long for a line are
+// broken between characters. Style codes take up no width, and every line
+// closes the styles it leaves open so lines can be shown on their own.
+func WrapLayout(text string, width int, layout Layout) string {
+	if width <= 20 {
+		width = 40 // Minimum readable width
+	}
+	gap := max(layout.ParagraphGap, layout.LineSpacing, 0)
+	spacing := make([]string, max(layout.LineSpacing, 0))
+
+	var lines []string
+	styles := styleState{}
+	extra := 0 // empty paragraphs since the last one with text
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if strings.TrimSpace(paragraph) == "" {
+			extra++
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, make([]string, gap+extra)...)
+		}
+		extra = 0
+
+		indent := max(layout.ParagraphIndent, 0)
+		if plain := StripANSI(paragraph); plain == SceneBreak || strings.HasPrefix(plain, "[Image") {
+			indent = 0
+		}
+		var wrapped []string
+		for i, line := range strings.Split(paragraph, "\n") {
+			if i > 0 {
+				indent = 0
+			}
+			wrapped = append(wrapped, wrapLine(line, width, indent, styles)...)
+		}
+		for i, line := range wrapped {
+			if i > 0 {
+				lines = append(lines, spacing...)
+			}
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapLine wraps one line of a paragraph, indenting the first wrapped line by
+// indent spaces.
+func wrapLine(line string, width, indent int, styles styleState) []string {
+	prefix := ""
+	for strings.HasPrefix(line[len(prefix):], QuotePrefix) {
+		prefix += QuotePrefix
+	}
+	text := line[len(prefix):]
+	fullWidth := max(width-runewidth.StringWidth(prefix), 10)
+	width = max(fullWidth-indent, 10)
+	prefix += strings.Repeat(" ", indent)
+
+	var lines []string
+	currentLine, currentLen := "", 0
+	endLine := func() {
+		lines = append(lines, prefix+currentLine+styles.close())
+		currentLine, currentLen = "", 0
+		// Only the first line is indented
+		prefix = strings.TrimSuffix(prefix, strings.Repeat(" ", indent))
+		width = fullWidth
+	}
+	place := func(word string, wordLen int) {
+		if currentLen == 0 {
+			currentLine = styles.open() + word
+			currentLen = wordLen
+		} else {
+			currentLine += " " + word
+			currentLen += wordLen + 1
+		}
+		styles.apply(word)
+	}
+
+	codes := "" // style codes waiting for the next visible word
+	for _, word := range strings.Fields(text) {
+		wordLen := visibleLen(word)
+		if wordLen == 0 {
+			codes += word
+			continue
+		}
+		word, codes = codes+word, ""
+
+		switch {
+		case currentLen > 0 && currentLen+wordLen+1 <= width:
+			place(word, wordLen)
+		case wordLen <= width:
+			if currentLen > 0 {
+				endLine()
+			}
+			place(word, wordLen)
+		default:
+			// Too long for any line, e.g. CJK text without spaces: break it
+			// between characters, filling the current line first
+			room := width
+			if currentLen > 0 {
+				room = width - currentLen - 1
+				if room < 2 {
+					endLine()
+					room = width
+				}
+			}
+			pieces := breakWord(word, room, fullWidth)
+			for i, piece := range pieces {
+				if i > 0 {
+					endLine()
+				}
+				place(piece, visibleLen(piece))
+			}
+		}
+	}
+	styles.apply(codes)
+	if currentLen > 0 {
+		endLine()
+	}
+	return lines
+}
+
+// breakWord splits a word into pieces no wider than first columns for the
+// first piece and width for the rest. Zero-width runes such as combining
+// marks stay with the character before them.
+func breakWord(word string, first, width int) []string {
+	var pieces []string
+	var piece strings.Builder
+	pieceLen, limit := 0, first
+	for len(word) > 0 {
+		if loc := ansiRegex.FindStringIndex(word); loc != nil && loc[0] == 0 {
+			piece.WriteString(word[:loc[1]])
+			word = word[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(word)
+		word = word[size:]
+		w := runewidth.RuneWidth(r)
+		if pieceLen > 0 && w > 0 && pieceLen+w > limit {
+			pieces = append(pieces, piece.String())
+			piece.Reset()
+			pieceLen, limit = 0, width
+		}
+		piece.WriteRune(r)
+		pieceLen += w
+	}
+	return append(pieces, piece.String())
+}
@@ -0,0 +1,108 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+type inlineStyle struct {
+	on, off string
+}
+
+var (
+	italic        = inlineStyle{"\x1b[3m", "\x1b[23m"}
+	bold          = inlineStyle{"\x1b[1m", "\x1b[22m"}
+	underline     = inlineStyle{"\x1b[4m", "\x1b[24m"}
+	strikethrough = inlineStyle{"\x1b[9m", "\x1b[29m"}
+)
+
+// styleOrder fixes the order styles are reopened in on a new line.
+var styleOrder = []inlineStyle{bold, italic, underline, strikethrough}
+
+var inlineStyles = map[string]inlineStyle{
+	"em": italic, "i": italic,
+	"strong": bold, "b": bold,
+	"u": underline, "ins": underline,
+	"s": strikethrough, "strike": strikethrough, "del": strikethrough,
+}
+
+var ansiRegex = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// StripANSI removes terminal style codes, leaving the visible text.
+func StripANSI(s string) string {
+	return ansiRegex.ReplaceAllString(s, "")
+}
+
+// visibleLen is the display width of s in terminal columns, ignoring style codes.
+func visibleLen(s string) int {
+	return runewidth.StringWidth(StripANSI(s))
+}
+
+// trimLine trims whitespace from both ends of a line, looking past style
+// codes so that e.g. "\x1b[3m Hello" loses its space but keeps its code.
+func trimLine(line string) string {
+	var lead, trail string
+	for {
+		if code := ansiRegex.FindString(line); code != "" && strings.HasPrefix(line, code) {
+			lead += code
+			line = line[len(code):]
+		} else if trimmed := strings.TrimLeftFunc(line, unicode.IsSpace); trimmed != line {
+			line = trimmed
+		} else {
+			break
+		}
+	}
+	for {
+		if loc := ansiRegex.FindAllStringIndex(line, -1); len(loc) > 0 && loc[len(loc)-1][1] == len(line) {
+			last := loc[len(loc)-1]
+			trail = line[last[0]:] + trail
+			line = line[:last[0]]
+		} else if trimmed := strings.TrimRightFunc(line, unicode.IsSpace); trimmed != line {
+			line = trimmed
+		} else {
+			break
+		}
+	}
+	return lead + line + trail
+}
+
+// styleState tracks which styles are open while Wrap splits styled text into
+// lines, so that every line can be shown on its own: each closes its styles
+// at the end and the next reopens them. Pages then start correctly styled.
+type styleState map[inlineStyle]bool
+
+func (st styleState) apply(word string) {
+	for _, code := range ansiRegex.FindAllString(word, -1) {
+		for _, style := range styleOrder {
+			switch code {
+			case style.on:
+				st[style] = true
+			case style.off:
+				delete(st, style)
+			}
+		}
+	}
+}
+
+func (st styleState) open() string {
+	var codes strings.Builder
+	for _, style := range styleOrder {
+		if st[style] {
+			codes.WriteString(style.on)
+		}
+	}
+	return codes.String()
+}
+
+func (st styleState) close() string {
+	var codes strings.Builder
+	for _, style := range styleOrder {
+		if st[style] {
+			codes.WriteString(style.off)
+		}
+	}
+	return codes.String()
+}
@@ -0,0 +1,182 @@
+// Package search finds phrases in the chapters of the offline cache.
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/internal/render"
+)
+
+// Hit is one occurrence of the query in a cached chapter.
+type Hit struct {
+	FictionID    int
+	FictionTitle string
+	ChapterIndex int // 0-based position in the fiction
+	ChapterID    int
+	ChapterTitle string
+	Position     float64 // How far into the chapter text the match is (0.0-1.0)
+	Snippet      string  // The match with some surrounding text
+}
+
+// Options narrow a search.
+type Options struct {
+	FictionID     int // Only search this fiction (0 for the whole library)
+	Limit         int // Stop after this many hits (0 for no limit)
+	PerChapter    int // At most this many hits per chapter (0 for no limit)
+	CaseSensitive bool
+}
+
+// snippetRadius is how many characters of context are kept either side of a match.
+const snippetRadius = 60
+
+// Library searches every cached chapter for query, in library then chapter
+// order. Chapters are cleaned the same way the reader shows them, so markup
+// never produces or hides matches.
+func Library(store *cache.Store, query string, opts Options) ([]Hit, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, nil
+	}
+
+	fictionIDs := []int{opts.FictionID}
+	if opts.FictionID == 0 {
+		var err error
+		if fictionIDs, err = store.Fictions(); err != nil {
+			return nil, err
+		}
+	}
+
+	var hits []Hit
+	for _, fictionID := range fictionIDs {
+		fiction, err := store.LoadFiction(fictionID)
+		if err != nil {
+			continue
+		}
+		cached := store.CachedChapters(fictionID)
+
+		for index, info := range fiction.Chapters {
+			if !cached[info.ID] {
+				continue
+			}
+			chapter, err := store.LoadChapter(fictionID, info.ID)
+			if err != nil {
+				continue
+			}
+
+			chapterOpts := opts
+			if opts.Limit > 0 {
+				chapterOpts.Limit = opts.Limit - len(hits)
+			}
+			for _, hit := range Chapter(chapter.Content, query, chapterOpts) {
+				hit.FictionID = fictionID
+				hit.FictionTitle = fiction.Title
+				hit.ChapterIndex = index
+				hit.ChapterID = info.ID
+				hit.ChapterTitle = info.Title
+				hits = append(hits, hit)
+			}
+			if opts.Limit > 0 && len(hits) >= opts.Limit {
+				return hits, nil
+			}
+		}
+	}
+	return hits, nil
+}
+
+// Chapter finds query in one chapter's HTML. Only each hit's Position and
+// Snippet are filled in; the caller knows which chapter it is. FictionID is
+// ignored and Limit caps the hits from this chapter.
+func Chapter(content, query string, opts Options) []Hit {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+	text := strings.Join(strings.Fields(render.CleanHTML(content)), " ")
+
+	var hits []Hit
+	for offset := 0; ; {
+		at, length := index(text[offset:], query, opts.CaseSensitive)
+		if at < 0 {
+			break
+		}
+		at += offset
+		hits = append(hits, Hit{
+			Position: float64(at) / float64(max(len(text), 1)),
+			Snippet:  snippet(text, at, length),
+		})
+		if (opts.Limit > 0 && len(hits) >= opts.Limit) || (opts.PerChapter > 0 && len(hits) >= opts.PerChapter) {
+			break
+		}
+		offset = at + length
+	}
+	return hits
+}
+
+// index finds the first match of needle in s, returning its byte offset and
+// length, or -1. Without caseSensitive runes are compared under Unicode case
+// folding, where a match can differ from needle in length (the Kelvin sign
+// folds to "k", "ſ" to "s"), so offsets stay those of s.
+func index(s, needle string, caseSensitive bool) (int, int) {
+	if caseSensitive {
+		return strings.Index(s, needle), len(needle)
+	}
+	for at := 0; at < len(s); {
+		if length := foldPrefix(s[at:], needle); length >= 0 {
+			return at, length
+		}
+		_, size := utf8.DecodeRuneInString(s[at:])
+		at += size
+	}
+	return -1, 0
+}
+
+// foldPrefix returns how many bytes at the start of s match needle rune for
+// rune under case folding, or -1 if s doesn't start with it.
+func foldPrefix(s, needle string) int {
+	n := 0
+	for _, want := range needle {
+		if n >= len(s) {
+			return -1
+		}
+		got, size := utf8.DecodeRuneInString(s[n:])
+		if got != want && !equalFold(got, want) {
+			return -1
+		}
+		n += size
+	}
+	return n
+}
+
+// equalFold reports whether a and b are the same letter in another case.
+// SimpleFold cycles through every case of a rune and back to it.
+func equalFold(a, b rune) bool {
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
+			return true
+		}
+	}
+	return false
+}
+
+// snippet cuts the text around a match on rune boundaries.
+func snippet(text string, at, length int) string {
+	start := max(at-snippetRadius, 0)
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	end := min(at+length+snippetRadius, len(text))
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	s := strings.TrimSpace(text[start:end])
+	if start > 0 {
+		s = "…" + s
+	}
+	if end < len(text) {
+		s += "…"
+	}
+	return s
+}
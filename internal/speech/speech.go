@@ -0,0 +1,263 @@
+// Package speech reads text aloud with the platform's speech engine: say on
+// macOS, SAPI on Windows, espeak-ng (or espeak) elsewhere, or piper.
+package speech
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Engine names accepted by New.
+const (
+	Say    = "say"
+	SAPI   = "sapi"
+	Espeak = "espeak-ng"
+	Piper  = "piper"
+)
+
+// ErrNoEngine is returned when no speech engine is installed.
+var ErrNoEngine = errors.New("no speech engine found; install espeak-ng or piper, or set ttsEngine in config.json")
+
+// Engine speaks text with one of the supported programs.
+type Engine struct {
+	Name  string
+	Voice string // Voice name, or the model file for piper; empty for the default
+	path  string
+}
+
+// New finds the named engine, or the first one available when name is empty.
+// voice picks a voice (a model file for piper); empty uses the default.
+func New(name, voice string) (*Engine, error) {
+	candidates := []string{name}
+	if name == "" {
+		switch runtime.GOOS {
+		case "darwin":
+			candidates = []string{Say}
+		case "windows":
+			candidates = []string{SAPI}
+		default:
+			candidates = []string{Espeak, "espeak"}
+			if voice != "" {
+				candidates = append(candidates, Piper)
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		program := candidate
+		switch candidate {
+		case SAPI:
+			program = "powershell"
+		case Piper:
+			if voice == "" {
+				return nil, errors.New("piper needs a voice model: set ttsVoice to the .onnx file in config.json")
+			}
+			if _, err := exec.LookPath("aplay"); err != nil {
+				return nil, errors.New("piper needs aplay to play its audio")
+			}
+		case Say, Espeak, "espeak":
+		default:
+			return nil, fmt.Errorf("unknown speech engine %q (use say, sapi, espeak-ng or piper)", candidate)
+		}
+		if path, err := exec.LookPath(program); err == nil {
+			return &Engine{Name: candidate, Voice: voice, path: path}, nil
+		}
+	}
+	if name != "" {
+		return nil, fmt.Errorf("speech engine %s is not installed", name)
+	}
+	return nil, ErrNoEngine
+}
+
+// stopDelay is how long a cancelled engine gets to exit, and to release its
+// output, before Speak gives up waiting for it.
+const stopDelay = time.Second
+
+// Speak reads text aloud at about wpm words per minute (0 for the engine's
+// default) and returns once it has finished or ctx is cancelled.
+func (e *Engine) Speak(ctx context.Context, text string, wpm int) error {
+	if e.Name == Piper {
+		return e.speakPiper(ctx, text, wpm)
+	}
+	cmd := e.command(ctx, wpm)
+	cmd.Stdin = strings.NewReader(text)
+	output, err := cmd.CombinedOutput()
+	return e.result(ctx, err, output)
+}
+
+func (e *Engine) result(ctx context.Context, err error, output []byte) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		if message := strings.TrimSpace(string(output)); message != "" {
+			return fmt.Errorf("%s: %s", e.Name, message)
+		}
+		return fmt.Errorf("%s: %w", e.Name, err)
+	}
+	return nil
+}
+
+// speakPiper pipes piper's raw audio into aplay. Each runs as its own
+// process, so cancelling ctx kills both rather than a shell between them.
+func (e *Engine) speakPiper(ctx context.Context, text string, wpm int) error {
+	scale := "1"
+	if wpm > 0 {
+		scale = strconv.FormatFloat(180/float64(wpm), 'f', 2, 64)
+	}
+	var piperOutput, aplayOutput bytes.Buffer
+	piper := exec.CommandContext(ctx, e.path, "--model", e.Voice, "--length_scale", scale, "--output-raw")
+	piper.Stdin = strings.NewReader(text)
+	piper.Stderr = &piperOutput
+	piper.WaitDelay = stopDelay
+	aplay := exec.CommandContext(ctx, "aplay", "-q", "-r", "22050", "-f", "S16_LE", "-t", "raw", "-")
+	aplay.Stderr = &aplayOutput
+	aplay.WaitDelay = stopDelay
+
+	audio, sink, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("%s: %w", e.Name, err)
+	}
+	piper.Stdout, aplay.Stdin = sink, audio
+	if err := aplay.Start(); err != nil {
+		audio.Close()
+		sink.Close()
+		return fmt.Errorf("%s: %w", e.Name, err)
+	}
+	err = piper.Start()
+	// The children hold their own ends; aplay sees the end of the audio once
+	// piper exits
+	audio.Close()
+	sink.Close()
+	if err != nil {
+		aplay.Process.Kill()
+		aplay.Wait()
+		return fmt.Errorf("%s: %w", e.Name, err)
+	}
+	piperErr := piper.Wait()
+	aplayErr := aplay.Wait()
+	return e.result(ctx, errors.Join(piperErr, aplayErr), append(piperOutput.Bytes(), aplayOutput.Bytes()...))
+}
+
+func (e *Engine) command(ctx context.Context, wpm int) *exec.Cmd {
+	var args []string
+	switch e.Name {
+	case Say:
+		args = []string{"-f", "-"}
+		if e.Voice != "" {
+			args = append(args, "-v", e.Voice)
+		}
+		if wpm > 0 {
+			args = append(args, "-r", strconv.Itoa(wpm))
+		}
+	case SAPI:
+		script := "Add-Type -AssemblyName System.Speech; $s = New-Object System.Speech.Synthesis.SpeechSynthesizer; "
+		if e.Voice != "" {
+			script += "$s.SelectVoice('" + strings.ReplaceAll(e.Voice, "'", "''") + "'); "
+		}
+		if wpm > 0 {
+			// SAPI rates run from -10 to 10 around roughly 180 wpm
+			rate := min(max((wpm-180)/20, -10), 10)
+			script += fmt.Sprintf("$s.Rate = %d; ", rate)
+		}
+		script += "$s.Speak([Console]::In.ReadToEnd())"
+		args = []string{"-NoProfile", "-Command", script}
+	default:
+		args = []string{"--stdin"}
+		if e.Voice != "" {
+			args = append(args, "-v", e.Voice)
+		}
+		if wpm > 0 {
+			args = append(args, "-s", strconv.Itoa(wpm))
+		}
+	}
+	cmd := exec.CommandContext(ctx, e.path, args...)
+	cmd.WaitDelay = stopDelay
+	return cmd
+}
+
+// Voices lists the voices the engine can use: voice names for say and SAPI,
+// languages for espeak and the models next to the current one for piper.
+func (e *Engine) Voices() ([]string, error) {
+	var voices []string
+	switch e.Name {
+	case Say:
+		output, err := exec.Command(e.path, "-v", "?").Output()
+		if err != nil {
+			return nil, err
+		}
+		// "Alex                en_US    # Most people recognize me by my voice."
+		for _, line := range strings.Split(string(output), "\n") {
+			if name, _, ok := strings.Cut(line, "  "); ok && strings.TrimSpace(name) != "" {
+				voices = append(voices, strings.TrimSpace(name))
+			}
+		}
+	case SAPI:
+		script := "Add-Type -AssemblyName System.Speech; (New-Object System.Speech.Synthesis.SpeechSynthesizer).GetInstalledVoices() | ForEach-Object { $_.VoiceInfo.Name }"
+		output, err := exec.Command(e.path, "-NoProfile", "-Command", script).Output()
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range strings.Split(string(output), "\n") {
+			if name := strings.TrimSpace(line); name != "" {
+				voices = append(voices, name)
+			}
+		}
+	case Piper:
+		models, err := filepath.Glob(filepath.Join(filepath.Dir(e.Voice), "*.onnx"))
+		if err != nil {
+			return nil, err
+		}
+		voices = models
+	default:
+		output, err := exec.Command(e.path, "--voices").Output()
+		if err != nil {
+			return nil, err
+		}
+		// " 5  en-us           --/M      English_(America)  gmw/en-US"
+		for i, line := range strings.Split(string(output), "\n") {
+			if fields := strings.Fields(line); i > 0 && len(fields) >= 2 {
+				voices = append(voices, fields[1])
+			}
+		}
+	}
+	sort.Strings(voices)
+	unique := voices[:0]
+	for i, voice := range voices {
+		if i == 0 || voice != voices[i-1] {
+			unique = append(unique, voice)
+		}
+	}
+	return unique, nil
+}
+
+// NextVoice returns the voice after current in voices, wrapping around.
+func NextVoice(voices []string, current string) string {
+	if len(voices) == 0 {
+		return current
+	}
+	for i, voice := range voices {
+		if voice == current {
+			return voices[(i+1)%len(voices)]
+		}
+	}
+	return voices[0]
+}
+
+// VoiceName is a short label for a voice, e.g. a piper model's file name.
+func VoiceName(voice string) string {
+	if voice == "" {
+		return "default voice"
+	}
+	return strings.TrimSuffix(filepath.Base(voice), ".onnx")
+}
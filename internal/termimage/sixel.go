@@ -0,0 +1,91 @@
+package termimage
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"io"
+)
+
+// sixelCellWidth is the assumed width of a terminal cell in pixels; sixel
+// images are sized in pixels rather than columns.
+const sixelCellWidth = 8
+
+// writeSixel scales img down to at most maxWidth pixels wide, dithers it to a
+// 256-colour palette and writes it as sixel graphics.
+func writeSixel(w io.Writer, img image.Image, maxWidth int) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return fmt.Errorf("empty image")
+	}
+	if width > maxWidth {
+		height = max(height*maxWidth/width, 1)
+		width = maxWidth
+	}
+
+	// Nearest-neighbour scaling is plenty for a terminal preview
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			scaled.Set(x, y, img.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height))
+		}
+	}
+	paletted := image.NewPaletted(scaled.Bounds(), palette.Plan9)
+	draw.FloydSteinberg.Draw(paletted, paletted.Bounds(), scaled, image.Point{})
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "\x1bPq\"1;1;%d;%d", width, height)
+	for i, c := range paletted.Palette {
+		r, g, b, _ := c.RGBA()
+		fmt.Fprintf(out, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, b*100/0xffff)
+	}
+
+	// Each band is six pixel rows; every colour used in it is drawn in turn,
+	// returning to the band's start with '$'
+	for top := 0; top < height; top += 6 {
+		used := make(map[uint8]bool)
+		for y := top; y < min(top+6, height); y++ {
+			for x := 0; x < width; x++ {
+				used[paletted.ColorIndexAt(x, y)] = true
+			}
+		}
+		for index := range used {
+			fmt.Fprintf(out, "#%d", index)
+			run, last := 0, byte(0)
+			for x := 0; x < width; x++ {
+				var bits byte
+				for row := 0; row < 6 && top+row < height; row++ {
+					if paletted.ColorIndexAt(x, top+row) == index {
+						bits |= 1 << row
+					}
+				}
+				if run > 0 && bits+63 != last {
+					writeSixelRun(out, last, run)
+					run = 0
+				}
+				last = bits + 63
+				run++
+			}
+			writeSixelRun(out, last, run)
+			out.WriteByte('$')
+		}
+		out.WriteByte('-')
+	}
+	out.WriteString("\x1b\\\n")
+	return out.Flush()
+}
+
+// writeSixelRun writes a sixel character repeated run times, using the
+// "!count" repeat form when it is shorter.
+func writeSixelRun(out *bufio.Writer, char byte, run int) {
+	if run > 3 {
+		fmt.Fprintf(out, "!%d%c", run, char)
+		return
+	}
+	for i := 0; i < run; i++ {
+		out.WriteByte(char)
+	}
+}
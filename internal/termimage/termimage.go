@@ -0,0 +1,139 @@
+// Package termimage draws images directly in terminals that support an
+// inline graphics protocol: kitty, iTerm2 or sixel.
+package termimage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// Protocol is a terminal graphics protocol.
+type Protocol string
+
+const (
+	None  Protocol = "none"
+	Kitty Protocol = "kitty"
+	ITerm Protocol = "iterm"
+	Sixel Protocol = "sixel"
+)
+
+// maxImageBytes bounds how much of an image is downloaded.
+const maxImageBytes = 20 << 20
+
+// Resolve returns the protocol named by configured ("kitty", "iterm", "sixel"
+// or "none"), or detects one from the environment when it is empty or "auto".
+func Resolve(configured string) Protocol {
+	switch p := Protocol(strings.ToLower(strings.TrimSpace(configured))); p {
+	case None, Kitty, ITerm, Sixel:
+		return p
+	}
+	return Detect()
+}
+
+// Detect guesses the terminal's graphics protocol from environment variables,
+// returning None if it doesn't recognise the terminal.
+func Detect() Protocol {
+	term := os.Getenv("TERM")
+	program := os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || program == "ghostty":
+		return Kitty
+	case program == "iTerm.app" || program == "WezTerm" || os.Getenv("LC_TERMINAL") == "iTerm2":
+		return ITerm
+	case strings.Contains(term, "sixel") || strings.HasPrefix(term, "foot") || strings.HasPrefix(term, "mlterm"):
+		return Sixel
+	}
+	return None
+}
+
+// Fetch downloads an image with client, so it goes through the same proxy,
+// User-Agent and headers as the chapter it is in.
+func Fetch(ctx context.Context, client *royalroad.Client, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	data, err := client.GetImage(ctx, url, maxImageBytes)
+	if err != nil {
+		return nil, fmt.Errorf("image request failed: %w", err)
+	}
+	return data, nil
+}
+
+// Draw writes data to w as an inline image at most cols terminal columns wide.
+func Draw(w io.Writer, p Protocol, data []byte, cols int) error {
+	switch p {
+	case Kitty:
+		return drawKitty(w, data, cols)
+	case ITerm:
+		return drawITerm(w, data, cols)
+	case Sixel:
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("can't decode image: %w", err)
+		}
+		return writeSixel(w, img, cols*sixelCellWidth)
+	}
+	return errors.New("this terminal can't show images")
+}
+
+// Clear removes images drawn with p, for protocols where they outlive the text.
+func Clear(w io.Writer, p Protocol) {
+	if p == Kitty {
+		fmt.Fprint(w, "\x1b_Ga=d\x1b\\")
+	}
+}
+
+// drawKitty sends a PNG, converting other formats first, in the 4096-byte
+// chunks the protocol requires.
+func drawKitty(w io.Writer, data []byte, cols int) error {
+	if http.DetectContentType(data) != "image/png" {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("can't decode image: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for first := true; len(encoded) > 0; first = false {
+		chunk := encoded[:min(4096, len(encoded))]
+		encoded = encoded[len(chunk):]
+		more := 0
+		if len(encoded) > 0 {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(w, "\x1b_Ga=T,f=100,c=%d,m=%d;%s\x1b\\", cols, more, chunk)
+		} else {
+			fmt.Fprintf(w, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// drawITerm uses iTerm2's inline file escape, which takes any format the
+// terminal can decode.
+func drawITerm(w io.Writer, data []byte, cols int) error {
+	_, err := fmt.Fprintf(w, "\x1b]1337;File=inline=1;size=%d;width=%d;preserveAspectRatio=1:%s\a\n",
+		len(data), cols, base64.StdEncoding.EncodeToString(data))
+	return err
+}
@@ -0,0 +1,157 @@
+// Package translate translates chapter text through a configurable web API:
+// LibreTranslate (self-hosted or public) or DeepL.
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// ErrNotConfigured is returned when no translation backend is set up.
+var ErrNotConfigured = errors.New(`translation isn't set up; add "translation": {"backend": "libretranslate" or "deepl", "apiKey": ..., "targetLanguage": ...} to config.json`)
+
+// Translator translates paragraphs of text into a target language, keeping
+// them in order.
+type Translator interface {
+	Translate(ctx context.Context, paragraphs []string, target string) ([]string, error)
+}
+
+const (
+	defaultLibreTranslateURL = "https://libretranslate.com"
+	deepLFreeURL             = "https://api-free.deepl.com"
+	deepLProURL              = "https://api.deepl.com"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// New returns the backend chosen in settings.
+func New(settings config.Translation) (Translator, error) {
+	switch strings.ToLower(settings.Backend) {
+	case "libretranslate", "libre":
+		baseURL := settings.URL
+		if baseURL == "" {
+			baseURL = defaultLibreTranslateURL
+		}
+		return &libreTranslate{baseURL: strings.TrimSuffix(baseURL, "/"), apiKey: settings.APIKey}, nil
+	case "deepl":
+		if settings.APIKey == "" {
+			return nil, errors.New("DeepL needs an apiKey under translation in config.json")
+		}
+		baseURL := settings.URL
+		if baseURL == "" {
+			// Free plan keys end in ":fx" and use their own host
+			baseURL = deepLProURL
+			if strings.HasSuffix(settings.APIKey, ":fx") {
+				baseURL = deepLFreeURL
+			}
+		}
+		return &deepL{baseURL: strings.TrimSuffix(baseURL, "/"), apiKey: settings.APIKey}, nil
+	case "":
+		return nil, ErrNotConfigured
+	}
+	return nil, fmt.Errorf("unknown translation backend %q (use libretranslate or deepl)", settings.Backend)
+}
+
+type libreTranslate struct {
+	baseURL string
+	apiKey  string
+}
+
+func (l *libreTranslate) Translate(ctx context.Context, paragraphs []string, target string) ([]string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"q":       paragraphs,
+		"source":  "auto",
+		"target":  strings.ToLower(target),
+		"format":  "text",
+		"api_key": l.apiKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.baseURL+"/translate", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		TranslatedText []string `json:"translatedText"`
+		Error          string   `json:"error"`
+	}
+	if err := do(req, &result); err != nil {
+		if result.Error != "" {
+			return nil, fmt.Errorf("LibreTranslate: %s", result.Error)
+		}
+		return nil, fmt.Errorf("LibreTranslate: %w", err)
+	}
+	if len(result.TranslatedText) != len(paragraphs) {
+		return nil, fmt.Errorf("LibreTranslate returned %d paragraphs for %d", len(result.TranslatedText), len(paragraphs))
+	}
+	return result.TranslatedText, nil
+}
+
+type deepL struct {
+	baseURL string
+	apiKey  string
+}
+
+func (d *deepL) Translate(ctx context.Context, paragraphs []string, target string) ([]string, error) {
+	form := url.Values{"target_lang": {strings.ToUpper(target)}, "text": paragraphs}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.baseURL+"/v2/translate", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+d.apiKey)
+
+	var result struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+		Message string `json:"message"`
+	}
+	if err := do(req, &result); err != nil {
+		if result.Message != "" {
+			return nil, fmt.Errorf("DeepL: %s", result.Message)
+		}
+		return nil, fmt.Errorf("DeepL: %w", err)
+	}
+	if len(result.Translations) != len(paragraphs) {
+		return nil, fmt.Errorf("DeepL returned %d paragraphs for %d", len(result.Translations), len(paragraphs))
+	}
+	translated := make([]string, len(result.Translations))
+	for i, translation := range result.Translations {
+		translated[i] = translation.Text
+	}
+	return translated, nil
+}
+
+// do sends req and decodes the JSON response into result, which is also
+// filled in for error responses so their message can be reported.
+func do(req *http.Request, result interface{}) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return err
+	}
+	decodeErr := json.Unmarshal(data, result)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return decodeErr
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/jackowfish/royal-road-cli/internal/render"
+)
+
+// Positions are remembered as a word offset into the chapter text as well as
+// a fraction of its lines. Re-wrapping for another terminal or text width
+// moves line breaks but never words, so the offset finds the same sentence
+// again where the fraction would drift.
+
+// textAnchor is a position in the chapter that survives re-layout.
+type textAnchor struct {
+	words    int     // Words of the chapter text above the position; 0 if unknown
+	progress float64 // Fallback fraction of the content lines
+}
+
+// anchor is the position of the top of the screen.
+func (m *ReaderModel) anchor() textAnchor {
+	position := m.currentPosition()
+	return textAnchor{words: position.words, progress: position.progress}
+}
+
+// seekAnchor moves to the page, or scroll position, holding a.
+func (m *ReaderModel) seekAnchor(a textAnchor) {
+	if a.words > 0 {
+		m.setTopLine(m.lineAtWord(a.words))
+		return
+	}
+	m.setTopLine(int(math.Round(a.progress * float64(len(m.content)))))
+}
+
+// wordOffset counts the words of the chapter text above line, leaving out
+// the author's note before it, which can be collapsed or hidden.
+func (m *ReaderModel) wordOffset(line int) int {
+	if line <= m.bodyStart || m.bodyStart >= len(m.content) {
+		return 0
+	}
+	return countWords(m.content[m.bodyStart:min(line, len(m.content))])
+}
+
+// lineAtWord is the content line holding the word offset words into the
+// chapter text.
+func (m *ReaderModel) lineAtWord(words int) int {
+	seen := 0
+	for line := m.bodyStart; line < len(m.content); line++ {
+		seen += len(strings.Fields(render.StripANSI(m.content[line])))
+		if seen > words {
+			return line
+		}
+	}
+	return max(len(m.content)-1, 0)
+}
@@ -0,0 +1,75 @@
+package ui
+
+import "testing"
+
+// anchorReader is a laid-out chapter with an author's note above the text.
+func anchorReader() *ReaderModel {
+	return &ReaderModel{
+		content: []string{
+			"Author's note: thanks",   // 0, before the body
+			"",                        // 1
+			"One two three",           // 2, the body starts here
+			"\x1b[1mfour five\x1b[0m", // 3
+			"",                        // 4
+			"six",                     // 5
+		},
+		bodyStart: 2,
+	}
+}
+
+func TestWordOffset(t *testing.T) {
+	m := anchorReader()
+
+	tests := []struct {
+		line int
+		want int
+	}{
+		{line: 0, want: 0},
+		{line: 2, want: 0},
+		{line: 3, want: 3},
+		{line: 4, want: 5},
+		{line: 5, want: 5},
+		{line: 6, want: 6},
+		{line: 100, want: 6},
+	}
+
+	for _, tt := range tests {
+		if got := m.wordOffset(tt.line); got != tt.want {
+			t.Errorf("wordOffset(%d) = %d, want %d", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestLineAtWord(t *testing.T) {
+	m := anchorReader()
+
+	tests := []struct {
+		words int
+		want  int
+	}{
+		{words: 0, want: 2},
+		{words: 2, want: 2},
+		{words: 3, want: 3},
+		{words: 4, want: 3},
+		{words: 5, want: 5},
+		{words: 6, want: 5}, // Past the end: the last line
+		{words: 1000, want: 5},
+	}
+
+	for _, tt := range tests {
+		if got := m.lineAtWord(tt.words); got != tt.want {
+			t.Errorf("lineAtWord(%d) = %d, want %d", tt.words, got, tt.want)
+		}
+	}
+
+	// Every line with text is found again from its own offset
+	for _, line := range []int{2, 3, 5} {
+		if got := m.lineAtWord(m.wordOffset(line)); got != line {
+			t.Errorf("lineAtWord(wordOffset(%d)) = %d", line, got)
+		}
+	}
+
+	if got := (&ReaderModel{}).lineAtWord(3); got != 0 {
+		t.Errorf("lineAtWord with no content = %d, want 0", got)
+	}
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// Highlights are marked with a bar in the left margin of the reader.
+var highlightMarker = lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Render("▎")
+
+// annotationDraft is a highlight waiting for its note.
+type annotationDraft struct {
+	start, end int // Content lines
+	input      textinput.Model
+}
+
+// startHighlight highlights the selected passage and asks for a note.
+func (m *ReaderModel) startHighlight() tea.Cmd {
+	if m.fiction == nil || m.currentChapter == nil {
+		return nil
+	}
+	start, end, ok := m.selectedPassage()
+	if !ok {
+		return nil
+	}
+	input := textinput.New()
+	input.Prompt = "✎ "
+	input.Placeholder = "Note (optional), enter to save"
+	input.Focus()
+	m.annotationDraft = &annotationDraft{start: start, end: end, input: input}
+	return textinput.Blink
+}
+
+func (m *ReaderModel) handleAnnotationDraftKey(msg tea.KeyMsg) tea.Cmd {
+	draft := m.annotationDraft
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.annotationDraft = nil
+		return nil
+	case "enter":
+		m.annotationDraft = nil
+		if err := m.saveAnnotation(draft.start, draft.end, strings.TrimSpace(draft.input.Value())); err != nil {
+			m.setCommandMessage(err.Error(), true)
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	draft.input, cmd = draft.input.Update(msg)
+	return cmd
+}
+
+// saveAnnotation stores content lines [start, end) as a highlight.
+func (m *ReaderModel) saveAnnotation(start, end int, note string) error {
+	if m.config == nil {
+		return fmt.Errorf("config is not available")
+	}
+	chapter := m.fiction.Chapters[m.chapterIndex]
+	m.config.AddAnnotation(config.Annotation{
+		FictionID:    strconv.Itoa(m.fiction.ID),
+		FictionTitle: m.fiction.Title,
+		ChapterIndex: m.chapterIndex,
+		ChapterID:    chapter.ID,
+		ChapterTitle: chapter.Title,
+		Start:        float64(start) / float64(len(m.content)),
+		End:          float64(end) / float64(len(m.content)),
+		StartWord:    m.wordOffset(start),
+		EndWord:      m.wordOffset(end),
+		Text:         m.passageText(start, end),
+		Note:         note,
+		CreatedAt:    time.Now().Format(time.RFC3339),
+	})
+	m.highlightsChanged()
+	m.setCommandMessage("Highlighted", false)
+	return m.config.Save()
+}
+
+// computeHighlights finds the content lines of this chapter's highlights.
+func (m *ReaderModel) computeHighlights() {
+	m.highlights = nil
+	if m.config == nil || m.fiction == nil || len(m.content) == 0 {
+		return
+	}
+	fictionID := strconv.Itoa(m.fiction.ID)
+	for _, annotation := range m.config.Annotations {
+		if annotation.FictionID != fictionID || annotation.ChapterIndex != m.chapterIndex {
+			continue
+		}
+		if m.highlights == nil {
+			m.highlights = make(map[int]bool)
+		}
+		start := int(math.Round(annotation.Start * float64(len(m.content))))
+		end := int(math.Round(annotation.End * float64(len(m.content))))
+		if annotation.EndWord > 0 {
+			start = m.lineAtWord(annotation.StartWord)
+			end = m.lineAtWord(annotation.EndWord-1) + 1
+		}
+		for line := start; line < end && line < len(m.content); line++ {
+			if !m.blankLine(line) {
+				m.highlights[line] = true
+			}
+		}
+	}
+}
+
+// highlightsChanged redraws the highlights after one is added or removed.
+func (m *ReaderModel) highlightsChanged() {
+	m.computeHighlights()
+	if m.scrollMode {
+		m.refreshViewport()
+	}
+}
+
+// openAnnotations shows the highlights made in this fiction.
+func (m *ReaderModel) openAnnotations() (tea.Model, tea.Cmd) {
+	return m.leave(func() (tea.Model, tea.Cmd) {
+		annotations := NewAnnotationsModel(m.config, m.fictionID, m)
+		return annotations, annotations.Init()
+	})
+}
+
+// annotationMarks lists the highlights in the config.
+type annotationMarks struct{}
+
+// NewAnnotationsModel lists the annotations in cfg, starting with the
+// fiction's own when fictionID is set.
+func NewAnnotationsModel(cfg *config.Config, fictionID string, previous tea.Model) *MarksModel {
+	return newMarksModel(annotationMarks{}, cfg, fictionID, previous)
+}
+
+func (annotationMarks) labels() markLabels {
+	return markLabels{
+		title:    "✎ Highlights",
+		allTitle: "✎ All highlights",
+		empty:    "No highlights yet — press H while reading to highlight the page (or the focused paragraph).",
+		closeKey: "A",
+		rowLines: 3,
+	}
+}
+
+// list keeps the annotations in the order they were made.
+func (annotationMarks) list(cfg *config.Config, fictionID string, all bool) []int {
+	var items []int
+	for i, annotation := range cfg.Annotations {
+		if all || annotation.FictionID == fictionID {
+			items = append(items, i)
+		}
+	}
+	return items
+}
+
+func (annotationMarks) remove(cfg *config.Config, i int) {
+	cfg.RemoveAnnotationAt(i)
+}
+
+func (annotationMarks) target(cfg *config.Config, i int) (string, string, jumpPosition) {
+	annotation := cfg.Annotations[i]
+	return annotation.FictionID, annotation.FictionTitle, jumpPosition{
+		chapterIndex: annotation.ChapterIndex,
+		progress:     annotation.Start,
+		words:        annotation.StartWord,
+	}
+}
+
+// row quotes the passage under the chapter, then the note.
+func (annotationMarks) row(cfg *config.Config, i int, width int) (string, string) {
+	annotation := cfg.Annotations[i]
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	line := fmt.Sprintf("Ch. %d: %s", annotation.ChapterIndex+1, annotation.ChapterTitle)
+	text := strings.Join(strings.Fields(annotation.Text), " ")
+	rest := "\n    " + dim.Render("“"+runewidth.Truncate(text, width, "…")+"”")
+	if annotation.Note != "" {
+		rest += "\n    ✎ " + runewidth.Truncate(annotation.Note, width-2, "…")
+	}
+	return line, rest
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jackowfish/royal-road-cli/internal/render"
+)
+
+// Auto-scroll turns pages (or scrolls a line) on a timer paced to a reading
+// speed in words per minute, starting from the reader's own speed: the
+// configured Reading.WordsPerMinute, or the one measured while reading.
+const (
+	autoScrollMinWPM  = 50
+	autoScrollMaxWPM  = 1500
+	autoScrollStepWPM = 25
+)
+
+type autoScrollState struct {
+	wpm int
+	seq int // Bumped on every change so stale ticks are dropped
+}
+
+type autoScrollTickMsg struct {
+	reader *ReaderModel
+	seq    int
+}
+
+// toggleAutoScroll starts auto-scrolling, or stops it if it is running.
+func (m *ReaderModel) toggleAutoScroll() tea.Cmd {
+	if m.autoScroll != nil {
+		m.autoScroll = nil
+		return nil
+	}
+	wpm := min(max(m.wordsPerMinute(), autoScrollMinWPM), autoScrollMaxWPM)
+	m.autoScroll = &autoScrollState{wpm: wpm}
+	return m.autoScrollTick()
+}
+
+// adjustAutoScroll changes the speed and restarts the timer at the new pace.
+func (m *ReaderModel) adjustAutoScroll(delta int) tea.Cmd {
+	m.autoScroll.wpm = min(max(m.autoScroll.wpm+delta, autoScrollMinWPM), autoScrollMaxWPM)
+	return m.autoScrollTick()
+}
+
+// autoScrollTick schedules the next step, timed by how many words are about
+// to scroll away: the whole screen in page mode, the top line in scroll mode.
+func (m *ReaderModel) autoScrollTick() tea.Cmd {
+	m.autoScroll.seq++
+	seq := m.autoScroll.seq
+
+	lines := m.linesPerPage
+	minDelay := 2 * time.Second
+	if m.scrollMode {
+		lines = 1
+		minDelay = 300 * time.Millisecond
+	}
+	words := 0
+	for i := m.topLine(); i < min(m.topLine()+lines, len(m.content)); i++ {
+		words += len(strings.Fields(render.StripANSI(m.content[i])))
+	}
+	delay := time.Duration(words) * time.Minute / time.Duration(m.autoScroll.wpm)
+	if delay < minDelay {
+		delay = minDelay
+	}
+
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return autoScrollTickMsg{reader: m, seq: seq}
+	})
+}
+
+// handleAutoScrollTick advances by one step, or stops at a prompt.
+func (m *ReaderModel) handleAutoScrollTick(msg autoScrollTickMsg) tea.Cmd {
+	if msg.reader != m || m.autoScroll == nil || msg.seq != m.autoScroll.seq {
+		return nil
+	}
+	if m.finishPrompt || (m.sample != nil && m.sample.prompt) {
+		m.autoScroll = nil
+		return nil
+	}
+	// Hands-free reading still counts as reading time
+	session.touch()
+	if m.loading || m.showTOC || m.showHelp {
+		return m.autoScrollTick()
+	}
+
+	var cmd tea.Cmd
+	atEnd := false
+	if m.scrollMode {
+		if atEnd = m.viewport.AtBottom(); atEnd {
+			cmd = m.pastChapterEnd()
+		} else {
+			m.scrollBy(1)
+		}
+	} else if m.currentPage < m.totalPages-1 {
+		m.currentPage++
+		session.pageTurned()
+	} else {
+		atEnd = true
+		cmd = m.pastChapterEnd()
+	}
+	m.countWordsRead()
+	// Stop at a prompt or at the end of the book
+	if (atEnd && cmd == nil) || m.finishPrompt || (m.sample != nil && m.sample.prompt) {
+		m.autoScroll = nil
+		return cmd
+	}
+	return tea.Batch(cmd, m.autoScrollTick())
+}
+
+// handleAutoScrollKey adjusts the speed with < and >, and pauses on any
+// other key, which then does what it normally would.
+func (m *ReaderModel) handleAutoScrollKey(key string) (tea.Cmd, bool) {
+	switch key {
+	case ">", ".":
+		return m.adjustAutoScroll(autoScrollStepWPM), true
+	case "<", ",":
+		return m.adjustAutoScroll(-autoScrollStepWPM), true
+	case "a":
+		m.autoScroll = nil
+		return nil, true
+	}
+	m.autoScroll = nil
+	return nil, false
+}
+
+func (m *ReaderModel) autoScrollFooter() string {
+	return fmt.Sprintf("▶ auto %d wpm [</>] speed • any key pauses", m.autoScroll.wpm)
+}
@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jackowfish/royal-road-cli/internal/download"
+)
+
+// backgroundDownload fetches the chapters of the open fiction that aren't
+// cached yet while the reader keeps going.
+type backgroundDownload struct {
+	cancel   context.CancelFunc
+	updates  chan download.Progress
+	done     int
+	total    int
+	failed   int
+	finished bool
+}
+
+// Quit choices while a background download is still running.
+const (
+	quitAfterFinish = "finish"
+	quitAfterCancel = "cancel"
+	quitAfterDetach = "detach"
+)
+
+// downloadProgressMsg carries one chapter's progress; ok is false once the
+// download has stopped.
+type downloadProgressMsg struct {
+	download *backgroundDownload
+	progress download.Progress
+	ok       bool
+}
+
+func waitForDownload(bg *backgroundDownload) tea.Cmd {
+	return func() tea.Msg {
+		progress, ok := <-bg.updates
+		return downloadProgressMsg{download: bg, progress: progress, ok: ok}
+	}
+}
+
+// startBackgroundDownload downloads every chapter of the fiction missing from
+// the cache.
+func (m *ReaderModel) startBackgroundDownload() tea.Cmd {
+	if m.fiction == nil || m.store == nil || m.offline || m.downloading() {
+		return nil
+	}
+
+	var indices []int
+	for i, chapter := range m.fiction.Chapters {
+		if !m.store.HasChapter(m.fiction.ID, chapter.ID) {
+			indices = append(indices, i)
+		}
+	}
+	if len(indices) == 0 {
+		m.background = &backgroundDownload{finished: true}
+		return nil
+	}
+	if err := m.store.SaveFiction(m.fiction); err != nil {
+		m.err = fmt.Errorf("failed to cache fiction: %w", err)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bg := &backgroundDownload{
+		cancel: cancel,
+		// Buffered for every chapter so the workers never block on a reader
+		// that has moved on to another screen
+		updates: make(chan download.Progress, len(indices)),
+		total:   len(indices),
+	}
+	m.background = bg
+
+	downloader := download.New(m.client, m.store, download.DefaultOptions())
+	fiction := m.fiction
+	go func() {
+		defer close(bg.updates)
+		_ = downloader.Chapters(ctx, fiction, indices, func(p download.Progress) {
+			bg.updates <- p
+		})
+	}()
+	return waitForDownload(bg)
+}
+
+func (m *ReaderModel) downloading() bool {
+	return m.background != nil && !m.background.finished
+}
+
+func (m *ReaderModel) handleDownloadProgress(msg downloadProgressMsg) (tea.Model, tea.Cmd) {
+	bg := msg.download
+	if !msg.ok {
+		bg.finished = true
+		evictOverQuota(m.config, m.store, m.fiction.ID)
+		m.cacheNotice = cacheWarning(m.config, m.store)
+		switch m.quitAfter {
+		case "":
+			return m, nil
+		case quitAfterDetach:
+			if bg.done < bg.total || bg.failed > 0 {
+				if err := detachDownload(m.fiction.ID); err != nil {
+					m.quitAfter, m.leaveTo = "", nil
+					m.err = fmt.Errorf("failed to hand the download off: %w", err)
+					return m, nil
+				}
+			}
+		}
+		if m.leaveTo != nil {
+			to := m.leaveTo
+			m.quitAfter, m.leaveTo = "", nil
+			return to()
+		}
+		return m, tea.Quit
+	}
+
+	bg.done = msg.progress.Done
+	if msg.progress.Err != nil {
+		bg.failed++
+	} else if m.cachedChapters != nil {
+		m.cachedChapters[msg.progress.Chapter.ID] = true
+	}
+	return m, waitForDownload(bg)
+}
+
+// handleQuitPrompt resolves the quit confirmation shown while chapters are
+// still downloading.
+func (m *ReaderModel) handleQuitPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "f", "enter":
+		m.quitAfter = quitAfterFinish
+	case "c", "ctrl+c":
+		m.quitAfter = quitAfterCancel
+		m.background.cancel()
+	case "d":
+		// Stop here, then let a separate process pick up what's left
+		m.quitAfter = quitAfterDetach
+		m.background.cancel()
+	case "esc", "q":
+		m.quitPrompt = false
+		m.leaveTo = nil
+		return m, nil
+	default:
+		return m, nil
+	}
+	m.quitPrompt = false
+	m.saveReadingProgress()
+	return m, nil
+}
+
+// detachDownload continues a download in a background `royal-road-cli
+// download` process that outlives the reader.
+func detachDownload(fictionID int) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(executable, "download", strconv.Itoa(fictionID))
+	// In its own session, so closing the terminal doesn't hang it up too
+	cmd.SysProcAttr = detachedProcAttr()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
+
+func (m *ReaderModel) downloadFooter() string {
+	bg := m.background
+	verb, going := "Quit", "quitting"
+	if m.leaveTo != nil {
+		verb, going = "Leave", "leaving"
+	}
+	switch {
+	case m.quitPrompt:
+		return fmt.Sprintf("↓ %d/%d chapters still downloading. %s: [f] when finished • [c] cancel them • [d] continue in the background • [esc] keep reading",
+			bg.done, bg.total, verb)
+	case m.quitAfter == quitAfterFinish:
+		return fmt.Sprintf("↓ Finishing downloads (%d/%d), then %s... [c] cancel", bg.done, bg.total, going)
+	case m.quitAfter != "":
+		return "↓ Stopping downloads..."
+	case bg.finished && bg.total == 0:
+		return "↓ all chapters downloaded"
+	case bg.finished && bg.failed > 0:
+		return fmt.Sprintf("↓ downloaded %d/%d (%d failed)", bg.done-bg.failed, bg.total, bg.failed)
+	case bg.finished:
+		return fmt.Sprintf("↓ downloaded %d chapters", bg.total)
+	}
+	return fmt.Sprintf("↓ %d/%d", bg.done, bg.total)
+}
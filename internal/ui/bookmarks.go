@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// openBookmarkPrompt asks for an optional label for a bookmark on this page.
+func (m *ReaderModel) openBookmarkPrompt() tea.Cmd {
+	if m.fiction == nil || m.currentChapter == nil {
+		return nil
+	}
+	input := textinput.New()
+	input.Prompt = "🔖 "
+	input.Placeholder = "Label (optional), enter to save"
+	input.Focus()
+	m.bookmarkLabel = &input
+	return textinput.Blink
+}
+
+func (m *ReaderModel) handleBookmarkPromptKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.bookmarkLabel = nil
+		return nil
+	case "enter":
+		label := strings.TrimSpace(m.bookmarkLabel.Value())
+		m.bookmarkLabel = nil
+		if err := m.addBookmark(label); err != nil {
+			m.setCommandMessage(err.Error(), true)
+		}
+		return nil
+	}
+
+	input, cmd := m.bookmarkLabel.Update(msg)
+	m.bookmarkLabel = &input
+	return cmd
+}
+
+// addBookmark bookmarks the current page.
+func (m *ReaderModel) addBookmark(label string) error {
+	if m.fiction == nil || m.chapterIndex >= len(m.fiction.Chapters) {
+		return fmt.Errorf("no chapter loaded")
+	}
+	if m.config == nil {
+		return fmt.Errorf("config is not available")
+	}
+	position := m.currentPosition()
+	m.config.AddBookmark(config.Bookmark{
+		FictionID:    strconv.Itoa(m.fiction.ID),
+		FictionTitle: m.fiction.Title,
+		ChapterIndex: m.chapterIndex,
+		ChapterTitle: m.fiction.Chapters[m.chapterIndex].Title,
+		Position:     m.currentPage,
+		Progress:     position.progress,
+		WordOffset:   position.words,
+		Label:        label,
+		CreatedAt:    time.Now().Format(time.RFC3339),
+	})
+	m.setCommandMessage(fmt.Sprintf("Bookmarked chapter %d, page %d", m.chapterIndex+1, m.currentPage+1), false)
+	return m.config.Save()
+}
+
+// openBookmarks shows the bookmarks for this fiction.
+func (m *ReaderModel) openBookmarks() (tea.Model, tea.Cmd) {
+	return m.leave(func() (tea.Model, tea.Cmd) {
+		bookmarks := NewBookmarksModel(m.config, m.fictionID, m)
+		return bookmarks, bookmarks.Init()
+	})
+}
+
+// bookmarkMarks lists the bookmarks in the config.
+type bookmarkMarks struct{}
+
+// NewBookmarksModel lists the bookmarks in cfg. With a fictionID only that
+// fiction's bookmarks are shown at first; esc returns to previous.
+func NewBookmarksModel(cfg *config.Config, fictionID string, previous tea.Model) *MarksModel {
+	return newMarksModel(bookmarkMarks{}, cfg, fictionID, previous)
+}
+
+func (bookmarkMarks) labels() markLabels {
+	return markLabels{
+		title:    "🔖 Bookmarks",
+		allTitle: "🔖 All bookmarks",
+		empty:    "No bookmarks yet — press B while reading to add one.",
+		closeKey: "'",
+		rowLines: 1,
+	}
+}
+
+// list orders the bookmarks by fiction, then position.
+func (bookmarkMarks) list(cfg *config.Config, fictionID string, all bool) []int {
+	var items []int
+	for i, bookmark := range cfg.Bookmarks {
+		if all || bookmark.FictionID == fictionID {
+			items = append(items, i)
+		}
+	}
+	bookmarks := cfg.Bookmarks
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := bookmarks[items[i]], bookmarks[items[j]]
+		if a.FictionTitle != b.FictionTitle {
+			return a.FictionTitle < b.FictionTitle
+		}
+		if a.ChapterIndex != b.ChapterIndex {
+			return a.ChapterIndex < b.ChapterIndex
+		}
+		return a.Progress < b.Progress
+	})
+	return items
+}
+
+func (bookmarkMarks) remove(cfg *config.Config, i int) {
+	cfg.RemoveBookmarkAt(i)
+}
+
+func (bookmarkMarks) target(cfg *config.Config, i int) (string, string, jumpPosition) {
+	bookmark := cfg.Bookmarks[i]
+	return bookmark.FictionID, bookmark.FictionTitle, jumpPosition{
+		chapterIndex: bookmark.ChapterIndex,
+		progress:     bookmark.Progress,
+		words:        bookmark.WordOffset,
+	}
+}
+
+func (bookmarkMarks) row(cfg *config.Config, i int, width int) (string, string) {
+	bookmark := cfg.Bookmarks[i]
+	line := fmt.Sprintf("Ch. %d, p. %d: %s", bookmark.ChapterIndex+1, bookmark.Position+1, bookmark.ChapterTitle)
+	if bookmark.Label == "" {
+		return line, ""
+	}
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	return line, "  " + dim.Render("“"+bookmark.Label+"”")
+}
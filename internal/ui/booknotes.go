@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// bookNotesCharLimit bounds a book's notes; they're reminders, not a diary.
+const bookNotesCharLimit = 4000
+
+func (m *ReaderModel) openBookNotes() (tea.Model, tea.Cmd) {
+	if m.config == nil || m.fiction == nil {
+		return m, nil
+	}
+	if m.config.GetEntry(m.fictionID) == nil {
+		// Notes live on the history entry
+		m.saveReadingProgress()
+	}
+	notes := NewBookNotesModel(m.config, m.fictionID, m)
+	return notes, notes.Init()
+}
+
+// BookNotesModel edits the free-form notes kept on a book in the history,
+// such as plot reminders or why it was put down.
+type BookNotesModel struct {
+	config    *config.Config
+	fictionID string
+	title     string
+	input     textarea.Model
+	previous  tea.Model
+}
+
+// NewBookNotesModel edits the notes of a fiction in cfg's history.
+func NewBookNotesModel(cfg *config.Config, fictionID string, previous tea.Model) *BookNotesModel {
+	termWidth, termHeight := getTerminalSize()
+	input := textarea.New()
+	input.Placeholder = "Plot reminders, who's who, why you stopped…"
+	input.CharLimit = bookNotesCharLimit
+	input.ShowLineNumbers = false
+	input.SetWidth(min(termWidth-4, 80))
+	input.SetHeight(max(termHeight-8, 5))
+
+	m := &BookNotesModel{config: cfg, fictionID: fictionID, previous: previous}
+	if entry := cfg.GetEntry(fictionID); entry != nil {
+		m.title = entry.FictionTitle
+		input.SetValue(entry.Notes)
+	}
+	input.Focus()
+	m.input = input
+	return m
+}
+
+func (m *BookNotesModel) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (m *BookNotesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.input.SetWidth(min(msg.Width-4, 80))
+		m.input.SetHeight(max(msg.Height-8, 5))
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			m.config.SetBookNotes(m.fictionID, strings.TrimSpace(m.input.Value()))
+			_ = m.config.Save()
+			return m.back()
+		case "ctrl+x":
+			return m.back()
+		}
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	// The screen underneath keeps its timers going
+	var cmds []tea.Cmd
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	cmds = append(cmds, cmd)
+	if m.previous != nil {
+		m.previous, cmd = m.previous.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m *BookNotesModel) back() (tea.Model, tea.Cmd) {
+	if m.previous != nil {
+		return m.previous, nil
+	}
+	menuModel := NewMenuModel()
+	return menuModel, menuModel.Init()
+}
+
+func (m *BookNotesModel) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("170")).
+		Render("📝 Notes on " + m.title)
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("[esc] save and close • [ctrl+x] discard changes")
+	return fmt.Sprintf("%s\n\n%s\n\n%s", title, m.input.View(), hint)
+}
+
+// notesPreview is the first line of a book's notes, cut to width columns.
+func notesPreview(notes string, width int) string {
+	line, _, more := strings.Cut(strings.TrimSpace(notes), "\n")
+	if more {
+		// The ellipsis says there's more even when the first line fits
+		return runewidth.Truncate(line, width-1, "") + "…"
+	}
+	return runewidth.Truncate(line, width, "…")
+}
@@ -1,18 +1,23 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
-	"royal-road-cli/internal/api"
+	"github.com/jackowfish/royal-road-cli/internal/browser"
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
 )
 
 type FictionListItem struct {
-	fiction api.PopularFiction
+	fiction royalroad.PopularFiction
 }
 
 func (f FictionListItem) Title() string {
@@ -39,12 +44,20 @@ func (f FictionListItem) FilterValue() string {
 
 type BrowseModel struct {
 	list      list.Model
-	client    *api.Client
+	client    *royalroad.Client
+	config    *config.Config
 	loading   bool
 	err       error
+	requests  requestScope
+	cacheOnly bool // Set by c on the error screen to list the cached copy
 }
 
-type fictionsLoadedMsg []api.PopularFiction
+// popularPath is the page GetPopularFictions lists.
+const popularPath = "/fictions/best-rated"
+
+var browseFailureKeys = failureKeys{retry: "r", browser: "o", cache: "c"}
+
+type fictionsLoadedMsg []royalroad.PopularFiction
 type errorMsg error
 
 func NewBrowseModel() *BrowseModel {
@@ -72,13 +85,16 @@ func NewBrowseModel() *BrowseModel {
 
 	l := list.New(items, delegate, termWidth, termHeight-2)
 	l.Title = "📚 Popular Royal Road Fictions"
-	l.StatusMessageLifetime = 0
+	l.StatusMessageLifetime = queueStatusLifetime
+	l.AdditionalShortHelpKeys = func() []key.Binding { return []key.Binding{queueKey} }
 	l.SetShowHelp(true)
 	l.SetFilteringEnabled(true)
 
+	cfg, _ := config.Load()
 	return &BrowseModel{
 		list:    l,
-		client:  api.NewClient(),
+		client:  network.NewClient(cfg),
+		config:  cfg,
 		loading: true,
 	}
 }
@@ -98,15 +114,42 @@ func (m *BrowseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
+		case "ctrl+p":
+			return openQuickSwitcher(m)
+		case "ctrl+r":
+			m.requests.stop()
+			return returnToReading(m)
 		case "enter":
 			if item, ok := m.list.SelectedItem().(FictionListItem); ok {
 				readerModel := NewReaderModel(fmt.Sprintf("%d", item.fiction.ID))
 				return readerModel, readerModel.Init()
 			}
+		case "a":
+			if m.list.FilterState() == list.Filtering {
+				break
+			}
+			if item, ok := m.list.SelectedItem().(FictionListItem); ok {
+				return m, m.list.NewStatusMessage(addToQueue(m.config, item.fiction.ID, item.fiction.Title, item.fiction.Author))
+			}
 		case "r":
 			m.loading = true
 			m.err = nil
+			m.cacheOnly = false
 			return m, m.loadFictions()
+		case "o":
+			if m.err != nil && describeFailure(m.err, m.cacheOnly).browser {
+				if err := browser.Open(royalroad.DefaultBaseURL + popularPath); err != nil {
+					m.err = fmt.Errorf("opening browser failed: %w", err)
+				}
+				return m, nil
+			}
+		case "c":
+			if m.err != nil && describeFailure(m.err, m.cacheOnly).cache {
+				m.loading = true
+				m.err = nil
+				m.cacheOnly = true
+				return m, m.loadFictions()
+			}
 		}
 	
 	case fictionsLoadedMsg:
@@ -139,16 +182,20 @@ func (m *BrowseModel) View() string {
 	if m.err != nil {
 		return lipgloss.NewStyle().
 			Padding(2).
-			Foreground(lipgloss.Color("196")).
-			Render(fmt.Sprintf("❌ Error loading fictions: %v\n\nPress 'r' to retry or 'q' to quit.", m.err))
+			Width(m.list.Width()).
+			Render(describeFailure(m.err, m.cacheOnly).render(m.err, browseFailureKeys, "'q' to quit"))
 	}
 	
 	return m.list.View()
 }
 
 func (m *BrowseModel) loadFictions() tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
-		fictions, err := m.client.GetPopularFictions()
+	cacheOnly := m.cacheOnly
+	return m.requests.run(func(ctx context.Context) tea.Msg {
+		if cacheOnly {
+			ctx = royalroad.CacheOnly(ctx)
+		}
+		fictions, err := m.client.GetPopularFictions(ctx)
 		if err != nil {
 			return errorMsg(err)
 		}
@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/download"
+	"github.com/jackowfish/royal-road-cli/internal/search"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// chapterSearch finds a phrase in every chapter of the open fiction. Cached
+// chapters are searched first so their hits show up at once; the rest are
+// fetched one at a time at the usual download pace.
+type chapterSearch struct {
+	input    textinput.Model
+	hits     []search.Hit
+	selected int
+	offset   int
+	searched string // Query the current hits are for
+	cancel   context.CancelFunc
+	updates  chan chapterSearchResult
+	scanned  int
+	total    int
+	missing  int // Chapters that couldn't be loaded
+	running  bool
+}
+
+const (
+	chapterSearchLimit      = 500
+	chapterSearchPerChapter = 5
+)
+
+type chapterSearchResult struct {
+	hits []search.Hit
+	err  error
+}
+
+// chapterSearchMsg carries one chapter's hits; ok is false once the search
+// has stopped.
+type chapterSearchMsg struct {
+	search *chapterSearch
+	result chapterSearchResult
+	ok     bool
+}
+
+func waitForChapterSearch(cs *chapterSearch) tea.Cmd {
+	return func() tea.Msg {
+		result, ok := <-cs.updates
+		return chapterSearchMsg{search: cs, result: result, ok: ok}
+	}
+}
+
+// openChapterSearch shows the search prompt, keeping the last results.
+func (m *ReaderModel) openChapterSearch() tea.Cmd {
+	if m.fiction == nil {
+		return nil
+	}
+	if m.chapterSearch == nil {
+		input := textinput.New()
+		input.Placeholder = "Phrase to find in every chapter..."
+		input.Prompt = "❯ "
+		input.Width = 50
+		m.chapterSearch = &chapterSearch{input: input}
+	}
+	m.showChapterSearch = true
+	m.chapterSearch.input.Focus()
+	return textinput.Blink
+}
+
+// runChapterSearch starts searching for query, stopping any earlier search.
+func (m *ReaderModel) runChapterSearch(query string) tea.Cmd {
+	cs := m.chapterSearch
+	if cs.cancel != nil {
+		cs.cancel()
+	}
+
+	// Cached chapters (and the one on screen) first, then the rest in order
+	var order []int
+	var remote []int
+	for i, chapter := range m.fiction.Chapters {
+		if i == m.chapterIndex || (m.store != nil && m.store.HasChapter(m.fiction.ID, chapter.ID)) {
+			order = append(order, i)
+		} else {
+			remote = append(remote, i)
+		}
+	}
+	order = append(order, remote...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cs.cancel = cancel
+	cs.updates = make(chan chapterSearchResult, len(order))
+	cs.hits, cs.selected, cs.offset = nil, 0, 0
+	cs.searched = query
+	cs.scanned, cs.missing, cs.total = 0, 0, len(order)
+	cs.running = true
+
+	fiction, store, client, offline := m.fiction, m.store, m.client, m.offline
+	current, currentIndex := m.currentChapter, m.chapterIndex
+	delay := download.DefaultOptions().Delay
+	go func() {
+		defer close(cs.updates)
+		fetched := 0
+		for _, index := range order {
+			if ctx.Err() != nil {
+				return
+			}
+			info := fiction.Chapters[index]
+
+			var chapter *royalroad.Chapter
+			var err error
+			switch {
+			case index == currentIndex && current != nil:
+				chapter = current
+			case store != nil && store.HasChapter(fiction.ID, info.ID):
+				chapter, err = store.LoadChapter(fiction.ID, info.ID)
+			case offline:
+				err = fmt.Errorf("chapter %d is not available offline", index+1)
+			default:
+				if fetched > 0 {
+					time.Sleep(delay)
+				}
+				fetched++
+				chapter, err = client.GetChapter(ctx, info.ID)
+				if err == nil && store != nil && store.HasFiction(fiction.ID) {
+					_ = store.SaveChapter(fiction.ID, info.ID, chapter)
+				}
+			}
+			if err != nil {
+				cs.updates <- chapterSearchResult{err: err}
+				continue
+			}
+
+			hits := search.Chapter(chapter.Content, query, search.Options{PerChapter: chapterSearchPerChapter})
+			for i := range hits {
+				hits[i].FictionID = fiction.ID
+				hits[i].FictionTitle = fiction.Title
+				hits[i].ChapterIndex = index
+				hits[i].ChapterID = info.ID
+				hits[i].ChapterTitle = info.Title
+			}
+			cs.updates <- chapterSearchResult{hits: hits}
+		}
+	}()
+	return waitForChapterSearch(cs)
+}
+
+func (m *ReaderModel) handleChapterSearchResult(msg chapterSearchMsg) tea.Cmd {
+	cs := msg.search
+	if cs != m.chapterSearch || cs.updates == nil {
+		return nil
+	}
+	if !msg.ok {
+		cs.running = false
+		return nil
+	}
+
+	cs.scanned++
+	if msg.result.err != nil {
+		cs.missing++
+	}
+	cs.hits = append(cs.hits, msg.result.hits...)
+	// Chapters arrive cached-first, so keep the list in reading order
+	sort.SliceStable(cs.hits, func(i, j int) bool {
+		return cs.hits[i].ChapterIndex < cs.hits[j].ChapterIndex
+	})
+	if len(cs.hits) >= chapterSearchLimit {
+		cs.hits = cs.hits[:chapterSearchLimit]
+		cs.cancel()
+	}
+	return waitForChapterSearch(cs)
+}
+
+// closeChapterSearch hides the search; a running search carries on so its
+// results are there when it is reopened.
+func (m *ReaderModel) closeChapterSearch() {
+	m.showChapterSearch = false
+	m.chapterSearch.input.Blur()
+}
+
+// stopChapterSearch cancels any running search, e.g. when the reader exits.
+func (m *ReaderModel) stopChapterSearch() {
+	if m.chapterSearch != nil && m.chapterSearch.cancel != nil {
+		m.chapterSearch.cancel()
+	}
+}
+
+// visibleSearchHits is how many results fit below the prompt.
+func (m *ReaderModel) visibleSearchHits() int {
+	return max((m.linesPerPage-6)/2, 3)
+}
+
+func (m *ReaderModel) handleChapterSearchKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	cs := m.chapterSearch
+	switch msg.String() {
+	case "ctrl+c":
+		m.stopChapterSearch()
+		return nil, false
+	case "esc":
+		m.closeChapterSearch()
+		return nil, true
+	case "up", "ctrl+k":
+		if cs.selected > 0 {
+			cs.selected--
+			cs.offset = min(cs.offset, cs.selected)
+		}
+		return nil, true
+	case "down", "ctrl+j", "ctrl+n":
+		if cs.selected < len(cs.hits)-1 {
+			cs.selected++
+			if cs.selected >= cs.offset+m.visibleSearchHits() {
+				cs.offset = cs.selected - m.visibleSearchHits() + 1
+			}
+		}
+		return nil, true
+	case "enter":
+		query := strings.TrimSpace(cs.input.Value())
+		if query != "" && query != cs.searched {
+			return m.runChapterSearch(query), true
+		}
+		if len(cs.hits) == 0 {
+			return nil, true
+		}
+		m.closeChapterSearch()
+		return m.openSearchHit(cs.hits[cs.selected]), true
+	}
+
+	var cmd tea.Cmd
+	cs.input, cmd = cs.input.Update(msg)
+	return cmd, true
+}
+
+// openSearchHit goes to the page a hit is on.
+func (m *ReaderModel) openSearchHit(hit search.Hit) tea.Cmd {
+	m.recordJump()
+	return m.gotoChapterPosition(jumpPosition{chapterIndex: hit.ChapterIndex, progress: hit.Position})
+}
+
+func (m *ReaderModel) chapterSearchView() string {
+	cs := m.chapterSearch
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("170")).
+		Render("🔎 Search " + m.fiction.Title)
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("%s\n\n%s\n\n", title, cs.input.View()))
+
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	if cs.searched != "" {
+		status := fmt.Sprintf("%d matches", len(cs.hits))
+		if cs.running {
+			status += fmt.Sprintf(" • searched %d/%d chapters...", cs.scanned, cs.total)
+		} else if len(cs.hits) >= chapterSearchLimit {
+			status = fmt.Sprintf("first %d matches", chapterSearchLimit)
+		}
+		if cs.missing > 0 {
+			status += fmt.Sprintf(" • %d chapters unavailable", cs.missing)
+		}
+		content.WriteString(dim.Render(status) + "\n\n")
+	}
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("170")).
+		Bold(true)
+
+	end := min(cs.offset+m.visibleSearchHits(), len(cs.hits))
+	for i := cs.offset; i < end; i++ {
+		hit := cs.hits[i]
+		line := fmt.Sprintf("Ch %d: %s (%d%%)", hit.ChapterIndex+1, hit.ChapterTitle, int(hit.Position*100))
+		if i == cs.selected {
+			content.WriteString(selectedStyle.Render("▶ " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n    " + dim.Render(hit.Snippet) + "\n")
+	}
+	return content.String()
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/render"
+)
+
+// checkpointMinWords is the chapter length from which quarter checkpoints are
+// shown; shorter chapters are easy enough to find your way around.
+const checkpointMinWords = 5000
+
+// computeCheckpoints places a checkpoint at roughly every 25% of a long
+// chapter, snapped forward to the start of a paragraph.
+func (m *ReaderModel) computeCheckpoints() {
+	m.checkpoints = nil
+	if m.currentChapter == nil || render.WordCount(m.currentChapter.Content) < checkpointMinWords {
+		return
+	}
+
+	for quarter := 1; quarter <= 3; quarter++ {
+		quarterLine := len(m.content) * quarter / 4
+		line := quarterLine
+		for line > 0 && line < len(m.content) && strings.TrimSpace(m.content[line-1]) != "" {
+			line++
+		}
+		if line >= len(m.content) {
+			// No blank lines to snap to, e.g. with paragraphGap 0
+			line = quarterLine
+		}
+		m.checkpoints = append(m.checkpoints, line)
+	}
+}
+
+// jumpCheckpoint moves to the page holding the next (dir > 0) or previous
+// checkpoint. It returns false if there is none in that direction.
+func (m *ReaderModel) jumpCheckpoint(dir int) bool {
+	if m.linesPerPage <= 0 {
+		return false
+	}
+	if m.scrollMode {
+		return m.scrollToCheckpoint(dir)
+	}
+	if dir > 0 {
+		for _, line := range m.checkpoints {
+			if page := line / m.linesPerPage; page > m.currentPage {
+				m.currentPage = page
+				return true
+			}
+		}
+		return false
+	}
+	for i := len(m.checkpoints) - 1; i >= 0; i-- {
+		if page := m.checkpoints[i] / m.linesPerPage; page < m.currentPage {
+			m.currentPage = page
+			return true
+		}
+	}
+	return false
+}
+
+// checkpointMarker returns the margin marker for a content line, if any. It
+// fits in the two columns of padding right of the text.
+func (m *ReaderModel) checkpointMarker(line int) string {
+	for _, checkpoint := range m.checkpoints {
+		if checkpoint == line {
+			return lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(" ◆")
+		}
+	}
+	return ""
+}
+
+// scrollToCheckpoint puts the next or previous checkpoint at the top of the
+// screen in scroll mode.
+func (m *ReaderModel) scrollToCheckpoint(dir int) bool {
+	top := m.topLine()
+	if dir > 0 {
+		for _, line := range m.checkpoints {
+			if line > top {
+				m.setTopLine(line)
+				return true
+			}
+		}
+		return false
+	}
+	for i := len(m.checkpoints) - 1; i >= 0; i-- {
+		if m.checkpoints[i] < top {
+			m.setTopLine(m.checkpoints[i])
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,314 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jackowfish/royal-road-cli/internal/download"
+	"github.com/jackowfish/royal-road-cli/internal/export"
+)
+
+// The command line opens with ':' at the bottom of the reader, vim style.
+// A bare page number ("45") or percentage ("75%") jumps within the chapter;
+// everything else is a named command such as ":ch 120" or ":export epub".
+
+const commandUsage = "commands: <page>, <percent>%, ch <n>, next, prev, toc, search [phrase], bookmark add [label]|remove|list, export <format>, download, scroll, focus, rsvp, auto, tabnew <id|title>, tabclose, help, menu, q"
+
+// commandDoneMsg reports a command that finished in the background.
+type commandDoneMsg struct {
+	reader  *ReaderModel
+	message string
+	err     error
+}
+
+// exportFormats maps the formats :export accepts to their file extension
+// and writer, as in the export command.
+var exportFormats = map[string]struct {
+	extension string
+	write     func(*export.Book, string) error
+}{
+	"epub": {"epub", export.WriteEPUB},
+	"mobi": {"mobi", export.WriteKindle},
+	"azw3": {"azw3", export.WriteKindle},
+	"txt":  {"txt", export.WriteText},
+	"md":   {"", export.WriteMarkdown},
+}
+
+// openCommandLine focuses an empty command line.
+func (m *ReaderModel) openCommandLine() tea.Cmd {
+	input := textinput.New()
+	input.Prompt = ":"
+	input.Placeholder = "45, 75%, ch 120, toc, bookmark add, export epub, q..."
+	input.Focus()
+	m.commandLine = &input
+	m.commandMessage = ""
+	return textinput.Blink
+}
+
+func (m *ReaderModel) handleCommandLineKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.commandLine = nil
+		return m, nil
+	case "enter":
+		command := strings.TrimSpace(m.commandLine.Value())
+		m.commandLine = nil
+		if command == "" {
+			return m, nil
+		}
+		model, cmd, err := m.runCommand(command)
+		if err != nil {
+			m.setCommandMessage(err.Error(), true)
+		}
+		return model, cmd
+	}
+
+	input, cmd := m.commandLine.Update(msg)
+	m.commandLine = &input
+	return m, cmd
+}
+
+func (m *ReaderModel) setCommandMessage(message string, failed bool) {
+	m.commandMessage = message
+	m.commandFailed = failed
+}
+
+// runCommand carries out a command line entry.
+func (m *ReaderModel) runCommand(command string) (tea.Model, tea.Cmd, error) {
+	fields := strings.Fields(command)
+	name, args := fields[0], fields[1:]
+	if _, err := strconv.ParseFloat(strings.TrimSuffix(name, "%"), 64); err == nil {
+		return m, nil, m.gotoPosition(command)
+	}
+
+	switch name {
+	case "q", "quit", "wq", "x":
+		return m, m.quit(), nil
+	case "menu":
+		model, cmd := m.backToMenu()
+		return model, cmd, nil
+	case "help", "h":
+		m.showHelp = !m.showHelp
+		return m, nil, nil
+	case "toc":
+		m.toggleTOC()
+		return m, nil, nil
+	case "ch", "chapter":
+		if len(args) != 1 {
+			return m, nil, fmt.Errorf("usage: ch <number>")
+		}
+		cmd, err := m.gotoChapter(args[0])
+		return m, cmd, err
+	case "next", "n":
+		cmd, err := m.gotoChapter(strconv.Itoa(m.chapterIndex + 2))
+		return m, cmd, err
+	case "prev", "p":
+		cmd, err := m.gotoChapter(strconv.Itoa(m.chapterIndex))
+		return m, cmd, err
+	case "search":
+		cmd := m.openChapterSearch()
+		if cmd != nil && len(args) > 0 {
+			query := strings.Join(args, " ")
+			m.chapterSearch.input.SetValue(query)
+			m.chapterSearch.input.CursorEnd()
+			cmd = tea.Batch(cmd, m.runChapterSearch(query))
+		}
+		return m, cmd, nil
+	case "bookmark", "bm":
+		if len(args) == 0 || (args[0] != "list" && args[0] != "ls") {
+			return m, nil, m.bookmarkCommand(args)
+		}
+		fallthrough
+	case "bookmarks", "marks":
+		model, cmd := m.openBookmarks()
+		return model, cmd, nil
+	case "notes":
+		model, cmd := m.openBookNotes()
+		return model, cmd, nil
+	case "export":
+		cmd, err := m.exportCommand(args)
+		return m, cmd, err
+	case "download":
+		return m, m.startBackgroundDownload(), nil
+	case "scroll":
+		m.toggleScrollMode()
+		return m, nil, nil
+	case "focus":
+		m.toggleFocusMode()
+		return m, nil, nil
+	case "rsvp":
+		return m, m.startRSVP(), nil
+	case "auto":
+		return m, m.toggleAutoScroll(), nil
+	case "tabnew", "tabe":
+		return m.openTabCommand(strings.Join(args, " "))
+	case "tabclose", "tabc":
+		return m.closeTab()
+	}
+	return m, nil, fmt.Errorf("unknown command %q; %s", name, commandUsage)
+}
+
+// openTabCommand opens another book in a new tab, by fiction ID or by the
+// title of a book in the history.
+func (m *ReaderModel) openTabCommand(target string) (tea.Model, tea.Cmd, error) {
+	if target == "" {
+		return m, nil, fmt.Errorf("usage: tabnew <fiction id or title>")
+	}
+	if m.loading {
+		return m, nil, fmt.Errorf("wait for the chapter to load before opening a tab")
+	}
+	fictionID := target
+	if _, err := strconv.Atoi(target); err != nil {
+		if m.config == nil {
+			return m, nil, fmt.Errorf("no book titled %q in your history", target)
+		}
+		matches := m.config.FindByTitle(target)
+		if len(matches) == 0 {
+			return m, nil, fmt.Errorf("no book titled %q in your history", target)
+		}
+		fictionID = matches[0].FictionID
+	}
+	if fictionID == m.fictionID {
+		return m, nil, fmt.Errorf("that book is already open")
+	}
+	for _, tab := range readerTabs {
+		if tab.fictionID == fictionID {
+			model, cmd := m.showTab(tab)
+			return model, cmd, nil
+		}
+	}
+
+	m.leaveTab()
+	readerModel := NewReaderModel(fictionID)
+	return readerModel, readerModel.Init(), nil
+}
+
+// gotoPosition jumps to a page number or a percentage of the chapter.
+func (m *ReaderModel) gotoPosition(target string) error {
+	if len(m.content) == 0 {
+		return fmt.Errorf("no chapter loaded")
+	}
+	if percent, ok := strings.CutSuffix(target, "%"); ok {
+		value, err := strconv.ParseFloat(strings.TrimSpace(percent), 64)
+		if err != nil || math.IsNaN(value) || value < 0 || value > 100 {
+			return fmt.Errorf("not a percentage: %s", target)
+		}
+		line := int(value / 100 * float64(len(m.content)))
+		m.recordJump()
+		m.setTopLine(min(line, len(m.content)-1))
+		return nil
+	}
+
+	page, err := strconv.Atoi(target)
+	if err != nil {
+		return fmt.Errorf("not a page number or percentage: %s", target)
+	}
+	if page < 1 || page > m.totalPages {
+		return fmt.Errorf("page %d is out of range (1-%d)", page, m.totalPages)
+	}
+	m.recordJump()
+	m.setTopLine((page - 1) * m.linesPerPage)
+	return nil
+}
+
+// gotoChapter loads a chapter by its 1-based number.
+func (m *ReaderModel) gotoChapter(target string) (tea.Cmd, error) {
+	if m.fiction == nil {
+		return nil, fmt.Errorf("no fiction loaded")
+	}
+	number, err := strconv.Atoi(target)
+	if err != nil || number < 1 || number > len(m.fiction.Chapters) {
+		return nil, fmt.Errorf("no chapter %s (1-%d)", target, len(m.fiction.Chapters))
+	}
+	if m.sampleSpent() {
+		return nil, nil
+	}
+	m.recordJump()
+	m.chapterIndex = number - 1
+	m.loading = true
+	return m.loadChapter(m.chapterIndex), nil
+}
+
+// bookmarkCommand bookmarks the current page, with the rest of the line as
+// its label, or removes the current chapter's bookmarks.
+func (m *ReaderModel) bookmarkCommand(args []string) error {
+	action := "add"
+	if len(args) > 0 {
+		action, args = args[0], args[1:]
+	}
+	switch action {
+	case "add":
+		return m.addBookmark(strings.Join(args, " "))
+	case "remove", "rm", "delete", "del":
+		if m.fiction == nil || m.config == nil {
+			return fmt.Errorf("no chapter loaded")
+		}
+		m.config.RemoveBookmark(strconv.Itoa(m.fiction.ID), m.chapterIndex)
+		m.setCommandMessage(fmt.Sprintf("Removed the bookmarks in chapter %d", m.chapterIndex+1), false)
+		return m.config.Save()
+	}
+	return fmt.Errorf("usage: bookmark add [label]|remove|list")
+}
+
+// exportCommand writes the whole fiction to a file in the current directory,
+// downloading any chapters that aren't cached yet.
+func (m *ReaderModel) exportCommand(args []string) (tea.Cmd, error) {
+	if m.fiction == nil {
+		return nil, fmt.Errorf("no fiction loaded")
+	}
+	if m.store == nil {
+		return nil, fmt.Errorf("exporting needs the chapter cache")
+	}
+	name := "epub"
+	if len(args) > 0 {
+		name = strings.ToLower(args[0])
+	}
+	format, ok := exportFormats[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown export format %q (epub, mobi, azw3, txt, md)", name)
+	}
+
+	fiction, store, client, offline := m.fiction, m.store, m.client, m.offline
+	output := export.Filename(fiction.Title, format.extension)
+	m.setCommandMessage(fmt.Sprintf("Exporting %s to %s...", fiction.Title, output), false)
+	return m.tasks.run(func(ctx context.Context) tea.Msg {
+		indices := make([]int, len(fiction.Chapters))
+		for i := range indices {
+			indices[i] = i
+		}
+		if !offline {
+			if err := store.SaveFiction(fiction); err != nil {
+				return commandDoneMsg{reader: m, err: err}
+			}
+			downloader := download.New(client, store, download.DefaultOptions())
+			if err := downloader.Chapters(ctx, fiction, indices, nil); err != nil {
+				return commandDoneMsg{reader: m, err: fmt.Errorf("export failed: %w", err)}
+			}
+		}
+		book, err := export.LoadBook(store, fiction, indices)
+		if err == nil {
+			err = format.write(book, output)
+		}
+		if err != nil {
+			return commandDoneMsg{reader: m, err: fmt.Errorf("export failed: %w", err)}
+		}
+		return commandDoneMsg{reader: m, message: fmt.Sprintf("Exported %d chapters to %s", len(book.Chapters), output)}
+	}), nil
+}
+
+func (m *ReaderModel) handleCommandDone(msg commandDoneMsg) {
+	if msg.reader != m {
+		return
+	}
+	if msg.err != nil {
+		m.setCommandMessage(msg.err.Error(), true)
+		return
+	}
+	m.setCommandMessage(msg.message, false)
+}
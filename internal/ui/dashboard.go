@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// progressBar draws fraction (0-1) as a bar of width cells, like "▓▓▓░░░░░".
+func progressBar(fraction float64, width int) string {
+	filled := max(min(int(fraction*float64(width)), width), 0)
+	return strings.Repeat("▓", filled) + strings.Repeat("░", width-filled)
+}
+
+// dashboardView summarizes the reading that's going on for the top of the
+// main menu: the current book, books with new chapters and today's reading
+// time. New chapters are counted from the last metadata refresh, which runs
+// in the background, so the menu never waits on the network.
+func (m *MenuModel) dashboardView() string {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	var lines []string
+	if entry := m.config.GetLastReadEntry(); entry != nil {
+		completed := entry.Completed()
+		lines = append(lines, fmt.Sprintf("Reading %s  %s %.0f%%  %s",
+			lipgloss.NewStyle().Bold(true).Render(entry.FictionTitle),
+			progressBar(completed, 12), completed*100,
+			dim.Render(fmt.Sprintf("ch. %d/%d", entry.CurrentChapter+1, entry.TotalChapters))))
+	}
+
+	var status []string
+	switch updated := m.booksWithNewChapters(); {
+	case m.refreshing:
+		status = append(status, "checking for new chapters…")
+	case updated == 1:
+		status = append(status, "1 book has new chapters")
+	case updated > 1:
+		status = append(status, fmt.Sprintf("%d books have new chapters", updated))
+	}
+	today := m.config.ReadingTimeOn(time.Now()) + session.elapsed()
+	status = append(status, formatStopwatch(today)+" read today")
+	lines = append(lines, dim.Render(strings.Join(status, " • ")))
+
+	return strings.Join(lines, "\n")
+}
+
+// booksWithNewChapters counts the books still being followed that have
+// chapters published since they were last read.
+func (m *MenuModel) booksWithNewChapters() int {
+	count := 0
+	for _, entry := range m.config.ReadingHistory {
+		if entry.CurrentShelf() != config.ShelfDropped && entry.NewChapters() > 0 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package ui
+
+import "syscall"
+
+// detachedProcAttr has nothing to add where there are no Unix sessions.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build unix
+
+package ui
+
+import "syscall"
+
+// detachedProcAttr starts a process in a session of its own, away from the
+// terminal's.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/browser"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// failure explains a failed request in plain words and says which recovery
+// actions make sense for it.
+type failure struct {
+	summary string // A few words, for a one-line hint
+	message string
+	retry   bool // Trying again may work as is
+	browser bool // The page in a browser shows, or solves, the problem
+	cache   bool // A cached copy can stand in for the page
+}
+
+// failureKeys are the keys a screen binds to the recovery actions; an empty
+// key leaves its action out.
+type failureKeys struct {
+	retry   string
+	browser string
+	cache   string
+}
+
+// describeFailure classifies err by the client's error types. usingCache is
+// set when the request was already answered from cache only, so offering the
+// cache again would be pointless.
+func describeFailure(err error, usingCache bool) failure {
+	switch {
+	case errors.Is(err, royalroad.ErrChallenge):
+		return failure{
+			summary: "blocked by a Cloudflare check; see 'royal-road-cli cookies'",
+			message: "Cloudflare wants to check that you're a browser. Pass the check in a browser, then import its cookies with 'royal-road-cli cookies import' and send its User-Agent (--user-agent or http.userAgent).",
+			retry:   true,
+			browser: true,
+			cache:   !usingCache,
+		}
+	case errors.Is(err, royalroad.ErrNotFound):
+		return failure{
+			summary: "not found",
+			message: "Royal Road has no such page; the fiction or chapter may have been deleted or hidden by its author.",
+			browser: true,
+		}
+	case errors.Is(err, royalroad.ErrRateLimited):
+		return failure{
+			summary: "Royal Road is rate limiting requests",
+			message: "Royal Road is refusing requests because too many were made. Wait a minute before retrying, or lower http.requestsPerSecond.",
+			retry:   true,
+			cache:   !usingCache,
+		}
+	case errors.Is(err, royalroad.ErrOffline) && usingCache:
+		return failure{
+			summary: "not cached",
+			message: "This page isn't cached either; it needs a connection to Royal Road.",
+			retry:   true,
+		}
+	case errors.Is(err, royalroad.ErrOffline):
+		return failure{
+			summary: "can't reach Royal Road",
+			message: "Can't reach Royal Road. Check your connection (or http.proxy), or carry on with what's cached.",
+			retry:   true,
+			cache:   true,
+		}
+	case errors.Is(err, royalroad.ErrParse):
+		return failure{
+			summary: "unreadable page",
+			message: "Royal Road sent a page that couldn't be read. The site may have changed its layout, or sent a notice in place of the page.",
+			// The HTTP cache already holds the page that couldn't be read
+			retry:   true,
+			browser: true,
+		}
+	}
+	return failure{summary: err.Error(), message: err.Error(), retry: true}
+}
+
+// render shows the explanation, the underlying error when it adds anything,
+// and the recovery actions on offer, followed by the screen's other keys.
+func (f failure) render(err error, keys failureKeys, others ...string) string {
+	var actions []string
+	if f.retry && keys.retry != "" {
+		actions = append(actions, fmt.Sprintf("'%s' to retry", keys.retry))
+	}
+	if f.browser && keys.browser != "" {
+		actions = append(actions, fmt.Sprintf("'%s' to open it in a browser", keys.browser))
+	}
+	if f.cache && keys.cache != "" {
+		actions = append(actions, fmt.Sprintf("'%s' to use the cached copy", keys.cache))
+	}
+	actions = append(actions, others...)
+
+	var s strings.Builder
+	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ " + f.message))
+	if f.message != err.Error() {
+		s.WriteString("\n\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(err.Error()))
+	}
+	if len(actions) > 0 {
+		s.WriteString("\n\nPress " + orList(actions) + ".")
+	}
+	return s.String()
+}
+
+// orList joins items as "a", "a or b" or "a, b, or c".
+func orList(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " or " + items[1]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + ", or " + items[len(items)-1]
+}
+
+var readerFailureKeys = failureKeys{retry: "r", browser: "o", cache: "c"}
+
+// handleFailureKey runs the recovery actions of the reader's error screen;
+// r, m and q keep their usual meaning.
+func (m *ReaderModel) handleFailureKey(key string) (tea.Model, tea.Cmd, bool) {
+	f := describeFailure(m.err, m.cacheOnly)
+	switch {
+	case key == readerFailureKeys.browser && f.browser:
+		url := m.chapterURL()
+		if m.fiction == nil {
+			url = fmt.Sprintf("%s/fiction/%s", royalroad.DefaultBaseURL, m.fictionID)
+		}
+		if err := browser.Open(url); err != nil {
+			m.err = fmt.Errorf("opening browser failed: %w", err)
+		}
+		return m, nil, true
+	case key == readerFailureKeys.cache && f.cache:
+		// Stays on for the chapters after, until r goes back online
+		m.cacheOnly = true
+		m.loading = true
+		m.err = nil
+		if m.fiction == nil {
+			return m, m.loadFiction(), true
+		}
+		return m, m.loadChapter(m.chapterIndex), true
+	}
+	return m, nil, false
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Focus mode dims every paragraph but the one being read. j/k move the
+// focus a paragraph at a time, turning the page when it runs off screen;
+// after any other move the focus falls on the first paragraph in view.
+
+var focusDimStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+// paragraphAt returns the content lines [start, end) of the paragraph
+// holding line, or ok false for a blank line.
+func (m *ReaderModel) paragraphAt(line int) (start, end int, ok bool) {
+	if line < 0 || line >= len(m.content) || m.blankLine(line) {
+		return 0, 0, false
+	}
+	start, end = line, line+1
+	for start > 0 && !m.blankLine(start-1) {
+		start--
+	}
+	for end < len(m.content) && !m.blankLine(end) {
+		end++
+	}
+	return start, end, true
+}
+
+func (m *ReaderModel) blankLine(line int) bool {
+	return strings.TrimSpace(m.content[line]) == ""
+}
+
+// focusedParagraph is the paragraph to highlight: the one last moved to if
+// it is still on screen, otherwise the first one in view.
+func (m *ReaderModel) focusedParagraph() (start, end int, ok bool) {
+	top := m.topLine()
+	bottom := min(top+m.linesPerPage, len(m.content))
+	if start, end, ok := m.paragraphAt(m.focusLine); ok && end > top && start < bottom {
+		return start, end, true
+	}
+	for line := top; line < bottom; line++ {
+		if start, end, ok := m.paragraphAt(line); ok {
+			return start, end, true
+		}
+	}
+	return 0, 0, false
+}
+
+// focusDimmed reports whether line is outside the focused paragraph, or
+// the sentence being read aloud.
+func (m *ReaderModel) focusDimmed(line int) bool {
+	if start, end, ok := m.ttsSentenceLines(); ok {
+		return line < start || line > end
+	}
+	if !m.focusMode {
+		return false
+	}
+	start, end, ok := m.focusedParagraph()
+	return !ok || line < start || line >= end
+}
+
+// moveFocus moves the focus to the next (dir > 0) or previous paragraph,
+// bringing it into view, or moves on to the next or previous chapter.
+func (m *ReaderModel) moveFocus(dir int) tea.Cmd {
+	start, end, ok := m.focusedParagraph()
+	if !ok {
+		start, end = m.topLine(), m.topLine()
+	}
+
+	line := end
+	if dir < 0 {
+		line = start - 1
+	}
+	for line >= 0 && line < len(m.content) && m.blankLine(line) {
+		line += dir
+	}
+	if line < 0 {
+		return m.beforeChapterStart()
+	}
+	if line >= len(m.content) {
+		return m.pastChapterEnd()
+	}
+
+	next, nextEnd, _ := m.paragraphAt(line)
+	m.focusLine = next
+	top := m.topLine()
+	if m.scrollMode {
+		// Scroll just far enough to show the whole paragraph
+		if next < top {
+			m.setTopLine(next)
+		} else if nextEnd > top+m.linesPerPage {
+			m.setTopLine(min(next, nextEnd-m.linesPerPage))
+		}
+		return nil
+	}
+	if next >= top+m.linesPerPage {
+		m.setTopLine(next)
+		session.pageTurned()
+	} else if nextEnd <= top {
+		m.setTopLine(nextEnd - 1)
+	}
+	return nil
+}
+
+// shownFocus is the start of the paragraph focus mode highlights, or -1.
+func (m *ReaderModel) shownFocus() int {
+	if !m.focusMode || m.tts != nil {
+		return -1
+	}
+	if start, _, ok := m.focusedParagraph(); ok {
+		return start
+	}
+	return -1
+}
+
+// followFocus redraws the viewport when scrolling has moved the focus to
+// another paragraph.
+func (m *ReaderModel) followFocus() {
+	if m.scrollMode && m.shownFocus() != m.viewportFocus {
+		m.refreshViewport()
+	}
+}
+
+// toggleFocusMode turns paragraph highlighting on or off.
+func (m *ReaderModel) toggleFocusMode() {
+	m.focusMode = !m.focusMode
+	m.focusLine = -1
+	if m.scrollMode {
+		m.refreshViewport()
+	}
+}
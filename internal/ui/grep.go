@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/internal/search"
+)
+
+// LibrarySearchModel searches the text of every downloaded chapter and opens
+// the reader at the page a hit appears on.
+type LibrarySearchModel struct {
+	input     textinput.Model
+	hits      []search.Hit
+	selected  int
+	offset    int
+	searching bool
+	searched  string // Query the current hits are for
+	err       error
+	height    int
+}
+
+const (
+	librarySearchLimit      = 200
+	librarySearchPerChapter = 5
+)
+
+type librarySearchMsg struct {
+	query string
+	hits  []search.Hit
+	err   error
+}
+
+func NewLibrarySearchModel() *LibrarySearchModel {
+	input := textinput.New()
+	input.Placeholder = "Phrase to find in downloaded chapters..."
+	input.Prompt = "❯ "
+	input.Focus()
+	input.Width = 50
+
+	return &LibrarySearchModel{input: input, height: 24}
+}
+
+func (m *LibrarySearchModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func runLibrarySearch(query string) tea.Cmd {
+	return func() tea.Msg {
+		store, err := cache.Open()
+		if err != nil {
+			return librarySearchMsg{query: query, err: err}
+		}
+		hits, err := search.Library(store, query, search.Options{
+			Limit:      librarySearchLimit,
+			PerChapter: librarySearchPerChapter,
+		})
+		return librarySearchMsg{query: query, hits: hits, err: err}
+	}
+}
+
+// visibleHits is how many results fit on screen below the input.
+func (m *LibrarySearchModel) visibleHits() int {
+	return max((m.height-8)/2, 3)
+}
+
+func (m *LibrarySearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+	case librarySearchMsg:
+		m.searching = false
+		m.searched = msg.query
+		m.hits = msg.hits
+		m.err = msg.err
+		m.selected = 0
+		m.offset = 0
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "ctrl+p":
+			return openQuickSwitcher(m)
+		case "ctrl+r":
+			return returnToReading(m)
+		case "esc":
+			menuModel := NewMenuModel()
+			return menuModel, menuModel.Init()
+		case "up", "ctrl+k":
+			if m.selected > 0 {
+				m.selected--
+				m.offset = min(m.offset, m.selected)
+			}
+			return m, nil
+		case "down", "ctrl+j", "ctrl+n":
+			if m.selected < len(m.hits)-1 {
+				m.selected++
+				if m.selected >= m.offset+m.visibleHits() {
+					m.offset = m.selected - m.visibleHits() + 1
+				}
+			}
+			return m, nil
+		case "enter":
+			query := strings.TrimSpace(m.input.Value())
+			if query != "" && query != m.searched {
+				m.searching = true
+				return m, runLibrarySearch(query)
+			}
+			if len(m.hits) == 0 {
+				return m, nil
+			}
+			hit := m.hits[m.selected]
+			readerModel := NewReaderModel(strconv.Itoa(hit.FictionID))
+			readerModel.SetStartChapter(hit.ChapterIndex)
+			readerModel.SetStartProgress(hit.Position)
+			return readerModel, readerModel.Init()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *LibrarySearchModel) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("170")).
+		Render("🔎 Search Downloaded Library")
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("%s\n\n%s\n\n", title, m.input.View()))
+
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	switch {
+	case m.searching:
+		content.WriteString("Searching...\n\n")
+	case m.err != nil:
+		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).
+			Render(fmt.Sprintf("Error: %v", m.err)) + "\n\n")
+	case m.searched != "" && len(m.hits) == 0:
+		content.WriteString("No matches in downloaded chapters.\n\n")
+	case len(m.hits) > 0:
+		count := fmt.Sprintf("%d matches", len(m.hits))
+		if len(m.hits) >= librarySearchLimit {
+			count = fmt.Sprintf("first %d matches", librarySearchLimit)
+		}
+		content.WriteString(dim.Render(count) + "\n\n")
+	}
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("170")).
+		Bold(true)
+
+	end := min(m.offset+m.visibleHits(), len(m.hits))
+	for i := m.offset; i < end; i++ {
+		hit := m.hits[i]
+		line := fmt.Sprintf("%s › Ch %d: %s (%d%%)", hit.FictionTitle, hit.ChapterIndex+1, hit.ChapterTitle, int(hit.Position*100))
+		if i == m.selected {
+			content.WriteString(selectedStyle.Render("▶ " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n    " + dim.Render(hit.Snippet) + "\n")
+	}
+
+	content.WriteString("\n[enter] search / open • ↑/↓ select • [esc] back")
+	return content.String()
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// The history's selection mode ([v]) picks several books with their numbers,
+// 0 standing for [10], across pages and filters, to delete, finish or export
+// at once.
+
+func (m *MenuModel) startSelecting() {
+	m.selecting = true
+	m.selectedBooks = make(map[string]bool)
+	m.batchNotice = ""
+}
+
+func (m *MenuModel) stopSelecting() {
+	m.selecting = false
+	m.selectedBooks = nil
+	m.confirmBatchDelete = false
+}
+
+func (m *MenuModel) handleSelectionKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.confirmBatchDelete {
+		m.confirmBatchDelete = false
+		if msg.String() == "y" {
+			for id := range m.selectedBooks {
+				m.config.RemoveEntry(id)
+			}
+			_ = m.config.Save()
+			m.batchNotice = fmt.Sprintf("Deleted %d books", len(m.selectedBooks))
+			m.selectedBooks = make(map[string]bool)
+			m.clampHistoryPage()
+		}
+		return m, nil
+	}
+
+	entries, _, _, _ := config.PageEntries(m.historyEntries(), m.historyPage, m.historyPageSize)
+	switch key := msg.String(); key {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "v":
+		m.stopSelecting()
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9", "0":
+		// 0 is the tenth book on the page
+		num, _ := strconv.Atoi(key)
+		if num == 0 {
+			num = 10
+		}
+		if num <= len(entries) {
+			id := entries[num-1].FictionID
+			if m.selectedBooks[id] {
+				delete(m.selectedBooks, id)
+			} else {
+				m.selectedBooks[id] = true
+			}
+		}
+	case "a":
+		// Everything the filters show, or none of it if it's all selected already
+		all := m.historyEntries()
+		allSelected := true
+		for _, entry := range all {
+			allSelected = allSelected && m.selectedBooks[entry.FictionID]
+		}
+		for _, entry := range all {
+			if allSelected {
+				delete(m.selectedBooks, entry.FictionID)
+			} else {
+				m.selectedBooks[entry.FictionID] = true
+			}
+		}
+	case "left", "h":
+		if m.historyPage > 1 {
+			m.historyPage--
+		}
+	case "right", "l":
+		_, totalPages, hasNext, _ := config.PageEntries(m.historyEntries(), m.historyPage, m.historyPageSize)
+		if hasNext && m.historyPage < totalPages {
+			m.historyPage++
+		}
+	case "d":
+		if len(m.selectedBooks) > 0 {
+			m.confirmBatchDelete = true
+		}
+	case "F":
+		finished := 0
+		for id := range m.selectedBooks {
+			if entry := m.config.GetEntry(id); entry != nil && entry.CurrentShelf() != config.ShelfFinished {
+				m.config.MarkFinished(id, time.Now())
+				finished++
+			}
+		}
+		_ = m.config.Save()
+		m.batchNotice = fmt.Sprintf("Marked %d books finished", finished)
+	case "E":
+		if len(m.selectedBooks) > 0 {
+			m.batchNotice = m.exportSelected()
+		}
+	}
+	return m, nil
+}
+
+// exportSelected writes the selected books to a file that 'history import'
+// reads, in the current directory, and describes the outcome.
+func (m *MenuModel) exportSelected() string {
+	now := time.Now()
+	var ids []string
+	for _, entry := range m.config.ReadingHistory {
+		if m.selectedBooks[entry.FictionID] {
+			ids = append(ids, entry.FictionID)
+		}
+	}
+	data, err := json.MarshalIndent(m.config.ExportHistory(now, ids...), "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Error encoding history: %v", err)
+	}
+	path := fmt.Sprintf("history-%s.json", now.Format("2006-01-02-150405"))
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Sprintf("Error writing %s: %v", path, err)
+	}
+	return fmt.Sprintf("Exported %d books to %s", len(ids), path)
+}
+
+// selectionView is the footer of the history in selection mode.
+func (m *MenuModel) selectionView() string {
+	if m.confirmBatchDelete {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(
+			fmt.Sprintf("Delete %d books from your history? Their progress is lost [y/n]", len(m.selectedBooks)))
+	}
+	footer := ""
+	if m.batchNotice != "" {
+		footer = m.batchNotice + "\n"
+	}
+	return footer + fmt.Sprintf("%d selected • [1-9, 0] select • [a] all • [d] delete • [F] mark finished • [E] export • [esc] done", len(m.selectedBooks))
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jackowfish/royal-road-cli/internal/browser"
+	"github.com/jackowfish/royal-road-cli/internal/render"
+	"github.com/jackowfish/royal-road-cli/internal/termimage"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// imageViewerClosedMsg is sent when the image viewer hands the terminal back.
+type imageViewerClosedMsg struct {
+	err error
+}
+
+// imageViewer takes over the terminal through tea.Exec to draw images with
+// an inline graphics protocol, one at a time, waiting for Enter after each.
+type imageViewer struct {
+	ctx      context.Context // The reader's tasks, cancelled when it's left
+	client   *royalroad.Client
+	images   []render.Image
+	protocol termimage.Protocol
+	cols     int
+	stdin    io.Reader
+	stdout   io.Writer
+}
+
+func (v *imageViewer) SetStdin(r io.Reader)  { v.stdin = r }
+func (v *imageViewer) SetStdout(w io.Writer) { v.stdout = w }
+func (v *imageViewer) SetStderr(io.Writer)   {}
+
+func (v *imageViewer) Run() error {
+	input := bufio.NewReader(v.stdin)
+	for i, image := range v.images {
+		fmt.Fprint(v.stdout, "\x1b[2J\x1b[H")
+		fmt.Fprintf(v.stdout, "%s (%d/%d)\n\n", render.ImagePlaceholder(image.Alt), i+1, len(v.images))
+
+		data, err := termimage.Fetch(v.ctx, v.client, image.URL)
+		if err == nil {
+			err = termimage.Draw(v.stdout, v.protocol, data, v.cols)
+		}
+		if err != nil {
+			fmt.Fprintf(v.stdout, "Can't show this image: %v\n%s\n", err, image.URL)
+		}
+
+		prompt := "Press Enter for the next image"
+		if i == len(v.images)-1 {
+			prompt = "Press Enter to return to the chapter"
+		}
+		fmt.Fprintf(v.stdout, "\n%s ", prompt)
+		_, err = input.ReadString('\n')
+		termimage.Clear(v.stdout, v.protocol)
+		if err != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+// findImages records the chapter's images and the content line where each
+// placeholder starts, so the ones on the current page can be found.
+func (m *ReaderModel) findImages() {
+	m.images = render.Images(m.currentChapter.Content, royalroad.DefaultBaseURL)
+	m.imageLines = nil
+	for line, text := range m.content {
+		if len(m.imageLines) == len(m.images) {
+			break
+		}
+		text = strings.TrimLeft(render.StripANSI(text), render.QuotePrefix)
+		if strings.HasPrefix(text, "[Image") {
+			m.imageLines = append(m.imageLines, line)
+		}
+	}
+}
+
+// pageImages returns the images whose placeholders are on screen.
+func (m *ReaderModel) pageImages() []render.Image {
+	start := m.topLine()
+	var images []render.Image
+	for i, line := range m.imageLines {
+		if line >= start && line < start+m.linesPerPage {
+			images = append(images, m.images[i])
+		}
+	}
+	return images
+}
+
+// viewImages shows the images on the current page in the terminal, or in the
+// browser when the terminal has no graphics protocol.
+func (m *ReaderModel) viewImages() tea.Cmd {
+	images := m.pageImages()
+	if len(images) == 0 {
+		return nil
+	}
+	if m.imageProtocol == termimage.None {
+		m.openImagesInBrowser(images)
+		return nil
+	}
+	viewer := &imageViewer{ctx: m.tasks.context(), client: m.client, images: images, protocol: m.imageProtocol, cols: m.textWidth()}
+	return tea.Exec(viewer, func(err error) tea.Msg {
+		return imageViewerClosedMsg{err: err}
+	})
+}
+
+// openInBrowser opens the images on the current page in the browser, or the
+// chapter itself if the page has none.
+func (m *ReaderModel) openInBrowser() {
+	if images := m.pageImages(); len(images) > 0 {
+		m.openImagesInBrowser(images)
+		return
+	}
+	url := m.chapterURL()
+	if url == "" {
+		return
+	}
+	if err := browser.Open(url); err != nil {
+		m.err = fmt.Errorf("opening browser failed: %w", err)
+	}
+}
+
+func (m *ReaderModel) openImagesInBrowser(images []render.Image) {
+	for _, image := range images {
+		if err := browser.Open(image.URL); err != nil {
+			m.err = fmt.Errorf("opening browser failed: %w", err)
+			return
+		}
+	}
+}
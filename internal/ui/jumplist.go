@@ -0,0 +1,85 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The jumplist remembers where the reader was before each jump (TOC, search,
+// command line), so ctrl+o can go back and ctrl+i (tab) forward again, as in vim.
+
+const jumplistLimit = 100
+
+type jumpPosition struct {
+	chapterIndex int
+	progress     float64 // Position within the chapter (0.0-1.0)
+	words        int     // Word offset into the chapter text, preferred over progress; 0 if unknown
+}
+
+type jumplist struct {
+	entries []jumpPosition
+	index   int // Entry ctrl+i returns to; len(entries) when at the newest position
+}
+
+// currentPosition is where the reader is now.
+func (m *ReaderModel) currentPosition() jumpPosition {
+	position := jumpPosition{chapterIndex: m.chapterIndex}
+	if len(m.content) > 0 {
+		position.progress = float64(m.topLine()) / float64(len(m.content))
+		position.words = m.wordOffset(m.topLine())
+	}
+	return position
+}
+
+// recordJump saves the current position before a jump, dropping any
+// positions that had been gone back over.
+func (m *ReaderModel) recordJump() {
+	if m.currentChapter == nil {
+		return
+	}
+	j := &m.jumps
+	j.entries = append(j.entries[:j.index], m.currentPosition())
+	if len(j.entries) > jumplistLimit {
+		j.entries = j.entries[len(j.entries)-jumplistLimit:]
+	}
+	j.index = len(j.entries)
+}
+
+// jumpBack returns to the position before the last jump.
+func (m *ReaderModel) jumpBack() tea.Cmd {
+	j := &m.jumps
+	if j.index == 0 || m.currentChapter == nil {
+		return nil
+	}
+	if j.index == len(j.entries) {
+		// Remember where we are so ctrl+i can come back
+		j.entries = append(j.entries, m.currentPosition())
+	}
+	j.index--
+	return m.gotoChapterPosition(j.entries[j.index])
+}
+
+// jumpForward undoes a jumpBack.
+func (m *ReaderModel) jumpForward() tea.Cmd {
+	j := &m.jumps
+	if j.index >= len(j.entries)-1 {
+		return nil
+	}
+	j.index++
+	return m.gotoChapterPosition(j.entries[j.index])
+}
+
+// gotoChapterPosition opens a chapter at a position, loading it if needed.
+func (m *ReaderModel) gotoChapterPosition(position jumpPosition) tea.Cmd {
+	if position.chapterIndex == m.chapterIndex && m.currentChapter != nil {
+		m.seekAnchor(textAnchor{words: position.words, progress: position.progress})
+		return nil
+	}
+	if m.fiction == nil || position.chapterIndex >= len(m.fiction.Chapters) {
+		return nil
+	}
+	m.chapterIndex = position.chapterIndex
+	m.savedChapterProgress = position.progress
+	m.savedWordOffset = position.words
+	m.loading = true
+	return m.loadChapter(position.chapterIndex)
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jackowfish/royal-road-cli/internal/render"
+)
+
+// Bounds for Reading.TextWidth when adjusted with +/-.
+const (
+	minTextWidth  = 40
+	maxTextWidth  = 200
+	textWidthStep = 4
+)
+
+// textWidth is the width chapters are wrapped to: Reading.TextWidth, or the
+// terminal minus padding if that is narrower or unset.
+func (m *ReaderModel) textWidth() int {
+	width := max(m.termWidth-4, minTextWidth) // 4 = padding on both sides
+	if m.config != nil && m.config.Reading.TextWidth > 0 {
+		width = min(width, max(m.config.Reading.TextWidth, minTextWidth))
+	}
+	return width
+}
+
+// leftMargin centers the text column in terminals wider than it.
+func (m *ReaderModel) leftMargin() int {
+	return max((m.termWidth-m.textWidth())/2, 0)
+}
+
+// layout is the line and paragraph spacing from the Reading settings.
+func (m *ReaderModel) layout() render.Layout {
+	if m.config == nil {
+		return render.DefaultLayout
+	}
+	return render.Layout{
+		LineSpacing:     m.config.Reading.LineSpacing,
+		ParagraphGap:    m.config.Reading.ParagraphGap,
+		ParagraphIndent: m.config.Reading.ParagraphIndent,
+	}
+}
+
+// adjustTextWidth widens (delta > 0) or narrows the text column, saves the
+// new width and re-wraps the chapter at the same position.
+func (m *ReaderModel) adjustTextWidth(delta int) {
+	if m.config == nil {
+		return
+	}
+	width := m.textWidth() + delta
+	m.config.Reading.TextWidth = min(max(width, minTextWidth), maxTextWidth)
+	m.config.Save()
+	m.applyWindowSize(tea.WindowSizeMsg{Width: m.termWidth, Height: m.termHeight})
+}
@@ -0,0 +1,289 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// libraryRow is a line of the library: a shelf heading or a book on it.
+type libraryRow struct {
+	shelf     string // Set on headings
+	fictionID string // Set on books
+}
+
+// LibraryModel shows every book in the reading history grouped by shelf,
+// with quick actions for moving books between shelves.
+type LibraryModel struct {
+	config   *config.Config
+	rows     []libraryRow
+	selected int // Row of the selected book
+	offset   int
+	height   int
+	message  string
+	starred  bool // Only starred books are listed
+	previous tea.Model
+}
+
+// NewLibraryModel lists the books in cfg, loading it if nil. Like the
+// bookmark list it shares the config of the screen it came from.
+func NewLibraryModel(cfg *config.Config, previous tea.Model) *LibraryModel {
+	if cfg == nil {
+		cfg, _ = config.Load()
+	}
+	_, termHeight := getTerminalSize()
+	m := &LibraryModel{
+		config:   cfg,
+		height:   max(termHeight-6, 5),
+		previous: previous,
+	}
+	m.refresh()
+	m.selectBook(0, 1)
+	return m
+}
+
+// refresh groups the history by shelf, most recently read first within each.
+func (m *LibraryModel) refresh() {
+	selectedID := ""
+	if m.selected < len(m.rows) {
+		selectedID = m.rows[m.selected].fictionID
+	}
+
+	m.rows = nil
+	for _, shelf := range config.ShelfOrder {
+		var books []libraryRow
+		for _, entry := range m.config.ReadingHistory {
+			if entry.CurrentShelf() == shelf && (entry.Starred || !m.starred) {
+				books = append(books, libraryRow{fictionID: entry.FictionID})
+			}
+		}
+		if len(books) > 0 {
+			m.rows = append(m.rows, libraryRow{shelf: shelf})
+			m.rows = append(m.rows, books...)
+		}
+	}
+
+	// Keep the selection on the same book after it moves shelves
+	for i, row := range m.rows {
+		if selectedID != "" && row.fictionID == selectedID {
+			m.selected = i
+		}
+	}
+	m.selected = min(m.selected, max(len(m.rows)-1, 0))
+	if m.selected < len(m.rows) && m.rows[m.selected].fictionID == "" {
+		// Land on a book rather than a heading
+		m.selectBook(m.selected, 1)
+		m.selectBook(m.selected, -1)
+	}
+	m.ensureVisible()
+}
+
+// selectBook moves the selection to the nearest book from row in direction
+// step, staying put if there isn't one.
+func (m *LibraryModel) selectBook(row, step int) {
+	for ; row >= 0 && row < len(m.rows); row += step {
+		if m.rows[row].fictionID != "" {
+			m.selected = row
+			m.ensureVisible()
+			return
+		}
+	}
+}
+
+func (m *LibraryModel) ensureVisible() {
+	if m.selected < m.offset {
+		m.offset = m.selected
+		// Show the shelf heading above the first book
+		if m.offset > 0 && m.rows[m.offset-1].shelf != "" {
+			m.offset--
+		}
+	} else if m.selected >= m.offset+m.height {
+		m.offset = m.selected - m.height + 1
+	}
+}
+
+func (m *LibraryModel) selectedEntry() *config.ReadingEntry {
+	if m.selected >= len(m.rows) || m.rows[m.selected].fictionID == "" {
+		return nil
+	}
+	return m.config.GetEntry(m.rows[m.selected].fictionID)
+}
+
+func (m *LibraryModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *LibraryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = max(msg.Height-6, 5)
+		m.ensureVisible()
+
+	case tea.KeyMsg:
+		m.message = ""
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "ctrl+p":
+			return openQuickSwitcher(m)
+		case "ctrl+r":
+			return returnToReading(m)
+		case "esc", "q":
+			return m.back()
+		case "up", "k":
+			m.selectBook(m.selected-1, -1)
+		case "down", "j":
+			m.selectBook(m.selected+1, 1)
+		case "enter":
+			if entry := m.selectedEntry(); entry != nil {
+				readerModel := NewReaderModel(entry.FictionID)
+				return readerModel, readerModel.Init()
+			}
+		case "*":
+			if entry := m.selectedEntry(); entry != nil {
+				m.config.ToggleStar(entry.FictionID)
+				_ = m.config.Save()
+				m.refresh()
+			}
+		case "s":
+			m.starred = !m.starred
+			m.refresh()
+		case "R":
+			m.moveTo(config.ShelfReading)
+		case "L":
+			m.moveTo(config.ShelfLater)
+		case "P":
+			m.moveTo(config.ShelfPaused)
+		case "F":
+			m.moveTo(config.ShelfFinished)
+		case "D":
+			m.moveTo(config.ShelfDropped)
+		}
+	}
+	return m, nil
+}
+
+// moveTo puts the selected book on another shelf.
+func (m *LibraryModel) moveTo(shelf string) {
+	entry := m.selectedEntry()
+	if entry == nil || entry.CurrentShelf() == shelf {
+		return
+	}
+	m.config.MoveToShelf(entry.FictionID, shelf, time.Now())
+	_ = m.config.Save()
+	m.message = fmt.Sprintf("Moved %s to %s", entry.FictionTitle, config.ShelfName(shelf))
+	m.refresh()
+}
+
+func (m *LibraryModel) back() (tea.Model, tea.Cmd) {
+	if m.previous != nil {
+		return m.previous, nil
+	}
+	menuModel := NewMenuModel()
+	return menuModel, menuModel.Init()
+}
+
+func (m *LibraryModel) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("170")).
+		Render("📚 Library")
+	if m.starred {
+		title += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(" • starred only")
+	}
+
+	var content strings.Builder
+	content.WriteString(title + "\n\n")
+	if len(m.rows) == 0 {
+		if m.starred {
+			content.WriteString("No starred books — press [*] on a book to star it, [s] to show them all.\n")
+		} else {
+			content.WriteString("Your library is empty — books you read or shelve show up here.\n")
+		}
+	}
+
+	headingStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("150"))
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("170")).
+		Bold(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	end := min(m.offset+m.height, len(m.rows))
+	for i := m.offset; i < end; i++ {
+		row := m.rows[i]
+		if row.shelf != "" {
+			content.WriteString(headingStyle.Render(fmt.Sprintf("%s (%d)", config.ShelfName(row.shelf), m.shelfCount(i))) + "\n")
+			continue
+		}
+		entry := m.config.GetEntry(row.fictionID)
+		line := entry.FictionTitle
+		if entry.Starred {
+			line = "★ " + line
+		}
+		if entry.Author != "" {
+			line += " by " + entry.Author
+		}
+		if i == m.selected {
+			content.WriteString(selectedStyle.Render("▶ " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("  " + dim.Render(libraryDetails(*entry)) + "\n")
+	}
+	if len(m.rows) > m.height {
+		content.WriteString(dim.Render(fmt.Sprintf("\n(%d-%d of %d)", m.offset+1, end, len(m.rows))) + "\n")
+	}
+
+	if m.message != "" {
+		content.WriteString("\n" + m.message)
+	}
+	content.WriteString("\n↑/↓ select • [enter] read • move to [R]eading, read [L]ater, [P]aused, [F]inished, [D]ropped • [*] star • [s] starred only • [esc] back")
+	return content.String()
+}
+
+// shelfCount counts the books under the heading at row.
+func (m *LibraryModel) shelfCount(row int) int {
+	count := 0
+	for i := row + 1; i < len(m.rows) && m.rows[i].shelf == ""; i++ {
+		count++
+	}
+	return count
+}
+
+// libraryDetails summarizes a book's progress on its shelf.
+func libraryDetails(entry config.ReadingEntry) string {
+	var details []string
+	switch entry.CurrentShelf() {
+	case config.ShelfFinished:
+		if entry.FinishedAt != "" {
+			details = append(details, "finished "+dateOnly(entry.FinishedAt))
+		}
+	default:
+		if entry.TotalChapters > 0 {
+			details = append(details, fmt.Sprintf("ch. %d/%d", entry.CurrentChapter+1, entry.TotalChapters))
+		}
+		if unread := entry.UnreadCount(nil); unread > 0 {
+			details = append(details, fmt.Sprintf("%d unread", unread))
+		}
+	}
+	if entry.Notes != "" {
+		details = append(details, "📝 "+notesPreview(entry.Notes, 30))
+	}
+	if entry.LastRead != "" {
+		details = append(details, "last read "+dateOnly(entry.LastRead))
+	} else if entry.ShelvedAt != "" {
+		details = append(details, "added "+dateOnly(entry.ShelvedAt))
+	}
+	return strings.Join(details, " • ")
+}
+
+// dateOnly trims a config.TimeLayout timestamp to its date.
+func dateOnly(timestamp string) string {
+	date, _, _ := strings.Cut(timestamp, " ")
+	return date
+}
@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// markKind is what a MarksModel lists: bookmarks or highlights. Marks are
+// addressed by their index in the config's slice of them.
+type markKind interface {
+	labels() markLabels
+	// list returns the marks in fictionID, or in every fiction with all, in
+	// display order.
+	list(cfg *config.Config, fictionID string, all bool) []int
+	remove(cfg *config.Config, i int)
+	// target is the fiction a mark is in and where in it to open the reader.
+	target(cfg *config.Config, i int) (fictionID, fictionTitle string, position jumpPosition)
+	// row renders a mark as its first line, which shows the selection, and
+	// whatever follows it, at most width columns wide.
+	row(cfg *config.Config, i int, width int) (line, rest string)
+}
+
+type markLabels struct {
+	title    string // For one fiction's marks, e.g. "🔖 Bookmarks"
+	allTitle string
+	empty    string // Shown when there are none, with how to add one
+	closeKey string // The reader key that opens the list also closes it
+	rowLines int    // Lines each mark takes up at most
+}
+
+// MarksModel lists marks, either for one fiction or for all of them, and
+// opens the reader at the chosen one.
+type MarksModel struct {
+	kind      markKind
+	config    *config.Config
+	fictionID string // Fiction shown unless all is set; empty for none
+	all       bool
+	items     []int // Marks in display order
+	selected  int
+	offset    int
+	height    int // Marks that fit on the screen
+	width     int
+	previous  tea.Model
+}
+
+// newMarksModel lists kind's marks in cfg. With a fictionID only that
+// fiction's are shown at first; esc returns to previous. The config is
+// shared so a reader coming back to the screen sees deletions.
+func newMarksModel(kind markKind, cfg *config.Config, fictionID string, previous tea.Model) *MarksModel {
+	if cfg == nil {
+		cfg, _ = config.Load()
+	}
+	termWidth, termHeight := getTerminalSize()
+	m := &MarksModel{
+		kind:      kind,
+		config:    cfg,
+		fictionID: fictionID,
+		all:       fictionID == "",
+		previous:  previous,
+	}
+	m.resize(termWidth, termHeight)
+	m.refresh()
+	return m
+}
+
+func (m *MarksModel) resize(width, height int) {
+	m.width = max(width-4, 20)
+	m.height = max((height-8)/m.kind.labels().rowLines, 2)
+}
+
+func (m *MarksModel) refresh() {
+	m.items = m.kind.list(m.config, m.fictionID, m.all)
+	m.selected = min(m.selected, max(len(m.items)-1, 0))
+	m.ensureVisible()
+}
+
+func (m *MarksModel) ensureVisible() {
+	if m.selected < m.offset {
+		m.offset = m.selected
+	} else if m.selected >= m.offset+m.height {
+		m.offset = m.selected - m.height + 1
+	}
+}
+
+func (m *MarksModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *MarksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.resize(msg.Width, msg.Height)
+		m.ensureVisible()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "ctrl+r":
+			return returnToReading(m)
+		case "esc", "q", m.kind.labels().closeKey:
+			return m.back()
+		case "up", "k":
+			if m.selected > 0 {
+				m.selected--
+				m.ensureVisible()
+			}
+		case "down", "j":
+			if m.selected < len(m.items)-1 {
+				m.selected++
+				m.ensureVisible()
+			}
+		case "a":
+			// Switch between this fiction's marks and everyone's
+			if m.fictionID != "" {
+				m.all = !m.all
+				m.selected, m.offset = 0, 0
+				m.refresh()
+			}
+		case "d", "delete":
+			if len(m.items) > 0 {
+				m.kind.remove(m.config, m.items[m.selected])
+				_ = m.config.Save()
+				m.refresh()
+			}
+		case "enter":
+			if len(m.items) > 0 {
+				return m.open(m.items[m.selected])
+			}
+		}
+		return m, nil
+	}
+
+	// The reader underneath keeps its timers, downloads and layout going
+	if m.previous != nil {
+		var cmd tea.Cmd
+		m.previous, cmd = m.previous.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *MarksModel) back() (tea.Model, tea.Cmd) {
+	if reader, ok := m.previous.(*ReaderModel); ok {
+		// Highlights may have been deleted
+		reader.highlightsChanged()
+		return reader, nil
+	}
+	if m.previous != nil {
+		return m.previous, nil
+	}
+	menuModel := NewMenuModel()
+	return menuModel, menuModel.Init()
+}
+
+// open goes to a mark, in the reader it came from if it's the same fiction.
+func (m *MarksModel) open(i int) (tea.Model, tea.Cmd) {
+	fictionID, _, position := m.kind.target(m.config, i)
+	if reader, ok := m.previous.(*ReaderModel); ok {
+		if reader.fictionID == fictionID {
+			reader.highlightsChanged()
+			reader.recordJump()
+			return reader, reader.gotoChapterPosition(position)
+		}
+		reader.saveReadingProgress()
+		reader.stopChapterSearch()
+	}
+	readerModel := NewReaderModel(fictionID)
+	readerModel.SetStartChapter(position.chapterIndex)
+	readerModel.SetStartProgress(position.progress)
+	readerModel.SetStartWordOffset(position.words)
+	return readerModel, readerModel.Init()
+}
+
+func (m *MarksModel) View() string {
+	labels := m.kind.labels()
+	titleText := labels.allTitle
+	if !m.all {
+		titleText = labels.title
+		if len(m.items) > 0 {
+			_, fictionTitle, _ := m.kind.target(m.config, m.items[0])
+			titleText += " in " + fictionTitle
+		}
+	}
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("170")).
+		Render(titleText)
+
+	var content strings.Builder
+	content.WriteString(title + "\n\n")
+	if len(m.items) == 0 {
+		content.WriteString(labels.empty + "\n")
+	}
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("170")).
+		Bold(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	end := min(m.offset+m.height, len(m.items))
+	for i := m.offset; i < end; i++ {
+		line, rest := m.kind.row(m.config, m.items[i], m.width)
+		if m.all {
+			_, fictionTitle, _ := m.kind.target(m.config, m.items[i])
+			line = fictionTitle + " • " + line
+		}
+		if i == m.selected {
+			content.WriteString(selectedStyle.Render("▶ " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString(rest + "\n")
+	}
+	if len(m.items) > m.height {
+		content.WriteString(dim.Render(fmt.Sprintf("\n(%d-%d of %d)", m.offset+1, end, len(m.items))) + "\n")
+	}
+
+	help := "\n↑/↓ select • [enter] open • [d] delete"
+	if m.fictionID != "" {
+		if m.all {
+			help += " • [a] this fiction only"
+		} else {
+			help += " • [a] all fictions"
+		}
+	}
+	content.WriteString(help + " • [esc] back")
+	return content.String()
+}
@@ -1,16 +1,22 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
 
-	"royal-road-cli/internal/api"
-	"royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/download"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
 )
 
 type MenuState int
@@ -20,24 +26,69 @@ const (
 	MenuStateHistory
 	MenuStateNewBook
 	MenuStateNewChapter
+	MenuStateCleanup
 )
 
 type MenuModel struct {
 	state       MenuState
 	config      *config.Config
-	client      *api.Client
+	client      *royalroad.Client
+	requests    requestScope // The typed fiction ID being checked
 	
 	// History pagination
 	historyPage     int
 	historyPageSize int
 	
+	// History filtering
+	historyFilter    textinput.Model
+	filteringHistory bool
+	historySort      int // Index into config.HistorySorts
+	historyStatus    int // Index into config.HistoryStatuses
+	
 	// Input fields
 	fictionInput  textinput.Model
 	chapterInput  textinput.Model
 	
+	// Stale books being reviewed for the Paused/Dropped shelves
+	cleanupEntries []config.ReadingEntry
+	cleanupIndex   int
+	
+	// Set after [c] while waiting for the number of the book to continue
+	pendingContinue bool
+	
+	// Set after [r], [d], [p], [m], [e] or [*] in the history while waiting for the
+	// number of the book to act on, then while confirming it
+	historyAction string
+	actionEntry   *config.ReadingEntry
+	
+	// Set after [C] in the history while confirming clearing all of it
+	confirmClear bool
+	
+	// Selection mode in the history, by fiction ID
+	selecting          bool
+	selectedBooks      map[string]bool
+	confirmBatchDelete bool
+	batchNotice        string
+	
 	// Status
 	loading bool
 	err     error
+	cacheNotice string // Set when the offline cache is close to its size limit
+	message     string // One-off notice from the screen that opened the menu
+	
+	// Re-fetching chapter counts for the history in the background
+	refreshing    bool
+	refreshNotice string
+	refresh       requestScope
+	
+	// Inline feedback for the New Book inputs
+	inputErr  string
+	inputHint string
+	checking  bool
+	
+	// Completions for the fiction input; suggestionIndex is -1 when none is selected
+	suggestions     []fictionSuggestion
+	suggestionIndex int
 	
 	// Results
 	selectedEntry *config.ReadingEntry
@@ -47,68 +98,153 @@ func NewMenuModel() *MenuModel {
 	cfg, _ := config.Load()
 	
 	fictionInput := textinput.New()
-	fictionInput.Placeholder = "Enter fiction ID (e.g., 21220)"
+	fictionInput.Placeholder = "Fiction ID (e.g., 21220) or Royal Road URL"
 	fictionInput.Focus()
-	fictionInput.Width = 30
+	fictionInput.Width = 50
 	
 	chapterInput := textinput.New()
 	chapterInput.Placeholder = "Enter chapter number (default: 1)"
+	switch cfg.Reading.NewBookStart {
+	case config.StartLatest:
+		chapterInput.Placeholder = "Enter chapter number (default: latest)"
+	case config.StartAsk:
+		chapterInput.Placeholder = "Enter chapter number (default: ask)"
+	}
 	chapterInput.Width = 30
+	chapterInput.Validate = digitsOnly("Chapter numbers are numbers")
 	
-	return &MenuModel{
+	historyFilter := textinput.New()
+	historyFilter.Placeholder = "Filter by title or author"
+	historyFilter.Prompt = "/ "
+	historyFilter.Width = 40
+	
+	m := &MenuModel{
 		state:           MenuStateMain,
 		config:          cfg,
-		client:          api.NewClient(),
+		client:          network.NewClient(cfg),
 		historyPage:     1,
 		historyPageSize: 10,
+		historyFilter:   historyFilter,
 		fictionInput:    fictionInput,
 		chapterInput:    chapterInput,
+		suggestionIndex: -1,
 	}
+	m.fictionInput.Validate = m.validateFictionInput
+	store, _ := cache.Open()
+	m.cacheNotice = cacheWarning(cfg, store)
+	return m
 }
 
 func (m *MenuModel) Init() tea.Cmd {
+	// Keep the new chapter badges on the continue lines current
+	if m.config.RefreshDue(time.Now()) {
+		return tea.Batch(textinput.Blink, m.refreshMetadata())
+	}
 	return textinput.Blink
 }
 
 func (m *MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch m.state {
-		case MenuStateMain:
-			return m.handleMainMenu(msg)
-		case MenuStateHistory:
-			return m.handleHistoryMenu(msg)
-		case MenuStateNewBook:
-			return m.handleNewBookInput(msg)
-		case MenuStateNewChapter:
-			return m.handleNewChapterInput(msg)
+		model, cmd := m.handleKey(msg)
+		if model != nil && model != tea.Model(m) {
+			m.leave()
+		}
+		if model != nil {
+			return model, cmd
 		}
 		
 	case tea.WindowSizeMsg:
 		return m, nil
+		
+	case fictionCheckedMsg:
+		return m.handleFictionChecked(msg)
+		
+	case metadataRefreshedMsg:
+		return m.handleMetadataRefreshed(msg)
 	}
 	
 	var cmd tea.Cmd
-	m.fictionInput, cmd = m.fictionInput.Update(msg)
+	if m.state == MenuStateNewChapter {
+		m.chapterInput, cmd = m.chapterInput.Update(msg)
+	} else {
+		m.fictionInput, cmd = m.fictionInput.Update(msg)
+	}
 	return m, cmd
 }
 
+// recentContinueCount is how many recent books the main menu offers to continue.
+const recentContinueCount = 3
+
+// handleKey returns a nil model for keys that go to the text inputs.
+func (m *MenuModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+p" {
+		return openQuickSwitcher(m)
+	}
+	if msg.String() == "ctrl+r" {
+		return returnToReading(m)
+	}
+	switch m.state {
+	case MenuStateMain:
+		return m.handleMainMenu(msg)
+	case MenuStateHistory:
+		return m.handleHistoryMenu(msg)
+	case MenuStateNewBook:
+		return m.handleNewBookInput(msg)
+	case MenuStateNewChapter:
+		return m.handleNewChapterInput(msg)
+	case MenuStateCleanup:
+		return m.handleCleanup(msg)
+	}
+	return nil, nil
+}
+
 func (m *MenuModel) handleMainMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.pendingContinue {
+		m.pendingContinue = false
+		switch msg.String() {
+		case "c", "enter":
+			return m.continueRecent(0)
+		case "1", "2", "3":
+			num, _ := strconv.Atoi(msg.String())
+			return m.continueRecent(num - 1)
+		}
+		// Anything else cancels the pending choice and is handled normally
+	}
+	
+	m.message = ""
 	switch msg.String() {
 	case "ctrl+c", "q":
 		return m, tea.Quit
+	case "u":
+		if len(m.config.Queue) > 0 {
+			return startQueued(m.config, m.config.Queue[0])
+		}
+	case "U":
+		queue := NewQueueModel(m.config, m)
+		return queue, queue.Init()
 	case "c":
-		// Continue last read
-		if lastEntry := m.config.GetLastReadEntry(); lastEntry != nil {
-			readerModel := NewReaderModel(lastEntry.FictionID)
-			readerModel.SetStartChapter(lastEntry.CurrentChapter)
-			return readerModel, readerModel.Init()
+		// With a single book there's nothing to choose between
+		if len(m.config.ContinueEntries()) == 1 {
+			return m.continueRecent(0)
+		}
+		if len(m.config.ContinueEntries()) > 1 {
+			m.pendingContinue = true
 		}
 	case "h":
 		// Show history
 		m.state = MenuStateHistory
 		m.historyPage = 1
+		if m.config.RefreshDue(time.Now()) {
+			return m, m.refreshMetadata()
+		}
 		return m, nil
+	case "l":
+		library := NewLibraryModel(m.config, m)
+		return library, library.Init()
+	case "'":
+		bookmarks := NewBookmarksModel(m.config, "", m)
+		return bookmarks, bookmarks.Init()
 	case "n":
 		// New book
 		m.state = MenuStateNewBook
@@ -122,24 +258,117 @@ func (m *MenuModel) handleMainMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Search fictions
 		searchModel := NewSearchModel()
 		return searchModel, searchModel.Init()
+	case "g":
+		// Search downloaded chapters
+		librarySearch := NewLibrarySearchModel()
+		return librarySearch, librarySearch.Init()
 	}
 	return m, nil
 }
 
+// continueRecent reopens the i-th most recently read book where it was left.
+func (m *MenuModel) continueRecent(i int) (tea.Model, tea.Cmd) {
+	entries := m.config.ContinueEntries()
+	if i < 0 || i >= len(entries) || i >= recentContinueCount {
+		return m, nil
+	}
+	entry := entries[i]
+	readerModel := NewReaderModel(entry.FictionID)
+	return readerModel, readerModel.Init()
+}
+
 func (m *MenuModel) handleHistoryMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.filteringHistory {
+		return m.handleHistoryFilterInput(msg)
+	}
+	if m.confirmClear {
+		return m.handleClearConfirm(msg)
+	}
+	if m.selecting {
+		return m.handleSelectionKey(msg)
+	}
+	if m.actionEntry != nil {
+		return m.handleActionConfirm(msg)
+	}
+	if action := m.historyAction; action != "" {
+		m.historyAction = ""
+		if num, err := strconv.Atoi(msg.String()); err == nil {
+			entries, _, _, _ := config.PageEntries(m.historyEntries(), m.historyPage, m.historyPageSize)
+			if num > 0 && num <= len(entries) {
+				entry := entries[num-1]
+				switch action {
+				case "p":
+					// Pinning is easily undone, so it doesn't ask first
+					m.config.TogglePin(entry.FictionID)
+					_ = m.config.Save()
+				case "*":
+					m.config.ToggleStar(entry.FictionID)
+					_ = m.config.Save()
+				case "e":
+					notes := NewBookNotesModel(m.config, entry.FictionID, m)
+					return notes, notes.Init()
+				default:
+					m.historyAction = action
+					m.actionEntry = &entry
+				}
+			}
+			return m, nil
+		}
+		// Anything else cancels the pending choice and is handled normally
+	}
+	
 	switch msg.String() {
 	case "ctrl+c", "q":
 		return m, tea.Quit
 	case "esc":
+		// Clear an active filter first, then leave the history screen
+		if m.historyFilter.Value() != "" {
+			m.historyFilter.SetValue("")
+			m.historyPage = 1
+			return m, nil
+		}
 		m.state = MenuStateMain
 		return m, nil
+	case "/":
+		m.filteringHistory = true
+		m.historyFilter.Focus()
+		return m, textinput.Blink
+	case "r", "d", "p", "m", "e", "*":
+		m.historyAction = msg.String()
+		return m, nil
+	case "R":
+		return m, m.refreshMetadata()
+	case "v":
+		m.startSelecting()
+		return m, nil
+	case "s":
+		m.historySort = (m.historySort + 1) % len(config.HistorySorts)
+		m.historyPage = 1
+		return m, nil
+	case "f":
+		m.historyStatus = (m.historyStatus + 1) % len(config.HistoryStatuses)
+		m.historyPage = 1
+		return m, nil
+	case "C":
+		if len(m.config.ReadingHistory) > 0 {
+			m.confirmClear = true
+		}
+		return m, nil
+	case "x":
+		// Review books that haven't been opened in a while
+		if stale := m.config.StaleEntries(time.Now()); len(stale) > 0 {
+			m.cleanupEntries = stale
+			m.cleanupIndex = 0
+			m.state = MenuStateCleanup
+		}
+		return m, nil
 	case "left", "h":
 		if m.historyPage > 1 {
 			m.historyPage--
 		}
 		return m, nil
 	case "right", "l":
-		_, totalPages, hasNext, _ := m.config.GetReadingHistoryPage(m.historyPage, m.historyPageSize)
+		_, totalPages, hasNext, _ := config.PageEntries(m.historyEntries(), m.historyPage, m.historyPageSize)
 		if hasNext && m.historyPage < totalPages {
 			m.historyPage++
 		}
@@ -147,11 +376,10 @@ func (m *MenuModel) handleHistoryMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
 		// Select entry by number
 		num, _ := strconv.Atoi(msg.String())
-		entries, _, _, _ := m.config.GetReadingHistoryPage(m.historyPage, m.historyPageSize)
+		entries, _, _, _ := config.PageEntries(m.historyEntries(), m.historyPage, m.historyPageSize)
 		if num > 0 && num <= len(entries) {
 			entry := entries[num-1]
 			readerModel := NewReaderModel(entry.FictionID)
-			readerModel.SetStartChapter(entry.CurrentChapter)
 			return readerModel, readerModel.Init()
 		}
 		return m, nil
@@ -159,59 +387,507 @@ func (m *MenuModel) handleHistoryMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// metadataRefreshedMsg carries freshly fetched fiction pages for the history.
+type metadataRefreshedMsg []download.FictionResult
+
+// refreshMetadata re-fetches the fiction page of every book in the history
+// apart from dropped ones, so chapter counts and statuses are current.
+func (m *MenuModel) refreshMetadata() tea.Cmd {
+	if m.refreshing || offlineMode {
+		return nil
+	}
+	var fictionIDs []int
+	for _, entry := range m.config.ReadingHistory {
+		if id, err := strconv.Atoi(entry.FictionID); err == nil && entry.CurrentShelf() != config.ShelfDropped {
+			fictionIDs = append(fictionIDs, id)
+		}
+	}
+	if len(fictionIDs) == 0 {
+		return nil
+	}
+	m.refreshing = true
+	m.refreshNotice = fmt.Sprintf("Refreshing %d books…", len(fictionIDs))
+	client := m.client
+	return m.refresh.run(func(ctx context.Context) tea.Msg {
+		return metadataRefreshedMsg(download.Fictions(ctx, client, fictionIDs, download.DefaultOptions()))
+	})
+}
+
+// leave cancels the menu's requests once another screen takes over; a
+// refresh left unfinished is retried the next time the menu opens.
+func (m *MenuModel) leave() {
+	m.requests.stop()
+	m.refresh.stop()
+	m.refreshing = false
+	m.refreshNotice = ""
+}
+
+func (m *MenuModel) handleMetadataRefreshed(msg metadataRefreshedMsg) (tea.Model, tea.Cmd) {
+	m.refreshing = false
+	refreshed, failed, updated := 0, 0, 0
+	for _, result := range msg {
+		if result.Err != nil {
+			failed++
+			continue
+		}
+		chapters := result.Fiction.Chapters
+		latest := ""
+		if len(chapters) > 0 {
+			latest = chapters[len(chapters)-1].Title
+		}
+		if m.config.RecordMetadata(strconv.Itoa(result.ID), len(chapters), result.Fiction.Status, latest) {
+			refreshed++
+			if m.config.GetEntry(strconv.Itoa(result.ID)).NewChapters() > 0 {
+				updated++
+			}
+		}
+	}
+	m.config.MarkRefreshed(time.Now())
+	_ = m.config.Save()
+	
+	m.refreshNotice = fmt.Sprintf("Refreshed %d books, %d with new chapters", refreshed, updated)
+	if failed > 0 {
+		m.refreshNotice += fmt.Sprintf(" (%d failed)", failed)
+	}
+	return m, nil
+}
+
+// historyShelfKeys maps the keys of the history's move prompt to shelves.
+var historyShelfKeys = map[string]string{
+	"r": config.ShelfReading,
+	"l": config.ShelfLater,
+	"p": config.ShelfPaused,
+	"f": config.ShelfFinished,
+	"d": config.ShelfDropped,
+}
+
+// handleActionConfirm starts the chosen book over from chapter 1 or deletes
+// it from the history once confirmed, or moves it to the chosen shelf.
+func (m *MenuModel) handleActionConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entry, action := m.actionEntry, m.historyAction
+	m.actionEntry, m.historyAction = nil, ""
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if action == "m" {
+		shelf, ok := historyShelfKeys[msg.String()]
+		if ok && shelf != entry.CurrentShelf() {
+			m.config.MoveToShelf(entry.FictionID, shelf, time.Now())
+			_ = m.config.Save()
+			m.clampHistoryPage()
+		}
+		return m, nil
+	}
+	switch msg.String() {
+	case "y", "enter":
+		if action == "d" {
+			m.config.RemoveEntry(entry.FictionID)
+			_ = m.config.Save()
+			m.clampHistoryPage()
+			return m, nil
+		}
+		if m.config.StartOver(entry.FictionID) {
+			_ = m.config.Save()
+		}
+		readerModel := NewReaderModel(entry.FictionID)
+		readerModel.SetStartChapter(0)
+		return readerModel, readerModel.Init()
+	}
+	return m, nil
+}
+
+// handleClearConfirm empties the reading history once confirmed.
+func (m *MenuModel) handleClearConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.confirmClear = false
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "y":
+		m.config.ClearHistory()
+		_ = m.config.Save()
+		m.historyPage = 1
+	}
+	return m, nil
+}
+
+// clampHistoryPage keeps the history page in range after entries are removed.
+func (m *MenuModel) clampHistoryPage() {
+	_, totalPages, _, _ := config.PageEntries(m.historyEntries(), m.historyPage, m.historyPageSize)
+	m.historyPage = max(min(m.historyPage, totalPages), 1)
+}
+
+func (m *MenuModel) handleCleanup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entry := m.cleanupEntries[m.cleanupIndex]
+	
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q":
+		// Ask again next time the cleanup is due
+		return m.finishCleanup()
+	case "p":
+		m.config.SetShelf(entry.FictionID, config.ShelfPaused)
+	case "d":
+		m.config.SetShelf(entry.FictionID, config.ShelfDropped)
+	case "k", "enter":
+		// Keep reading: leave it on the Reading shelf
+	default:
+		return m, nil
+	}
+	
+	m.cleanupIndex++
+	if m.cleanupIndex >= len(m.cleanupEntries) {
+		return m.finishCleanup()
+	}
+	return m, nil
+}
+
+func (m *MenuModel) finishCleanup() (tea.Model, tea.Cmd) {
+	m.config.MarkCleanupDone(time.Now())
+	_ = m.config.Save()
+	m.cleanupEntries = nil
+	m.state = MenuStateHistory
+	return m, nil
+}
+
+func (m *MenuModel) handleHistoryFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		// Abandon the filter entirely
+		m.filteringHistory = false
+		m.historyFilter.Blur()
+		m.historyFilter.SetValue("")
+		m.historyPage = 1
+		return m, nil
+	case "enter":
+		// Keep the filter applied but return keys to the history list
+		m.filteringHistory = false
+		m.historyFilter.Blur()
+		return m, nil
+	}
+	
+	var cmd tea.Cmd
+	m.historyFilter, cmd = m.historyFilter.Update(msg)
+	m.historyPage = 1
+	return m, cmd
+}
+
+// historyEntries returns the reading history narrowed by the current status
+// and text filters and sorted, pinned books first. Without a sort order a
+// text filter keeps its best matches first.
+func (m *MenuModel) historyEntries() []config.ReadingEntry {
+	entries := config.FilterByStatus(m.config.ReadingHistory, config.HistoryStatuses[m.historyStatus])
+	if query := strings.TrimSpace(m.historyFilter.Value()); query != "" {
+		entries = filterHistory(entries, query)
+	}
+	order := config.HistorySorts[m.historySort]
+	if order == config.SortShelf {
+		// Pinned books lead their shelf rather than the whole list
+		return config.SortEntries(entries, order)
+	}
+	return config.PinnedFirst(config.SortEntries(entries, order))
+}
+
 func (m *MenuModel) handleNewBookInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "ctrl+c", "q":
+	case "ctrl+c":
 		return m, tea.Quit
 	case "esc":
+		m.requests.stop()
+		m.checking = false
 		m.state = MenuStateMain
 		m.fictionInput.SetValue("")
+		m.clearInputFeedback()
+		m.refreshSuggestions()
+		return m, nil
+	case "up":
+		if len(m.suggestions) > 0 {
+			m.suggestionIndex--
+			if m.suggestionIndex < -1 {
+				m.suggestionIndex = len(m.suggestions) - 1
+			}
+		}
+		return m, nil
+	case "down":
+		if len(m.suggestions) > 0 {
+			m.suggestionIndex++
+			if m.suggestionIndex >= len(m.suggestions) {
+				m.suggestionIndex = -1
+			}
+		}
+		return m, nil
+	case "tab":
+		m.applySuggestion()
 		return m, nil
 	case "enter":
-		if m.fictionInput.Value() != "" {
-			m.state = MenuStateNewChapter
-			m.chapterInput.Focus()
-			m.fictionInput.Blur()
+		// A highlighted suggestion wins; typed words fall back to the best match
+		if m.suggestionIndex >= 0 || (!isDigits(m.fictionInput.Value()) && fictionIDOrURL(m.fictionInput.Value()) != nil) {
+			m.applySuggestion()
+		}
+		if model, ok := m.openPastedURL(); ok {
+			return model, model.Init()
+		}
+		if m.inputErr != "" {
 			return m, nil
 		}
+		
+		fictionID := strings.TrimSpace(m.fictionInput.Value())
+		if fictionID == "" {
+			m.inputErr = "Enter a fiction ID first"
+			return m, nil
+		}
+		if m.checking {
+			return m, nil
+		}
+		if offlineMode {
+			m.advanceToChapterInput()
+			return m, nil
+		}
+		m.checking = true
+		m.inputErr = ""
+		m.inputHint = "Checking fiction " + fictionID + "..."
+		return m, m.checkFiction(fictionID)
 	}
 	
+	before := m.fictionInput.Value()
 	var cmd tea.Cmd
 	m.fictionInput, cmd = m.fictionInput.Update(msg)
+	m.updateInputFeedback(msg, m.fictionInput, before)
+	if m.fictionInput.Value() != before {
+		m.refreshSuggestions()
+	}
+	
+	// A pasted chapter URL has everything needed to start reading
+	if m.inputHint != "" {
+		if model, ok := m.openPastedURL(); ok {
+			return model, model.Init()
+		}
+	}
 	return m, cmd
 }
 
+// openPastedURL handles a Royal Road URL in the fiction input. Chapter URLs
+// open the reader directly; fiction URLs are reduced to their ID so the normal
+// flow can continue. It returns ok only when a reader was created.
+func (m *MenuModel) openPastedURL() (*ReaderModel, bool) {
+	value := strings.TrimSpace(m.fictionInput.Value())
+	if value == "" || isDigits(value) {
+		return nil, false
+	}
+	
+	fictionID, chapterID, ok := royalroad.ParseURL(value)
+	if !ok {
+		m.inputErr = "That doesn't look like a Royal Road fiction or chapter URL"
+		return nil, false
+	}
+	
+	m.fictionInput.SetValue(strconv.Itoa(fictionID))
+	m.fictionInput.CursorEnd()
+	if chapterID == 0 {
+		m.inputHint = fmt.Sprintf("Found fiction %d in the URL", fictionID)
+		return nil, false
+	}
+	
+	m.rememberFictionID(strconv.Itoa(fictionID))
+	readerModel := NewReaderModel(strconv.Itoa(fictionID))
+	readerModel.SetStartChapterID(chapterID)
+	return readerModel, true
+}
+
+// validateFictionInput accepts IDs, URLs, and words that match a known title.
+func (m *MenuModel) validateFictionInput(value string) error {
+	err := fictionIDOrURL(value)
+	if err != nil && len(matchFictionSuggestions(collectFictionSuggestions(m.config), value)) > 0 {
+		return nil
+	}
+	return err
+}
+
+func (m *MenuModel) refreshSuggestions() {
+	m.suggestions = matchFictionSuggestions(collectFictionSuggestions(m.config), m.fictionInput.Value())
+	m.suggestionIndex = -1
+}
+
+// applySuggestion replaces the input with the highlighted suggestion's ID, or
+// the first suggestion's if none is highlighted.
+func (m *MenuModel) applySuggestion() {
+	if len(m.suggestions) == 0 {
+		return
+	}
+	index := max(m.suggestionIndex, 0)
+	m.fictionInput.SetValue(m.suggestions[index].FictionID)
+	m.fictionInput.CursorEnd()
+	m.suggestions = nil
+	m.suggestionIndex = -1
+	m.inputErr = ""
+}
+
+// rememberFictionID records a fiction ID entered by hand for later completion.
+func (m *MenuModel) rememberFictionID(fictionID string) {
+	m.config.AddRecentFictionID(fictionID)
+	_ = m.config.Save()
+}
+
 func (m *MenuModel) handleNewChapterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "ctrl+c", "q":
+	case "ctrl+c":
 		return m, tea.Quit
 	case "esc":
 		m.state = MenuStateNewBook
 		m.chapterInput.SetValue("")
 		m.chapterInput.Blur()
 		m.fictionInput.Focus()
+		m.clearInputFeedback()
 		return m, nil
 	case "enter":
 		fictionID := m.fictionInput.Value()
 		chapterStr := m.chapterInput.Value()
 		
-		chapterNum := 1 // Default to chapter 1
+		readerModel := NewReaderModel(fictionID)
+		
+		// Without a chapter the reader falls back to the newBookStart setting
 		if chapterStr != "" {
-			if num, err := strconv.Atoi(chapterStr); err == nil && num > 0 {
-				chapterNum = num
+			num, err := strconv.Atoi(chapterStr)
+			if err != nil || num < 1 {
+				m.inputErr = "Chapter numbers start at 1"
+				return m, nil
 			}
+			readerModel.SetStartChapter(num - 1) // Convert to 0-based index
 		}
-		
-		readerModel := NewReaderModel(fictionID)
-		readerModel.SetStartChapter(chapterNum - 1) // Convert to 0-based index
 		return readerModel, readerModel.Init()
 	}
 	
+	before := m.chapterInput.Value()
 	var cmd tea.Cmd
 	m.chapterInput, cmd = m.chapterInput.Update(msg)
+	m.updateInputFeedback(msg, m.chapterInput, before)
 	return m, cmd
 }
 
+// fictionCheckedMsg reports whether a typed fiction ID exists on the site.
+type fictionCheckedMsg struct {
+	fictionID string
+	exists    bool
+	err       error
+}
+
+func (m *MenuModel) checkFiction(fictionID string) tea.Cmd {
+	return m.requests.run(func(ctx context.Context) tea.Msg {
+		id, err := strconv.Atoi(fictionID)
+		if err != nil {
+			return fictionCheckedMsg{fictionID: fictionID, err: err}
+		}
+		exists, err := m.client.FictionExists(ctx, id)
+		return fictionCheckedMsg{fictionID: fictionID, exists: exists, err: err}
+	})
+}
+
+func (m *MenuModel) handleFictionChecked(msg fictionCheckedMsg) (tea.Model, tea.Cmd) {
+	m.checking = false
+	if m.state != MenuStateNewBook || msg.fictionID != strings.TrimSpace(m.fictionInput.Value()) {
+		// The user moved on while the check was in flight
+		return m, nil
+	}
+	
+	switch {
+	case msg.err != nil:
+		// Don't block reading on a flaky check; the reader reports real failures
+		m.advanceToChapterInput()
+		m.inputHint = fmt.Sprintf("Couldn't verify fiction %s (%s) — continuing anyway", msg.fictionID, describeFailure(msg.err, false).summary)
+	case !msg.exists:
+		m.inputHint = ""
+		m.inputErr = fmt.Sprintf("No fiction with ID %s exists on Royal Road", msg.fictionID)
+	default:
+		m.advanceToChapterInput()
+	}
+	return m, nil
+}
+
+func (m *MenuModel) advanceToChapterInput() {
+	m.rememberFictionID(strings.TrimSpace(m.fictionInput.Value()))
+	m.clearInputFeedback()
+	m.suggestions = nil
+	m.suggestionIndex = -1
+	m.state = MenuStateNewChapter
+	m.chapterInput.Focus()
+	m.fictionInput.Blur()
+}
+
+func (m *MenuModel) clearInputFeedback() {
+	m.inputErr = ""
+	m.inputHint = ""
+	m.checking = false
+}
+
+// updateInputFeedback surfaces validation errors and paste feedback after a
+// key has been applied to an input.
+func (m *MenuModel) updateInputFeedback(msg tea.KeyMsg, input textinput.Model, before string) {
+	m.inputHint = ""
+	if input.Err != nil {
+		m.inputErr = input.Err.Error()
+		return
+	}
+	m.inputErr = ""
+	
+	// Terminals deliver a paste as one key event carrying many runes
+	if len(msg.Runes) > 1 || msg.String() == "ctrl+v" {
+		if added := len([]rune(input.Value())) - len([]rune(before)); added > 0 {
+			m.inputHint = fmt.Sprintf("Pasted %d characters", added)
+		}
+	}
+}
+
+// digitsOnly rejects any input containing a non-digit.
+func digitsOnly(what string) textinput.ValidateFunc {
+	return func(value string) error {
+		if !isDigits(value) {
+			return fmt.Errorf("%s — only digits are allowed", what)
+		}
+		return nil
+	}
+}
+
+// fictionIDOrURL accepts a numeric ID or anything on its way to being a
+// Royal Road URL.
+func fictionIDOrURL(value string) error {
+	if isDigits(value) {
+		return nil
+	}
+	lower := strings.ToLower(value)
+	for _, prefix := range []string{"https://", "http://", "www.", "royalroad.com"} {
+		if strings.HasPrefix(lower, prefix) || strings.HasPrefix(prefix, lower) {
+			return nil
+		}
+	}
+	return fmt.Errorf("Enter a numeric fiction ID or paste a Royal Road URL")
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// inputFeedbackView renders the inline error/hint line and editing key help.
+func (m *MenuModel) inputFeedbackView() string {
+	var feedback strings.Builder
+	if m.inputErr != "" {
+		feedback.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("✗ " + m.inputErr))
+		feedback.WriteString("\n")
+	} else if m.inputHint != "" {
+		feedback.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(m.inputHint))
+		feedback.WriteString("\n")
+	}
+	feedback.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).
+		Render("ctrl+w delete word • ctrl+u clear to start • ctrl+k clear to end • ctrl+a/ctrl+e start/end"))
+	return feedback.String()
+}
+
 func (m *MenuModel) View() string {
 	switch m.state {
 	case MenuStateMain:
@@ -222,6 +898,8 @@ func (m *MenuModel) View() string {
 		return m.viewNewBookInput()
 	case MenuStateNewChapter:
 		return m.viewNewChapterInput()
+	case MenuStateCleanup:
+		return m.viewCleanup()
 	}
 	return ""
 }
@@ -234,31 +912,67 @@ func (m *MenuModel) viewMainMenu() string {
 		Render("📚 Royal Road CLI")
 	
 	var options strings.Builder
+	if len(m.config.ReadingHistory) > 0 {
+		options.WriteString(m.dashboardView() + "\n\n")
+	}
 	
-	// Continue option
-	if lastEntry := m.config.GetLastReadEntry(); lastEntry != nil {
-		continueStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("120")).
-			Bold(true)
-		
-		chapterProgress := fmt.Sprintf("(%d/%d", lastEntry.CurrentChapter+1, lastEntry.TotalChapters)
-		if lastEntry.ChapterProgress > 0 {
-			chapterProgress += fmt.Sprintf(", %.0f%% through chapter)", lastEntry.ChapterProgress*100)
+	// Continue options for the most recent books
+	recent := m.config.ContinueEntries()
+	if len(recent) > recentContinueCount {
+		recent = recent[:recentContinueCount]
+	}
+	continueStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("120")).
+		Bold(true)
+	for i, entry := range recent {
+		chapterProgress := fmt.Sprintf("(%d/%d", entry.CurrentChapter+1, entry.TotalChapters)
+		if entry.ChapterProgress > 0 {
+			chapterProgress += fmt.Sprintf(", %.0f%% through chapter)", entry.ChapterProgress*100)
 		} else {
 			chapterProgress += ")"
 		}
 		
-		options.WriteString(continueStyle.Render(fmt.Sprintf("  [c] Continue: %s %s\n", lastEntry.FictionTitle, chapterProgress)))
-		options.WriteString(fmt.Sprintf("      Chapter: %s\n\n", lastEntry.ChapterTitle))
+		key := "c"
+		if len(recent) > 1 {
+			key = fmt.Sprintf("c%d", i+1)
+		}
+		options.WriteString(continueStyle.Render(fmt.Sprintf("  [%s] Continue: %s %s", key, entry.FictionTitle, chapterProgress)))
+		options.WriteString(newChaptersBadge(entry) + "\n")
+		options.WriteString(fmt.Sprintf("       Chapter: %s\n", entry.ChapterTitle))
+	}
+	if len(recent) > 0 {
+		options.WriteString("\n")
+	}
+	if m.pendingContinue {
+		options.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).
+			Render(fmt.Sprintf("  Continue which book? [1-%d] ([c] for the most recent)", len(recent))))
+		options.WriteString("\n\n")
+	}
+	
+	if len(m.config.Queue) > 0 {
+		next := m.config.Queue[0]
+		options.WriteString(continueStyle.Render(fmt.Sprintf("  [u] Start next in queue: %s", next.Title)))
+		options.WriteString(fmt.Sprintf(" (%d queued)\n\n", len(m.config.Queue)))
 	}
 	
 	// Other options
+	options.WriteString("  [l] Library\n")
+	options.WriteString("  [U] Reading Queue\n")
 	options.WriteString("  [h] Reading History\n")
+	options.WriteString("  ['] Bookmarks\n")
 	options.WriteString("  [n] Start New Book\n") 
 	options.WriteString("  [b] Browse Popular Fictions\n")
 	options.WriteString("  [s] Search Fictions\n")
+	options.WriteString("  [g] Search Downloaded Library\n")
 	options.WriteString("  [q] Quit\n")
 	
+	if m.cacheNotice != "" {
+		options.WriteString("\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(m.cacheNotice) + "\n")
+	}
+	if m.message != "" {
+		options.WriteString("\n" + m.message + "\n")
+	}
+	
 	return fmt.Sprintf("%s\n\n%s", title, options.String())
 }
 
@@ -268,31 +982,89 @@ func (m *MenuModel) viewHistoryMenu() string {
 		Foreground(lipgloss.Color("170")).
 		Render("📖 Reading History")
 	
-	entries, totalPages, hasNext, hasPrev := m.config.GetReadingHistoryPage(m.historyPage, m.historyPageSize)
+	entries, totalPages, hasNext, hasPrev := config.PageEntries(m.historyEntries(), m.historyPage, m.historyPageSize)
+	
+	filterLine := ""
+	if m.filteringHistory || m.historyFilter.Value() != "" {
+		filterLine = m.historyFilter.View() + "\n\n"
+	}
+	
+	if m.historySort != 0 || m.historyStatus != 0 {
+		filterLine += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(
+			fmt.Sprintf("Sorted by %s • showing %s", config.HistorySorts[m.historySort], config.HistoryStatuses[m.historyStatus])) + "\n\n"
+	}
 	
 	if len(entries) == 0 {
+		if m.historyFilter.Value() != "" {
+			return fmt.Sprintf("%s\n\n%sNo entries match your filter.\n\nPress [esc] to clear the filter", title, filterLine)
+		}
+		if m.historyStatus != 0 {
+			return fmt.Sprintf("%s\n\n%sNo %s books.\n\nPress [f] to show more", title, filterLine, config.HistoryStatuses[m.historyStatus])
+		}
 		return fmt.Sprintf("%s\n\nNo reading history found.\n\nPress [esc] to go back", title)
 	}
 	
 	var content strings.Builder
 	content.WriteString(fmt.Sprintf("%s\n\n", title))
+	if m.config.CleanupDue(time.Now()) {
+		stale := len(m.config.StaleEntries(time.Now()))
+		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(
+			fmt.Sprintf("🧹 %d book(s) haven't been opened in %d+ months • [x] review them", stale, m.config.Reading.AbandonMonths)))
+		content.WriteString("\n\n")
+	}
+	content.WriteString(filterLine)
 	
+	grouped := config.HistorySorts[m.historySort] == config.SortShelf
+	headingStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("170"))
 	for i, entry := range entries {
 		num := i + 1
+		if grouped && (i == 0 || entries[i-1].CurrentShelf() != entry.CurrentShelf()) {
+			content.WriteString(headingStyle.Render(config.ShelfName(entry.CurrentShelf())) + "\n")
+		}
 		progress := fmt.Sprintf("(%d/%d", entry.CurrentChapter+1, entry.TotalChapters)
 		if entry.ChapterProgress > 0 {
 			progress += fmt.Sprintf(", %.0f%% through chapter)", entry.ChapterProgress*100)
 		} else {
 			progress += ")"
 		}
+		progress += newChaptersBadge(entry)
 		
 		entryStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("150"))
 		titleStyle := lipgloss.NewStyle().Bold(true)
 		
-		content.WriteString(fmt.Sprintf("  [%d] %s %s\n", num, titleStyle.Render(entry.FictionTitle), progress))
+		pin := ""
+		if entry.Pinned {
+			pin = "📌 "
+		}
+		if entry.Starred {
+			pin += "★ "
+		}
+		if m.selecting {
+			if m.selectedBooks[entry.FictionID] {
+				pin = lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Render("[✓] ") + pin
+			} else {
+				pin = "[ ] " + pin
+			}
+		}
+		content.WriteString(fmt.Sprintf("  [%d] %s%s %s%s%s\n", num, pin, titleStyle.Render(entry.FictionTitle), progress, shelfBadge(entry), rereadBadge(entry)))
 		content.WriteString(fmt.Sprintf("      %s • Chapter: %s\n", 
 			entryStyle.Render("by "+entry.Author), entry.ChapterTitle))
-		content.WriteString(fmt.Sprintf("      Last read: %s\n\n", entry.LastRead))
+		lastRead := entry.LastRead
+		if lastRead == "" {
+			lastRead = "not started"
+		}
+		details := "Last read: " + lastRead
+		if entry.NewChapters() > 0 && entry.LatestChapter != "" {
+			details += " • Latest: " + entry.LatestChapter
+		}
+		if entry.FictionStatus != "" {
+			details += " • " + strings.ToLower(entry.FictionStatus)
+		}
+		content.WriteString(fmt.Sprintf("      %s\n", details))
+		if entry.Notes != "" {
+			content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("      📝 "+notesPreview(entry.Notes, 70)) + "\n")
+		}
+		content.WriteString("\n")
 	}
 	
 	// Pagination info
@@ -312,19 +1084,166 @@ func (m *MenuModel) viewHistoryMenu() string {
 	}
 	
 	content.WriteString(fmt.Sprintf("%s\n", pageInfo))
-	content.WriteString("Press number to continue reading • [esc] back to main menu")
+	if m.refreshNotice != "" {
+		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(m.refreshNotice) + "\n")
+	}
+	promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	if m.selecting {
+		content.WriteString(m.selectionView())
+		return content.String()
+	}
+	if m.confirmClear {
+		content.WriteString(promptStyle.Render(fmt.Sprintf("Clear all %d books from your history? Bookmarks and stats are kept [y/n]", len(m.config.ReadingHistory))))
+		return content.String()
+	}
+	if entry := m.actionEntry; entry != nil {
+		var prompt string
+		switch m.historyAction {
+		case "m":
+			content.WriteString(promptStyle.Render(fmt.Sprintf("Move %s to: [r]eading, read [l]ater, [p]aused, [f]inished or [d]ropped?", entry.FictionTitle)))
+			return content.String()
+		case "d":
+			prompt = fmt.Sprintf("Delete %s from your history? Its progress is lost", entry.FictionTitle)
+		default:
+			prompt = fmt.Sprintf("Start %s over from chapter 1? Progress resets", entry.FictionTitle)
+			if entry.CurrentShelf() == config.ShelfFinished {
+				prompt += "; finishing it before is kept in your stats"
+			}
+		}
+		content.WriteString(promptStyle.Render(prompt + " [y/n]"))
+		return content.String()
+	}
+	if m.historyAction != "" {
+		question := "Start which book over?"
+		switch m.historyAction {
+		case "d":
+			question = "Delete which book?"
+		case "p":
+			question = "Pin or unpin which book?"
+		case "m":
+			question = "Move which book to another shelf?"
+		case "e":
+			question = "Edit the notes on which book?"
+		case "*":
+			question = "Star or unstar which book?"
+		}
+		content.WriteString(promptStyle.Render(fmt.Sprintf("%s [1-%d]", question, len(entries))))
+		return content.String()
+	}
+	content.WriteString("Press number to continue reading • [r] start one over • [d] delete • [p] pin • [m] move shelf • [e] notes • [R] refresh • [v] select several • [C] clear all • [/] filter • [s] sort • [*] star • [f] in progress/finished/starred • [x] review stale books • [esc] back to main menu")
+	
+	return content.String()
+}
+
+// shelfBadge marks history entries that are no longer on the Reading shelf.
+func shelfBadge(entry config.ReadingEntry) string {
+	switch entry.CurrentShelf() {
+	case config.ShelfFinished:
+		return " " + lipgloss.NewStyle().Foreground(lipgloss.Color("120")).Render("✓ finished")
+	case config.ShelfPaused:
+		return " " + lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("⏸ paused")
+	case config.ShelfDropped:
+		return " " + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("✗ dropped")
+	case config.ShelfLater:
+		return " " + lipgloss.NewStyle().Foreground(lipgloss.Color("111")).Render("🔖 read later")
+	}
+	return ""
+}
+
+// newChaptersBadge counts the chapters published since a book was last read,
+// as of the last metadata refresh.
+func newChaptersBadge(entry config.ReadingEntry) string {
+	newChapters := entry.NewChapters()
+	if newChapters == 0 {
+		return ""
+	}
+	return " " + lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true).Render(fmt.Sprintf("(+%d new)", newChapters))
+}
+
+// rereadBadge marks books being read again after finishing them.
+func rereadBadge(entry config.ReadingEntry) string {
+	if len(entry.Completions) == 0 {
+		return ""
+	}
+	finished := len(entry.Completions)
+	if entry.CurrentShelf() == config.ShelfFinished {
+		finished++
+	}
+	return " " + lipgloss.NewStyle().Foreground(lipgloss.Color("111")).Render(fmt.Sprintf("↻ finished %d×", finished))
+}
+
+func (m *MenuModel) viewCleanup() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("170")).
+		Render("🧹 Tidy Up Your Reading List")
+	
+	entry := m.cleanupEntries[m.cleanupIndex]
+	titleStyle := lipgloss.NewStyle().Bold(true)
 	
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("%s\n\n", title))
+	content.WriteString(fmt.Sprintf("Book %d of %d that you haven't opened in %d+ months:\n\n",
+		m.cleanupIndex+1, len(m.cleanupEntries), m.config.Reading.AbandonMonths))
+	content.WriteString(fmt.Sprintf("  %s by %s\n", titleStyle.Render(entry.FictionTitle), entry.Author))
+	content.WriteString(fmt.Sprintf("  Chapter %d/%d: %s\n", entry.CurrentChapter+1, entry.TotalChapters, entry.ChapterTitle))
+	content.WriteString(fmt.Sprintf("  Last read: %s\n\n", entry.LastRead))
+	content.WriteString("[p] move to Paused • [d] move to Dropped • [k] keep reading • [esc] ask me later")
 	return content.String()
 }
 
+// historySource adapts reading history entries for fuzzy matching on title and author.
+type historySource []config.ReadingEntry
+
+func (h historySource) String(i int) string {
+	return h[i].FictionTitle + " " + h[i].Author
+}
+
+func (h historySource) Len() int {
+	return len(h)
+}
+
+func filterHistory(entries []config.ReadingEntry, query string) []config.ReadingEntry {
+	matches := fuzzy.FindFrom(query, historySource(entries))
+	filtered := make([]config.ReadingEntry, 0, len(matches))
+	for _, match := range matches {
+		filtered = append(filtered, entries[match.Index])
+	}
+	return filtered
+}
+
 func (m *MenuModel) viewNewBookInput() string {
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("170")).
 		Render("📖 Start New Book")
 	
-	return fmt.Sprintf("%s\n\nEnter Fiction ID:\n%s\n\nPress [enter] to continue or [esc] to go back",
-		title, m.fictionInput.View())
+	return fmt.Sprintf("%s\n\nEnter Fiction ID:\n%s\n%s%s\n\nPress [enter] to continue or [esc] to go back",
+		title, m.fictionInput.View(), m.suggestionsView(), m.inputFeedbackView())
+}
+
+// suggestionsView lists completions for the fiction input, highlighting the
+// selected one.
+func (m *MenuModel) suggestionsView() string {
+	if len(m.suggestions) == 0 {
+		return ""
+	}
+	
+	normalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Bold(true)
+	
+	var list strings.Builder
+	for i, suggestion := range m.suggestions {
+		if i == m.suggestionIndex {
+			list.WriteString(selectedStyle.Render("▸ " + suggestion.label()))
+		} else {
+			list.WriteString(normalStyle.Render("  " + suggestion.label()))
+		}
+		list.WriteString("\n")
+	}
+	list.WriteString(normalStyle.Render("↑/↓ choose • tab complete"))
+	list.WriteString("\n")
+	return list.String()
 }
 
 func (m *MenuModel) viewNewChapterInput() string {
@@ -333,6 +1252,6 @@ func (m *MenuModel) viewNewChapterInput() string {
 		Foreground(lipgloss.Color("170")).
 		Render("📖 Start New Book")
 	
-	return fmt.Sprintf("%s\n\nFiction ID: %s\n\nStarting chapter (optional):\n%s\n\nPress [enter] to start reading or [esc] to go back",
-		title, m.fictionInput.Value(), m.chapterInput.View())
+	return fmt.Sprintf("%s\n\nFiction ID: %s\n\nStarting chapter (optional):\n%s\n%s\n\nPress [enter] to start reading or [esc] to go back",
+		title, m.fictionInput.Value(), m.chapterInput.View(), m.inputFeedbackView())
 }
\ No newline at end of file
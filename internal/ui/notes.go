@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// notesMode is how author's notes are shown in this chapter: the
+// authorNotes setting, or the other way round after N.
+func (m *ReaderModel) notesMode() string {
+	mode := config.NotesShow
+	if m.config != nil && m.config.Reading.AuthorNotes != "" {
+		mode = m.config.Reading.AuthorNotes
+	}
+	if !m.notesToggled {
+		return mode
+	}
+	if mode == config.NotesShow {
+		return config.NotesCollapse
+	}
+	return config.NotesShow
+}
+
+// authorNote renders a pre- or post-chapter note in full, as a one-line
+// summary or not at all.
+func (m *ReaderModel) authorNote(note string, width int) string {
+	if note == "" {
+		return ""
+	}
+	style := lipgloss.NewStyle().
+		Italic(true).
+		Foreground(lipgloss.Color("240")).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderLeft(true).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 0, 0, 1)
+
+	switch m.notesMode() {
+	case config.NotesHide:
+		return ""
+	case config.NotesCollapse:
+		label := fmt.Sprintf("Author's Note (%s) [N] expand: ", formatWordCount(len(strings.Fields(note))))
+		summary := strings.Join(strings.Fields(note), " ")
+		// less the border and padding
+		return style.Render(runewidth.Truncate(label+summary, max(width-2, 10), "…"))
+	}
+	return style.Width(width - 1).Render("Author's Note: " + note) // less the border
+}
+
+// toggleAuthorNotes expands or collapses this chapter's notes, keeping the
+// reader's place.
+func (m *ReaderModel) toggleAuthorNotes() {
+	if m.currentChapter == nil || (m.currentChapter.PreNote == "" && m.currentChapter.PostNote == "") {
+		m.setCommandMessage("This chapter has no author's notes", false)
+		return
+	}
+	position := m.anchor()
+	m.notesToggled = !m.notesToggled
+	m.updateContent()
+	m.seekAnchor(position)
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pagerCommand is set by the --pager flag and overrides Reading.Pager.
+var pagerCommand string
+
+// SetPagerCommand makes readers hand every chapter to an external pager such
+// as "less -R" or "bat --paging=always" instead of the built-in pager.
+func SetPagerCommand(command string) {
+	pagerCommand = command
+}
+
+// pagerClosedMsg is sent when the external pager exits.
+type pagerClosedMsg struct {
+	index int
+	err   error
+}
+
+// resolvePager picks the pager to run: the configured one, then $PAGER, then less.
+func resolvePager(configured string) string {
+	if strings.TrimSpace(configured) != "" {
+		return configured
+	}
+	if env := os.Getenv("PAGER"); env != "" {
+		return env
+	}
+	return "less -R"
+}
+
+// openInPager suspends the TUI and pipes text into the pager.
+func openInPager(pager string, text string, index int) tea.Cmd {
+	parts := strings.Fields(pager)
+	if len(parts) == 0 {
+		return func() tea.Msg {
+			return pagerClosedMsg{index: index, err: fmt.Errorf("no pager configured")}
+		}
+	}
+
+	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return pagerClosedMsg{index: index, err: err}
+	})
+}
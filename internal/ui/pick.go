@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PickItem is one fiction a PickModel offers to open.
+type PickItem struct {
+	FictionID string
+	Label     string
+	Detail    string
+}
+
+func (p PickItem) Title() string       { return p.Label }
+func (p PickItem) Description() string { return p.Detail }
+func (p PickItem) FilterValue() string { return p.Label }
+
+// PickModel asks which of several fictions to open, e.g. when a title given
+// on the command line matches more than one.
+type PickModel struct {
+	list list.Model
+}
+
+// NewPickModel lists items under title.
+func NewPickModel(title string, items []PickItem) *PickModel {
+	termWidth, termHeight := getTerminalSize()
+
+	listItems := make([]list.Item, len(items))
+	for i, item := range items {
+		listItems[i] = item
+	}
+
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(lipgloss.Color("170")).
+		BorderForeground(lipgloss.Color("170")).
+		Bold(true)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
+		Foreground(lipgloss.Color("240")).
+		BorderForeground(lipgloss.Color("170"))
+
+	l := list.New(listItems, delegate, termWidth, termHeight-2)
+	l.Title = title
+	l.SetShowStatusBar(false)
+	return &PickModel{list: l}
+}
+
+func (m *PickModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *PickModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		m.list.SetHeight(msg.Height - 2)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			return m, tea.Quit
+		case "enter":
+			if item, ok := m.list.SelectedItem().(PickItem); ok {
+				// Opens at the saved position, as the reader does by default
+				readerModel := NewReaderModel(item.FictionID)
+				return readerModel, readerModel.Init()
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m *PickModel) View() string {
+	return m.list.View()
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// queueStatusLifetime is how long "added to queue" notices stay in lists.
+const queueStatusLifetime = 3 * time.Second
+
+// queueKey is the list help entry for adding the selected fiction to the queue.
+var queueKey = key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add to queue"))
+
+// addToQueue queues a fiction for later in the screen's config and describes
+// the outcome.
+func addToQueue(cfg *config.Config, fictionID int, title, author string) string {
+	if cfg == nil {
+		return "Config is not available"
+	}
+	if !cfg.Enqueue(strconv.Itoa(fictionID), title, author) {
+		return fmt.Sprintf("%s is already queued", title)
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Sprintf("Error saving queue: %v", err)
+	}
+	return fmt.Sprintf("Queued %s (%d in queue)", title, len(cfg.Queue))
+}
+
+// startQueued opens a queued fiction in the reader, taking it off the queue.
+func startQueued(cfg *config.Config, entry config.QueueEntry) (tea.Model, tea.Cmd) {
+	cfg.Dequeue(entry.FictionID)
+	_ = cfg.Save()
+	// A queued book that was read before picks up where it was left, as the
+	// reader restores its saved position
+	readerModel := NewReaderModel(entry.FictionID)
+	return readerModel, readerModel.Init()
+}
+
+// QueueModel lists the reading queue for reordering and pruning.
+type QueueModel struct {
+	config   *config.Config
+	selected int
+	offset   int
+	height   int
+	previous tea.Model
+}
+
+// NewQueueModel shows the queue in cfg, loading it if nil.
+func NewQueueModel(cfg *config.Config, previous tea.Model) *QueueModel {
+	if cfg == nil {
+		cfg, _ = config.Load()
+	}
+	_, termHeight := getTerminalSize()
+	return &QueueModel{config: cfg, height: max(termHeight-6, 5), previous: previous}
+}
+
+func (m *QueueModel) ensureVisible() {
+	if m.selected < m.offset {
+		m.offset = m.selected
+	} else if m.selected >= m.offset+m.height {
+		m.offset = m.selected - m.height + 1
+	}
+}
+
+func (m *QueueModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *QueueModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.height = max(size.Height-6, 5)
+		m.ensureVisible()
+		return m, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	defer m.ensureVisible()
+	queue := m.config.Queue
+	switch keyMsg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "ctrl+r":
+		return returnToReading(m)
+	case "esc", "q":
+		if m.previous != nil {
+			return m.previous, nil
+		}
+		menuModel := NewMenuModel()
+		return menuModel, menuModel.Init()
+	case "up", "k":
+		m.selected = max(m.selected-1, 0)
+	case "down", "j":
+		m.selected = min(m.selected+1, max(len(queue)-1, 0))
+	case "K", "shift+up":
+		m.move(-1)
+	case "J", "shift+down":
+		m.move(1)
+	case "enter":
+		if m.selected < len(queue) {
+			return startQueued(m.config, queue[m.selected])
+		}
+	case "d", "x":
+		if m.selected < len(queue) {
+			m.config.Dequeue(queue[m.selected].FictionID)
+			_ = m.config.Save()
+			m.selected = min(m.selected, max(len(m.config.Queue)-1, 0))
+		}
+	}
+	return m, nil
+}
+
+// move shifts the selected fiction up or down the queue.
+func (m *QueueModel) move(step int) {
+	if moved := m.config.MoveInQueue(m.selected, step); moved != m.selected {
+		m.selected = moved
+		_ = m.config.Save()
+	}
+}
+
+func (m *QueueModel) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("170")).
+		Render("📋 Reading Queue")
+
+	var content strings.Builder
+	content.WriteString(title + "\n\n")
+	if len(m.config.Queue) == 0 {
+		content.WriteString("The queue is empty. Press [a] in browse or search results to add books.\n\n[esc] back")
+		return content.String()
+	}
+
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Bold(true)
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	end := min(m.offset+m.height, len(m.config.Queue))
+	for i := m.offset; i < end; i++ {
+		entry := m.config.Queue[i]
+		line := fmt.Sprintf("%d. %s", i+1, entry.Title)
+		if entry.Author != "" {
+			line += " by " + entry.Author
+		}
+		if i == m.selected {
+			content.WriteString(selectedStyle.Render("▶ " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("  " + dim.Render("queued "+dateOnly(entry.AddedAt)) + "\n")
+	}
+	if len(m.config.Queue) > m.height {
+		content.WriteString(dim.Render(fmt.Sprintf("\n(%d-%d of %d)", m.offset+1, end, len(m.config.Queue))) + "\n")
+	}
+	content.WriteString("\n↑/↓ select • [K/J] move up/down • [enter] start reading • [d] remove • [esc] back")
+	return content.String()
+}
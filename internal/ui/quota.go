@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// cacheWarnRatio is how full the cache may get before the UI warns about it.
+const cacheWarnRatio = 0.9
+
+// cacheWarning describes how close the cache is to cache.maxSizeMB, or
+// returns "" while it's comfortably below (or there is no limit).
+func cacheWarning(cfg *config.Config, store *cache.Store) string {
+	if cfg == nil || store == nil || cfg.Cache.Limit() == 0 {
+		return ""
+	}
+	size, err := store.TotalSize()
+	if err != nil || float64(size) < cacheWarnRatio*float64(cfg.Cache.Limit()) {
+		return ""
+	}
+	return fmt.Sprintf("⚠ offline cache %d%% of its %d MB limit; least recently read books will be evicted",
+		size*100/cfg.Cache.Limit(), cfg.Cache.MaxSizeMB)
+}
+
+// evictOverQuota applies the cache limit, never evicting the given fiction.
+func evictOverQuota(cfg *config.Config, store *cache.Store, keep int) {
+	if cfg == nil || store == nil {
+		return
+	}
+	_, _ = store.EvictLRU(cfg.Cache.Limit(), func(fictionID int) time.Time {
+		return cfg.LastReadTime(strconv.Itoa(fictionID))
+	}, keep)
+}
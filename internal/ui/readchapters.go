@@ -0,0 +1,54 @@
+package ui
+
+// seedReadChapters starts tracking the chapters read for a fiction last
+// read before that was tracked, counting every chapter before the one
+// reached as read.
+func (m *ReaderModel) seedReadChapters() {
+	if m.config == nil || m.fiction == nil {
+		return
+	}
+	entry := m.config.GetEntry(m.fictionID)
+	if entry == nil || entry.ReadChapters != nil {
+		return
+	}
+	read := []int{}
+	for i := 0; i < entry.CurrentChapter && i < len(m.fiction.Chapters); i++ {
+		read = append(read, m.fiction.Chapters[i].ID)
+	}
+	entry.ReadChapters = read
+	m.config.Save()
+}
+
+// markChapterRead records the current chapter as read once its end is reached.
+func (m *ReaderModel) markChapterRead() {
+	if m.config == nil || m.fiction == nil || m.chapterIndex >= len(m.fiction.Chapters) {
+		return
+	}
+	chapterID := m.fiction.Chapters[m.chapterIndex].ID
+	if entry := m.config.GetEntry(m.fictionID); entry == nil || entry.HasRead(chapterID) {
+		return
+	}
+	m.markChaptersRead([]int{chapterID}, true)
+}
+
+// markChaptersRead marks chapters read or unread, e.g. from the TOC.
+func (m *ReaderModel) markChaptersRead(chapterIDs []int, read bool) {
+	if m.config == nil {
+		return
+	}
+	if m.config.GetEntry(m.fictionID) == nil {
+		// Not in the history until a chapter has been opened
+		m.saveReadingProgress()
+	}
+	if !m.config.MarkChaptersRead(m.fictionID, chapterIDs, read) {
+		return
+	}
+	for _, id := range chapterIDs {
+		if read {
+			m.readChapters[id] = true
+		} else {
+			delete(m.readChapters, id)
+		}
+	}
+	m.config.Save()
+}
@@ -1,27 +1,38 @@
 package ui
 
 import (
+	"context"
 	"fmt"
-	"html"
-	"regexp"
+	"math"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
-	"royal-road-cli/internal/api"
-	"royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+	"github.com/jackowfish/royal-road-cli/internal/render"
+	"github.com/jackowfish/royal-road-cli/internal/termimage"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
 )
 
 type ReaderModel struct {
 	fictionID       string
-	client          *api.Client
-	fiction         *api.Fiction
-	currentChapter  *api.Chapter
+	client          *royalroad.Client
+	fiction         *royalroad.Fiction
+	currentChapter  *royalroad.Chapter
 	chapterIndex    int
 	startChapter    int
+	startChapterID  int // Chapter to open by ID, resolved once the fiction loads
+	startExplicit   bool // Start chapter was chosen by the caller rather than defaulted
+	startProgress   float64 // Position within the start chapter chosen by the caller (0.0-1.0)
+	inHistory       bool // Fiction has been read before
+	startPrompt     bool // Asking whether a new fiction should open at chapter 1 or the latest
 	loading         bool
 	err             error
 	showHelp        bool
@@ -30,6 +41,20 @@ type ReaderModel struct {
 	config          *config.Config
 	tocModel        *TOCModel
 	
+	// Offline support
+	store           *cache.Store   // nil if the cache directory is unavailable
+	offline         bool           // never touch the network
+	fromCache       bool           // current chapter was served from the cache
+	cachedChapters  map[int]bool   // chapter IDs available offline
+	cacheNotice     string         // set when the cache is close to its size limit
+	editedChapters  map[int]bool   // chapter IDs edited by the author since they were cached
+	chapterEdited   bool           // current chapter was edited since it was last read
+	chapterWordCounts map[int]int  // Word counts by chapter ID, for chapters read or cached
+	readChapters    map[int]bool   // Chapter IDs read, shared with the TOC
+	
+	// External pager; empty means the built-in pager is used
+	pager           string
+	
 	// Page-based navigation
 	content              []string  // All content lines
 	currentPage          int       // Current page number (0-based)
@@ -39,12 +64,87 @@ type ReaderModel struct {
 	termHeight           int       // Terminal height
 	goToLastPage         bool      // Flag to go to last page after loading
 	savedChapterProgress float64   // Saved progress percentage to restore
+	savedWordOffset      int       // Saved word offset to restore, preferred over the percentage
+	bodyStart            int       // First content line of the chapter text, after the author's note
+	checkpoints          []int     // Content lines of the 25/50/75% markers in long chapters
+	chapterWords         int       // Words in the current chapter
+	readTop              int       // Top line when words read were last counted; -1 to start over
+	scrollMode           bool           // Scroll line by line in viewport instead of flipping pages
+	viewport             viewport.Model // Scroll position in scroll mode
+	scrolledLines        int            // Lines scrolled since the last counted page turn
+	autoScroll           *autoScrollState // Set while turning pages on a timer
+	rsvp                 *rsvpState       // Set while speed reading a word at a time
+	focusMode            bool             // Dim all but the paragraph being read
+	notesToggled         bool             // Author's notes shown the other way from the setting in this chapter
+	chapterSearch        *chapterSearch   // Last search through every chapter, kept between openings
+	showChapterSearch    bool
+	commandLine          *textinput.Model // Set while typing a ':' command
+	commandMessage       string           // Outcome of the last command, until the next key
+	commandFailed        bool
+	bookmarkLabel        *textinput.Model // Set while naming a new bookmark
+	annotationDraft      *annotationDraft // Set while writing a highlight's note
+	highlights           map[int]bool     // Content lines inside highlights
+	translation          *translationState // Set while a translation is shown
+	tts                  *ttsState         // Set while reading aloud
+	focusLine            int              // Start of the paragraph last focused with j/k
+	viewportFocus        int              // Start of the focused paragraph as drawn in the viewport
+	jumps                jumplist         // Positions before each jump, for ctrl+o/ctrl+i
+	images               []render.Image     // Images in the current chapter
+	imageLines           []int              // Content line of each image's placeholder
+	imageProtocol        termimage.Protocol // How images are drawn; None opens them in the browser
+	
+	// Finish detection: asked once per reader when the last page of a completed fiction is reached
+	finishPrompt         bool
+	finishAsked          bool
+	
+	// Set by SetSampleMinutes when sampling a fiction's opening chapters
+	sample               *sampleState
+	
+	// Chapters downloading while reading; quitting waits for, cancels or
+	// detaches them
+	background           *backgroundDownload
+	requests             requestScope // The fiction or chapter being fetched
+	tasks                taskScope    // Translations, exports and image fetches
+	revalidate           bool         // Set by r so the reload asks the site rather than the HTTP cache
+	cacheOnly            bool         // Set by c on an error screen to read from the HTTP cache alone
+	quitPrompt           bool
+	quitAfter            string // quitAfterFinish, quitAfterCancel or quitAfterDetach once chosen
+	leaveTo              func() (tea.Model, tea.Cmd) // Screen the quit prompt leaves for; nil quits
+	sessionSeq           int    // Bumped by every sessionTick, so only the newest one re-arms
+	
+	// Resize debouncing
+	pendingSize          *tea.WindowSizeMsg // Latest size not yet laid out
+	resizeSeq            int                // Incremented on every resize event
+	tabPrefix            bool               // g was just pressed; t or T next switches tabs
+	tabPrefixTop         int                // Top line before that g jumped to the first page
+}
+
+type fictionLoadedMsg struct {
+	fiction   *royalroad.Fiction
+	fromCache bool
 }
 
-type fictionLoadedMsg *api.Fiction
 type chapterLoadedMsg struct {
-	chapter *api.Chapter
-	index   int
+	chapter   *royalroad.Chapter
+	index     int
+	fromCache bool
+}
+
+// resizeSettledMsg fires once the terminal size has stopped changing.
+type resizeSettledMsg struct {
+	seq int
+}
+
+// resizeDebounce is how long the size must stay put before re-wrapping.
+const resizeDebounce = 150 * time.Millisecond
+
+// offlineMode is set by the --offline flag and applies to every reader opened.
+var offlineMode bool
+
+// SetOfflineMode makes readers load fictions and chapters from the local
+// cache only, without touching the network.
+func SetOfflineMode(offline bool) {
+	offlineMode = offline
 }
 
 func NewReaderModel(fictionID string) *ReaderModel {
@@ -57,16 +157,32 @@ func NewReaderModel(fictionID string) *ReaderModel {
 	linesPerPage := max(termHeight-headerHeight-footerHeight, 10)
 
 	cfg, _ := config.Load()
+	store, _ := cache.Open()
+	
+	pager := pagerCommand
+	if pager == "" && cfg != nil {
+		pager = cfg.Reading.Pager
+	}
+	imageProtocol := termimage.Detect()
+	if cfg != nil {
+		imageProtocol = termimage.Resolve(cfg.Reading.ImageProtocol)
+	}
 
 	return &ReaderModel{
 		fictionID:     fictionID,
-		client:        api.NewClient(),
+		client:        network.NewClient(cfg),
 		loading:       true,
 		showHelp:      false,
 		showTOC:       false,
 		ready:         true,
 		startChapter:  0, // Default to first chapter
 		config:        cfg,
+		store:         store,
+		offline:       offlineMode,
+		pager:         pager,
+		imageProtocol: imageProtocol,
+		scrollMode:    cfg != nil && cfg.Reading.ScrollMode,
+		viewport:      viewport.New(termWidth, linesPerPage),
 		termWidth:     termWidth,
 		termHeight:    termHeight,
 		linesPerPage:  linesPerPage,
@@ -78,24 +194,49 @@ func NewReaderModel(fictionID string) *ReaderModel {
 
 func (m *ReaderModel) SetStartChapter(chapterIndex int) {
 	m.startChapter = chapterIndex
+	m.startExplicit = true
+}
+
+// SetStartProgress opens the start chapter at the page holding the given
+// fraction of its text, e.g. a search hit.
+func (m *ReaderModel) SetStartProgress(progress float64) {
+	m.startProgress = progress
+	m.savedChapterProgress = progress
+}
+
+// SetStartWordOffset opens the start chapter at the page holding the given
+// word of its text, which is found at any text width. It wins over
+// SetStartProgress once the chapter is laid out.
+func (m *ReaderModel) SetStartWordOffset(words int) {
+	m.savedWordOffset = words
+}
+
+// SetStartChapterID opens the chapter with the given Royal Road chapter ID,
+// e.g. one taken from a pasted chapter URL. It takes precedence over saved
+// progress and SetStartChapter.
+func (m *ReaderModel) SetStartChapterID(chapterID int) {
+	m.startChapterID = chapterID
+	m.startExplicit = true
 }
 
 func (m *ReaderModel) restoreReadingPosition() {
-	if m.config == nil {
+	if m.config == nil || m.startChapterID != 0 || m.sample != nil {
 		return
 	}
 
 	// Find the saved progress for this fiction
 	for _, entry := range m.config.ReadingHistory {
 		if entry.FictionID == m.fictionID {
-			// Only restore chapter if it wasn't explicitly set
-			if m.startChapter == 0 {
-				m.startChapter = entry.CurrentChapter
-			}
+			m.inHistory = true
 			
-			// Always restore page position if we're on the same chapter
-			if m.startChapter == entry.CurrentChapter && entry.ChapterProgress > 0 {
-				m.savedChapterProgress = entry.ChapterProgress
+			// Only restore the chapter, and the page within it, if the caller
+			// didn't choose one
+			if !m.startExplicit {
+				m.startChapter = entry.CurrentChapter
+				if entry.ChapterProgress > 0 && m.startProgress == 0 {
+					m.savedChapterProgress = entry.ChapterProgress
+					m.savedWordOffset = entry.ChapterWordOffset
+				}
 			}
 			break
 		}
@@ -105,29 +246,147 @@ func (m *ReaderModel) restoreReadingPosition() {
 func (m *ReaderModel) Init() tea.Cmd {
 	// Always try to restore reading position from history
 	m.restoreReadingPosition()
+	openTab(m)
+	if m.config != nil {
+		session.idleThreshold = time.Duration(m.config.Reading.IdleMinutes) * time.Minute
+	}
+	session.resume()
 	
 	return tea.Batch(
 		m.loadFiction(),
+		sessionTick(m),
 	)
 }
 
 func (m *ReaderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if tab := tabOwner(msg); tab != nil && tab != m {
+		// Another open book's timer or download
+		_, cmd := tab.Update(msg)
+		return m, cmd
+	}
+	defer m.followFocus()
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		headerHeight := 4
-		footerHeight := 1
-		
-		m.termWidth = msg.Width
-		m.termHeight = msg.Height
-		m.linesPerPage = max(msg.Height-headerHeight-footerHeight, 10)
-		m.ready = true
+		// Window managers can emit dozens of sizes per second while dragging;
+		// only re-wrap once the size has settled.
+		m.pendingSize = &msg
+		m.resizeSeq++
+		seq := m.resizeSeq
+		return m, tea.Tick(resizeDebounce, func(time.Time) tea.Msg {
+			return resizeSettledMsg{seq: seq}
+		})
 		
-		// Recalculate pages when window size changes
-		if m.currentChapter != nil {
-			m.updateContent()
+	case resizeSettledMsg:
+		if msg.seq != m.resizeSeq || m.pendingSize == nil {
+			return m, nil
 		}
+		m.applyWindowSize(*m.pendingSize)
+		m.pendingSize = nil
+		return m, nil
 
 	case tea.KeyMsg:
+		session.touch()
+		
+		if msg.String() == "ctrl+p" {
+			return m.leave(func() (tea.Model, tea.Cmd) {
+				m.saveReadingProgress()
+				return openQuickSwitcher(m)
+			})
+		}
+		
+		if m.startPrompt {
+			return m.handleStartPrompt(msg)
+		}
+		
+		if m.quitPrompt {
+			return m.handleQuitPrompt(msg)
+		}
+		if m.quitAfter != "" {
+			// Waiting for downloads to stop; only cancelling the wait is allowed
+			if m.quitAfter == quitAfterFinish && (msg.String() == "c" || msg.String() == "ctrl+c") {
+				m.quitAfter = quitAfterCancel
+				m.background.cancel()
+			}
+			return m, nil
+		}
+		
+		if m.sample != nil && m.sample.prompt {
+			if model, cmd, handled := m.handleSampleDecision(msg); handled {
+				return model, cmd
+			}
+		}
+		
+		if m.finishPrompt {
+			m.finishPrompt = false
+			if msg.String() == "y" {
+				m.markFinished()
+				return m, nil
+			}
+			if msg.String() == "n" || msg.String() == "esc" {
+				return m, nil
+			}
+			// Any other key dismisses the prompt and carries on as usual
+		}
+		
+		if m.err != nil {
+			if model, cmd, handled := m.handleFailureKey(msg.String()); handled {
+				return model, cmd
+			}
+		}
+		
+		m.commandMessage = ""
+		if m.commandLine != nil {
+			return m.handleCommandLineKey(msg)
+		}
+		if m.bookmarkLabel != nil {
+			return m, m.handleBookmarkPromptKey(msg)
+		}
+		if m.annotationDraft != nil {
+			return m, m.handleAnnotationDraftKey(msg)
+		}
+		if m.translation != nil && m.handleTranslationKey(msg.String()) {
+			return m, nil
+		}
+		if m.showChapterSearch {
+			if cmd, handled := m.handleChapterSearchKey(msg); handled {
+				return m, cmd
+			}
+		}
+		
+		// The keys from here on move through the chapter; count the words
+		// they go past once they have
+		defer m.countWordsRead()
+		if m.autoScroll != nil {
+			if cmd, handled := m.handleAutoScrollKey(msg.String()); handled {
+				return m, cmd
+			}
+		}
+		if m.rsvp != nil {
+			if cmd, handled := m.handleRSVPKey(msg.String()); handled {
+				return m, cmd
+			}
+		}
+		if m.tts != nil && !m.showHelp && !m.showTOC {
+			if cmd, handled := m.handleTTSKey(msg.String()); handled {
+				return m, cmd
+			}
+		}
+		
+		// gt and gT switch tabs; g on its own still goes to the first page
+		if !m.showTOC && !m.showHelp && len(readerTabs) > 1 {
+			if m.tabPrefix && (msg.String() == "t" || msg.String() == "T") {
+				m.tabPrefix = false
+				m.setTopLine(m.tabPrefixTop)
+				if msg.String() == "T" {
+					return m.switchTab(-1)
+				}
+				return m.switchTab(1)
+			}
+			m.tabPrefix = msg.String() == "g"
+			m.tabPrefixTop = m.topLine()
+		}
+		
 		// Handle TOC navigation first if TOC is visible
 		if m.showTOC && m.tocModel != nil {
 			if selectedChapter, shouldClose := m.tocModel.Update(msg); shouldClose {
@@ -135,6 +394,7 @@ func (m *ReaderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.tocModel.SetVisible(false)
 				if selectedChapter >= 0 {
 					// Jump to selected chapter
+					m.recordJump()
 					m.chapterIndex = selectedChapter
 					m.loading = true
 					return m, m.loadChapter(selectedChapter)
@@ -144,27 +404,39 @@ func (m *ReaderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		
+		if m.focusMode && !m.showHelp {
+			switch msg.String() {
+			case "down", "j":
+				return m, m.moveFocus(1)
+			case "up", "k":
+				return m, m.moveFocus(-1)
+			}
+		}
+		if m.scrollMode && !m.showHelp {
+			if cmd, handled := m.handleScrollKey(msg.String()); handled {
+				return m, cmd
+			}
+		}
+		
 		switch msg.String() {
 		case "ctrl+c", "q":
-			// Save progress before quitting
-			m.saveReadingProgress()
-			return m, tea.Quit
+			return m, m.quit()
+		case "D":
+			// Download the rest of the fiction while reading
+			return m, m.startBackgroundDownload()
 		case "m":
-			// Save progress before going back to menu
-			m.saveReadingProgress()
-			menuModel := NewMenuModel()
-			return menuModel, menuModel.Init()
+			return m.backToMenu()
 		case "?":
 			m.showHelp = !m.showHelp
 			return m, nil
 		case "t":
-			if m.fiction != nil && m.tocModel != nil {
-				m.showTOC = !m.showTOC
-				m.tocModel.SetVisible(m.showTOC)
-			}
+			m.toggleTOC()
 			return m, nil
 		case "n", "b":
 			// Next chapter
+			if m.sampleSpent() {
+				return m, nil
+			}
 			if m.fiction != nil && m.chapterIndex < len(m.fiction.Chapters)-1 {
 				m.chapterIndex++
 				m.loading = true
@@ -184,24 +456,80 @@ func (m *ReaderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Next page
 			if m.currentPage < m.totalPages-1 {
 				m.currentPage++
-			} else if m.fiction != nil && m.chapterIndex < len(m.fiction.Chapters)-1 {
-				// Auto-navigate to next chapter at end of current chapter
-				m.chapterIndex++
-				m.loading = true
-				return m, m.loadChapter(m.chapterIndex)
+				session.pageTurned()
+				return m, nil
 			}
-			return m, nil
+			return m, m.pastChapterEnd()
 		case "up", "k", "left", "h":
 			// Previous page
 			if m.currentPage > 0 {
 				m.currentPage--
-			} else if m.fiction != nil && m.chapterIndex > 0 {
-				// Go to previous chapter and show its last page
-				m.chapterIndex--
-				m.loading = true
-				m.goToLastPage = true
-				return m, m.loadChapter(m.chapterIndex)
+				return m, nil
+			}
+			return m, m.beforeChapterStart()
+
+		case "ctrl+o":
+			// Back to where the last jump came from
+			return m, m.jumpBack()
+		case "tab":
+			// ctrl+i: forward again after ctrl+o
+			return m, m.jumpForward()
+		case "B":
+			// Bookmark this page
+			return m, m.openBookmarkPrompt()
+		case "'":
+			return m.openBookmarks()
+		case "H":
+			// Highlight the page, or the focused paragraph, with a note
+			return m, m.startHighlight()
+		case "A":
+			return m.openAnnotations()
+		case "y":
+			// Copy the page, or the focused paragraph, with a citation
+			m.copySelection()
+			return m, nil
+		case "S":
+			// Read aloud, or stop
+			if m.tts != nil {
+				m.stopTTS()
+				return m, nil
 			}
+			return m, m.startTTS()
+		case "T":
+			// Translate the page, or the focused paragraph
+			return m, m.startTranslation()
+		case "c":
+			// Capture the page, or the focused paragraph, to the quotes file
+			m.captureQuote()
+			return m, nil
+		case ":":
+			// Run a command, e.g. :ch 120 or :75%
+			return m, m.openCommandLine()
+		case "/":
+			// Search every chapter of this fiction
+			return m, m.openChapterSearch()
+		case "F":
+			// Highlight the paragraph being read
+			m.toggleFocusMode()
+			return m, nil
+		case "w":
+			// Speed read a word at a time
+			return m, m.startRSVP()
+		case "a":
+			// Turn pages hands-free until the next key press
+			return m, m.toggleAutoScroll()
+		case "s":
+			// Switch between scrolling and page flips
+			m.toggleScrollMode()
+			return m, nil
+		case "]":
+			// Next 25% checkpoint in a long chapter
+			if m.jumpCheckpoint(1) {
+				session.pageTurned()
+			}
+			return m, nil
+		case "[":
+			m.jumpCheckpoint(-1)
 			return m, nil
 		case "g", "home":
 			// Go to first page
@@ -216,19 +544,89 @@ func (m *ReaderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "r":
 			m.loading = true
 			m.err = nil
+			m.revalidate = true
+			m.cacheOnly = false
 			return m, m.loadFiction()
+		case "e":
+			// Open the current chapter in an external pager
+			if m.currentChapter != nil {
+				return m, m.openChapterInPager()
+			}
+			return m, nil
+		case "+", "=":
+			m.adjustTextWidth(textWidthStep)
+			return m, nil
+		case "N":
+			m.toggleAuthorNotes()
+			return m, nil
+		case "M":
+			return m.openBookNotes()
+		case "-":
+			m.adjustTextWidth(-textWidthStep)
+			return m, nil
+		case "i":
+			// View the images on this page
+			return m, m.viewImages()
+		case "o":
+			// Open this page's images, or the chapter, in the browser
+			m.openInBrowser()
+			return m, nil
+		case "O":
+			// Continue with the next fiction in a reading order once this one is done
+			if next := m.nextInReadingOrder(); next != nil {
+				m.saveReadingProgress()
+				readerModel := NewReaderModel(next.FictionID)
+				return readerModel, readerModel.Init()
+			}
+			return m, nil
 		}
 
 	case fictionLoadedMsg:
 		m.loading = false
-		m.fiction = msg
+		m.fiction = msg.fiction
 		
 		// Initialize TOC model now that we have fiction data
-		m.tocModel = NewTOCModel(m.fiction, 0, m.termHeight)
+		m.tocModel = NewTOCModel(m.fiction, 0, m.linesPerPage)
+		m.tocModel.SetWidth(m.termWidth)
+		if m.store != nil {
+			m.cachedChapters = m.store.CachedChapters(m.fiction.ID)
+			m.tocModel.SetCachedChapters(m.cachedChapters, m.offline || msg.fromCache)
+			m.editedChapters = m.store.EditedChapters(m.fiction.ID)
+			m.tocModel.SetEditedChapters(m.editedChapters)
+			m.chapterWordCounts = m.store.ChapterWords(m.fiction.ID)
+			m.tocModel.SetWordCounts(m.chapterWordCounts)
+			m.cacheNotice = cacheWarning(m.config, m.store)
+		}
+		m.seedReadChapters()
+		m.readChapters = make(map[int]bool)
+		if m.config != nil {
+			if entry := m.config.GetEntry(m.fictionID); entry != nil {
+				m.readChapters = entry.ReadSet()
+			}
+		}
+		m.tocModel.SetReadChapters(m.readChapters, m.markChaptersRead)
 		
 		if len(m.fiction.Chapters) > 0 {
 			// Start from specified chapter or first chapter
 			startIndex := m.startChapter
+			for i, chapter := range m.fiction.Chapters {
+				if m.startChapterID != 0 && chapter.ID == m.startChapterID {
+					startIndex = i
+					break
+				}
+			}
+			m.startChapterID = 0
+			if !m.startExplicit && !m.inHistory && m.sample == nil && m.config != nil {
+				switch m.config.Reading.NewBookStart {
+				case config.StartLatest:
+					startIndex = len(m.fiction.Chapters) - 1
+				case config.StartAsk:
+					if len(m.fiction.Chapters) > 1 {
+						m.startPrompt = true
+						return m, nil
+					}
+				}
+			}
 			if startIndex >= len(m.fiction.Chapters) {
 				startIndex = len(m.fiction.Chapters) - 1
 			}
@@ -245,24 +643,45 @@ func (m *ReaderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		m.currentChapter = msg.chapter
 		m.chapterIndex = msg.index
+		m.fromCache = msg.fromCache
+		if m.cachedChapters != nil && m.store != nil && m.store.HasChapter(m.fiction.ID, m.fiction.Chapters[msg.index].ID) {
+			m.cachedChapters[m.fiction.Chapters[msg.index].ID] = true
+		}
+		if m.store != nil {
+			// Reading an edited chapter (or catching an edit while reading online) settles its flag
+			chapterID := m.fiction.Chapters[msg.index].ID
+			m.chapterEdited = m.store.EditedChapters(m.fiction.ID)[chapterID]
+			_ = m.store.ClearEdited(m.fiction.ID, chapterID)
+			delete(m.editedChapters, chapterID)
+		}
+		session.chapterOpened(m.fictionID)
+		m.countSampleChapter(msg.index)
 		
 		// Update TOC model with new current chapter
 		if m.tocModel != nil {
 			m.tocModel.SetCurrentChapter(msg.index)
 		}
 		
+		m.focusLine = -1
+		m.notesToggled = false
 		m.updateContent()
+		m.recordChapterWords()
 		
 		// Set page position
 		if m.goToLastPage {
 			// Go to last page
-			if m.totalPages > 0 {
-				m.currentPage = m.totalPages - 1
-			}
+			m.setTopLine(len(m.content))
 			m.goToLastPage = false
+		} else if m.savedWordOffset > 0 {
+			// Restore the exact spot, whatever the layout is now
+			m.setTopLine(m.lineAtWord(m.savedWordOffset))
+			m.savedWordOffset = 0
+			m.savedChapterProgress = 0
 		} else if m.savedChapterProgress > 0 {
 			// Restore from saved progress percentage
-			if m.totalPages > 0 {
+			if m.scrollMode {
+				m.setTopLine(int(float64(len(m.content)) * m.savedChapterProgress))
+			} else if m.totalPages > 0 {
 				targetPage := int(float64(m.totalPages) * m.savedChapterProgress)
 				if targetPage >= m.totalPages {
 					targetPage = m.totalPages - 1
@@ -272,19 +691,73 @@ func (m *ReaderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.savedChapterProgress = 0 // Clear after using
 		} else {
 			// Go to first page
-			m.currentPage = 0
+			m.setTopLine(0)
 		}
 		
 		// Save reading progress
 		m.saveReadingProgress()
 		
+		if m.pager != "" {
+			return m, m.openChapterInPager()
+		}
+		return m, tea.Batch(m.resumeRSVPAfterLoad(), m.resumeTTSAfterLoad())
+		
+	case chapterSearchMsg:
+		return m, m.handleChapterSearchResult(msg)
+
+	case commandDoneMsg:
+		m.handleCommandDone(msg)
+		return m, nil
+		
+	case ttsDoneMsg:
+		return m, m.handleTTSDone(msg)
+
+	case translationMsg:
+		m.handleTranslation(msg)
+		return m, nil
+
+	case rsvpTickMsg:
+		return m, m.handleRSVPTick(msg)
+		
+	case autoScrollTickMsg:
+		return m, m.handleAutoScrollTick(msg)
+		
+	case imageViewerClosedMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("image viewer failed: %w", msg.err)
+		}
+		return m, nil
+
+	case pagerClosedMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("pager failed: %w", msg.err)
+			return m, nil
+		}
+		// Paging through the whole chapter externally counts as reading it
+		if msg.index == m.chapterIndex && m.totalPages > 0 {
+			m.setTopLine(len(m.content))
+			m.saveReadingProgress()
+			if m.canOfferFinish() {
+				m.finishPrompt = true
+				m.finishAsked = true
+			}
+		}
 		return m, nil
 
+	case downloadProgressMsg:
+		return m.handleDownloadProgress(msg)
+
 	case errorMsg:
 		m.loading = false
 		m.err = msg
 		return m, nil
 		
+	case sessionTickMsg:
+		// Keep the stopwatch in the footer ticking while this reader is active
+		if msg.reader == m && msg.seq == m.sessionSeq {
+			return m, sessionTick(m)
+		}
+		return m, nil
 	}
 
 	return m, nil
@@ -304,8 +777,12 @@ func (m *ReaderModel) View() string {
 	if m.err != nil {
 		return lipgloss.NewStyle().
 			Padding(2).
-			Foreground(lipgloss.Color("196")).
-			Render(fmt.Sprintf("❌ Error: %v\n\nPress 'r' to retry, 'm' to go back to menu, or 'q' to quit.", m.err))
+			Width(m.termWidth).
+			Render(describeFailure(m.err, m.cacheOnly).render(m.err, readerFailureKeys, "'m' to go back to menu", "'q' to quit"))
+	}
+
+	if m.startPrompt {
+		return m.startPromptView()
 	}
 
 	header := m.headerView()
@@ -329,6 +806,20 @@ func (m *ReaderModel) headerView() string {
 			m.chapterIndex+1, 
 			len(m.fiction.Chapters),
 			m.fiction.Chapters[m.chapterIndex].Title)
+		if m.chapterWords > 0 {
+			chapterInfo += " (" + formatWordCount(m.chapterWords) + ")"
+		}
+		if left := m.bookTimeLeft(); left != "" {
+			chapterInfo += " • " + left
+		}
+		if m.offline {
+			chapterInfo += " • 📴 offline"
+		} else if m.fromCache {
+			chapterInfo += " • 📴 cached copy (network unavailable)"
+		}
+		if m.chapterEdited {
+			chapterInfo += " • ✎ edited since it was cached"
+		}
 	}
 
 	titleStyle := lipgloss.NewStyle().
@@ -342,8 +833,13 @@ func (m *ReaderModel) headerView() string {
 	chapterStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("150"))
 
+	titleLine := titleStyle.Render(title)
+	if tabs := m.tabBarView(); tabs != "" {
+		titleLine = tabs
+	}
+	
 	return fmt.Sprintf("%s\n%s\n%s", 
-		titleStyle.Render(title),
+		titleLine,
 		authorStyle.Render("by "+author),
 		chapterStyle.Render(chapterInfo))
 }
@@ -357,6 +853,18 @@ func (m *ReaderModel) contentView() string {
 		return m.tocModel.View()
 	}
 	
+	if m.showChapterSearch {
+		return m.chapterSearchView()
+	}
+	
+	if m.rsvp != nil {
+		return m.rsvpView()
+	}
+	
+	if m.translation != nil {
+		return m.translationView()
+	}
+	
 	return m.getCurrentPageContent()
 }
 
@@ -368,6 +876,10 @@ func (m *ReaderModel) getCurrentPageContent() string {
 		return fmt.Sprintf("No content available (content length: 0, chapter loaded: yes, savedProgress: %.3f)", m.savedChapterProgress)
 	}
 	
+	if m.scrollMode {
+		return m.viewport.View()
+	}
+	
 	start := m.currentPage * m.linesPerPage
 	end := start + m.linesPerPage
 	
@@ -381,16 +893,34 @@ func (m *ReaderModel) getCurrentPageContent() string {
 	}
 	
 	pageContent := make([]string, m.linesPerPage)
-	copy(pageContent, m.content[start:end])
-	
-	// Fill remaining lines with empty strings if needed
-	for i := end - start; i < m.linesPerPage; i++ {
-		pageContent[i] = ""
+	for i := start; i < end; i++ {
+		pageContent[i-start] = m.displayLine(i)
 	}
 	
 	return strings.Join(pageContent, "\n")
 }
 
+// displayLine is a content line as shown: dimmed in focus mode, centered in
+// the terminal and with any checkpoint marker in the margin.
+func (m *ReaderModel) displayLine(i int) string {
+	line := m.content[i]
+	if m.focusDimmed(i) {
+		line = focusDimStyle.Render(render.StripANSI(line))
+	}
+	if marker := m.checkpointMarker(i); marker != "" {
+		// Right-align the marker in the margin past the wrapped text
+		gap := m.textWidth() - lipgloss.Width(line)
+		line += strings.Repeat(" ", max(gap, 0)) + marker
+	}
+	if line == "" {
+		return ""
+	}
+	if margin := m.leftMargin(); m.highlights[i] && margin >= 2 {
+		return strings.Repeat(" ", margin-2) + highlightMarker + " " + line
+	}
+	return strings.Repeat(" ", m.leftMargin()) + line
+}
+
 func (m *ReaderModel) footerView() string {
 	info := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("240")).
@@ -405,9 +935,56 @@ func (m *ReaderModel) footerView() string {
 		return m.tocModel.FooterView()
 	}
 	
+	if m.quitPrompt || m.quitAfter != "" {
+		return info.Render(m.downloadFooter())
+	}
+	
+	if m.finishPrompt {
+		return info.Render("🎉 You've finished " + m.fiction.Title + "! Move it to your Finished shelf? [y/n]")
+	}
+	
+	if m.commandLine != nil {
+		return m.commandLine.View()
+	}
+	if m.bookmarkLabel != nil {
+		return m.bookmarkLabel.View()
+	}
+	if m.annotationDraft != nil {
+		return m.annotationDraft.input.View()
+	}
+	if m.commandMessage != "" {
+		if m.commandFailed {
+			return info.Foreground(lipgloss.Color("196")).Render("⚠ " + m.commandMessage)
+		}
+		return info.Render(m.commandMessage)
+	}
+	
+	if m.showChapterSearch {
+		return info.Render("[enter] search / open • ↑/↓ select • [esc] back to the chapter")
+	}
+	
+	if m.rsvp != nil {
+		return info.Render(m.rsvpFooter())
+	}
+	if m.tts != nil {
+		return info.Render(m.ttsFooter())
+	}
+	if m.translation != nil {
+		return info.Render(m.translationFooter())
+	}
+	
 	// Show page progress
 	if m.totalPages > 0 {
 		progress := fmt.Sprintf("Page %d/%d", m.currentPage+1, m.totalPages)
+		if m.scrollMode {
+			progress = fmt.Sprintf("Scrolling %d%%", int(m.viewport.ScrollPercent()*100))
+		}
+		if m.config == nil || m.config.Reading.ShowProgress {
+			progress += " • " + m.bookProgressView()
+		}
+		if left := m.chapterTimeLeft(); left != "" {
+			progress += " • " + left
+		}
 		
 		// Add navigation hints based on position
 		if m.currentPage == m.totalPages-1 {
@@ -416,6 +993,9 @@ func (m *ReaderModel) footerView() string {
 				progress += " • [→] next chapter"
 			} else {
 				progress += " • [end of book]"
+				if next := m.nextInReadingOrder(); next != nil {
+					progress += fmt.Sprintf(" • [O] next in order: %s", next.Title)
+				}
 			}
 		} else {
 			progress += " • [→] next page"
@@ -430,12 +1010,54 @@ func (m *ReaderModel) footerView() string {
 			progress += " • [←] prev page"
 		}
 		
+		if len(m.checkpoints) > 0 {
+			progress += " • [/] ◆ checkpoints"
+		}
+		
+		if len(m.pageImages()) > 0 {
+			progress += " • [i] view image"
+		}
+		
+		if m.sample != nil {
+			if m.sample.prompt {
+				return info.Render(m.sampleFooter())
+			}
+			progress += " • " + m.sampleFooter()
+		}
+		
+		if m.autoScroll != nil {
+			progress += " • " + m.autoScrollFooter()
+		}
+		if m.background != nil {
+			progress += " • " + m.downloadFooter()
+		}
+		if m.cacheNotice != "" {
+			progress += " • ⚠ cache nearly full"
+		}
+		
+		progress += " • ⏱ " + formatStopwatch(session.elapsed())
+		if session.idle() {
+			progress += " (idle)"
+		}
+		
 		return info.Render(progress)
 	}
 	
 	return info.Render("Press ? for help • t for TOC")
 }
 
+// bookProgressView shows how far through the whole fiction the reader is,
+// like "Book 37.4% ▓▓▓░░░░░".
+func (m *ReaderModel) bookProgressView() string {
+	const barWidth = 8
+	
+	fraction := 0.0
+	if m.fiction != nil && len(m.fiction.Chapters) > 0 {
+		fraction = (float64(m.chapterIndex) + m.currentPosition().progress) / float64(len(m.fiction.Chapters))
+	}
+	return fmt.Sprintf("Book %.1f%% %s", fraction*100, progressBar(fraction, barWidth))
+}
+
 func (m *ReaderModel) updateContent() {
 	if m.currentChapter == nil {
 		return
@@ -447,6 +1069,15 @@ func (m *ReaderModel) updateContent() {
 	// Split into lines for paging
 	m.content = strings.Split(formattedContent, "\n")
 	
+	m.chapterWords = render.WordCount(m.currentChapter.Content)
+	m.readTop = -1
+	m.computeCheckpoints()
+	m.computeHighlights()
+	m.findImages()
+	if m.scrollMode {
+		m.refreshViewport()
+	}
+	
 	// Calculate total pages
 	if len(m.content) == 0 {
 		m.totalPages = 1
@@ -460,6 +1091,87 @@ func (m *ReaderModel) updateContent() {
 	}
 }
 
+// applyWindowSize re-lays out the chapter for a new terminal size, keeping the
+// first visible word on screen.
+func (m *ReaderModel) applyWindowSize(size tea.WindowSizeMsg) {
+	headerHeight := 4
+	footerHeight := 1
+	
+	position := m.anchor()
+	
+	m.termWidth = size.Width
+	m.termHeight = size.Height
+	m.linesPerPage = max(size.Height-headerHeight-footerHeight, 10)
+	m.ready = true
+	if m.tocModel != nil {
+		m.tocModel.SetWidth(size.Width)
+		m.tocModel.SetHeight(m.linesPerPage)
+	}
+	
+	// Recalculate pages when window size changes
+	if m.currentChapter != nil {
+		m.updateContent()
+		if len(m.content) > 0 {
+			m.seekAnchor(position)
+		}
+	}
+}
+
+// quit saves progress and exits, first asking what to do with a running
+// background download.
+func (m *ReaderModel) quit() tea.Cmd {
+	if m.quitPrompt || m.quitAfter != "" {
+		return nil
+	}
+	if m.downloading() {
+		m.quitPrompt = true
+		m.leaveTo = nil
+		return nil
+	}
+	// Save progress before quitting
+	m.saveReadingProgress()
+	m.requests.stop()
+	m.stopTasks()
+	m.stopChapterSearch()
+	m.stopTTS()
+	return tea.Quit
+}
+
+// leave shows the screen that to returns, first asking, as quit does, what to
+// do with a running background download, which stops once the reader is left.
+func (m *ReaderModel) leave(to func() (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	if m.quitPrompt || m.quitAfter != "" {
+		return m, nil
+	}
+	if m.downloading() {
+		m.quitPrompt = true
+		m.leaveTo = to
+		return m, nil
+	}
+	return to()
+}
+
+// backToMenu saves progress and returns to the main menu.
+func (m *ReaderModel) backToMenu() (tea.Model, tea.Cmd) {
+	return m.leave(func() (tea.Model, tea.Cmd) {
+		m.saveReadingProgress()
+		m.requests.stop()
+		m.stopTasks()
+		m.stopChapterSearch()
+		m.stopTTS()
+		session.pause()
+		menuModel := NewMenuModel()
+		return menuModel, menuModel.Init()
+	})
+}
+
+func (m *ReaderModel) toggleTOC() {
+	if m.fiction != nil && m.tocModel != nil {
+		m.showTOC = !m.showTOC
+		m.tocModel.SetVisible(m.showTOC)
+	}
+}
+
 func (m *ReaderModel) helpContent() string {
 	help := `📖 Royal Road CLI Reader Help
 
@@ -474,9 +1186,44 @@ CHAPTER NAVIGATION:
   p              Previous chapter
   g / home       First page of chapter
   G / end        Last page of chapter
+  [ / ]          Previous/next 25% checkpoint (long chapters, marked ◆)
+  gt / gT        Next/previous open book (:tabnew <id> opens one)
   
 FEATURES:
-  t              Toggle table of contents (scrollable)
+  t              Toggle table of contents (scrollable; / filters by title,
+                 x marks a chapter read/unread, V a range, X all before it)
+  e              Open chapter in external pager ($PAGER or less -R)
+  B              Bookmark this page, with an optional label
+  '              Bookmarks (a switches to every fiction's)
+  H              Highlight the page (or focused paragraph) with a note
+  A              Highlights and notes
+  y              Copy the page (or focused paragraph) with a citation
+  c              Capture the page (or focused paragraph) to the quotes file
+  T              Translate the page (or focused paragraph); v for side by side
+  S              Read aloud from this page, following along; S again stops.
+                 While reading: space pause, ←/→ sentence, </> speed, V voice
+  N              Expand or collapse this chapter's author's notes
+  M              Your own notes on this book (esc saves)
+  ctrl+o / tab   Back/forward through jumps (TOC, search, command line)
+  :              Command line: a page (:45) or percentage (:75%), :ch 120,
+                 :toc, :search <phrase>, :bookmark add, :export epub, :q
+  /              Search every chapter of this fiction (cached chapters first,
+                 the rest are fetched); Enter opens a match at its page
+  F              Focus mode: dim all but the paragraph being read (j/k move
+                 a paragraph at a time)
+  w              RSVP speed reading: one word at a time in the middle of the
+                 screen (space pause, ←/→ back/skip, < / > speed, 1-3 words
+                 at once, esc back to the page)
+  a              Auto-scroll at your reading speed (< / > adjust, any other
+                 key pauses)
+  s              Toggle scroll mode (j/k scroll a line, space/h/l a screen,
+                 ctrl+d/ctrl+u half a screen)
+  + / -          Widen/narrow the text column (saved as textWidth)
+  i              View images on this page (kitty, iTerm2 or sixel terminals)
+  o              Open this page's images, or the chapter, in the browser
+  D              Download the rest of the fiction in the background
+  O              Open next fiction in reading order (at end of book)
+  ctrl+p         Quick switch to another book
   ?              Toggle this help
   m              Back to main menu
   r              Refresh current content
@@ -486,7 +1233,7 @@ TABLE OF CONTENTS:
   When TOC is open:
   • ↑/↓ or j/k to navigate chapters
   • Enter to jump to selected chapter
-  • Numbers 1-9 for quick chapter jumps
+  • Type a chapter number (e.g. 247) and press Enter to jump to it
   • t or Escape to close TOC
   
 READING:
@@ -506,147 +1253,125 @@ func (m *ReaderModel) formatChapterContent() string {
 
 	var content strings.Builder
 
-	if m.currentChapter.PreNote != "" {
-		authorNote := lipgloss.NewStyle().
-			Italic(true).
-			Foreground(lipgloss.Color("240")).
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderLeft(true).
-			BorderForeground(lipgloss.Color("240")).
-			Padding(0, 0, 0, 1)
-		
-		content.WriteString(authorNote.Render("Author's Note: "+m.currentChapter.PreNote))
+	// Wrap to the configured text width, or the terminal if narrower
+	textWidth := m.textWidth()
+
+	if note := m.authorNote(m.currentChapter.PreNote, textWidth); note != "" {
+		content.WriteString(note)
 		content.WriteString("\n\n")
 	}
+	m.bodyStart = strings.Count(content.String(), "\n")
 
-	chapterContent := m.cleanHTML(m.currentChapter.Content)
-	// Use terminal width minus padding for text wrapping
-	textWidth := max(m.termWidth-4, 40) // 4 = padding on both sides
-	chapterContent = m.wrapText(chapterContent, textWidth)
+	chapterContent := render.Styled(m.currentChapter.Content)
+	chapterContent = render.WrapLayout(chapterContent, textWidth, m.layout())
 	
 	content.WriteString(chapterContent)
 
-	if m.currentChapter.PostNote != "" {
+	if note := m.authorNote(m.currentChapter.PostNote, textWidth); note != "" {
 		content.WriteString("\n\n")
-		authorNote := lipgloss.NewStyle().
-			Italic(true).
-			Foreground(lipgloss.Color("240")).
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderLeft(true).
-			BorderForeground(lipgloss.Color("240")).
-			Padding(0, 0, 0, 1)
-		
-		content.WriteString(authorNote.Render("Author's Note: "+m.currentChapter.PostNote))
+		content.WriteString(note)
 	}
 
 	return content.String()
 }
 
-func (m *ReaderModel) cleanHTML(htmlContent string) string {
-	content := html.UnescapeString(htmlContent)
-	
-	tagRegex := regexp.MustCompile(`<[^>]*>`)
-	content = tagRegex.ReplaceAllString(content, "")
-	
-	content = regexp.MustCompile(`\s+`).ReplaceAllString(content, " ")
-	content = strings.TrimSpace(content)
-	
-	paragraphs := strings.Split(content, ". ")
-	if len(paragraphs) > 1 {
-		result := make([]string, 0, len(paragraphs))
-		for i, p := range paragraphs {
-			p = strings.TrimSpace(p)
-			if p != "" {
-				if i < len(paragraphs)-1 && !strings.HasSuffix(p, ".") {
-					p += "."
-				}
-				result = append(result, p)
-			}
-		}
-		content = strings.Join(result, "\n\n")
-	}
-	
-	return content
-}
-
-func (m *ReaderModel) wrapText(text string, width int) string {
-	if width <= 20 {
-		width = 40 // Minimum readable width
-	}
-	
-	paragraphs := strings.Split(text, "\n\n")
-	var wrappedParagraphs []string
-	
-	for _, paragraph := range paragraphs {
-		if strings.TrimSpace(paragraph) == "" {
-			continue
-		}
-		
-		words := strings.Fields(paragraph)
-		if len(words) == 0 {
-			continue
-		}
-		
-		var lines []string
-		currentLine := ""
-		
-		for _, word := range words {
-			if len(currentLine)+len(word)+1 <= width {
-				if currentLine == "" {
-					currentLine = word
-				} else {
-					currentLine += " " + word
-				}
-			} else {
-				if currentLine != "" {
-					lines = append(lines, currentLine)
-				}
-				currentLine = word
-			}
+func (m *ReaderModel) loadFiction() tea.Cmd {
+	revalidate, cacheOnly := m.revalidate, m.cacheOnly
+	return m.requests.run(func(ctx context.Context) tea.Msg {
+		if revalidate {
+			ctx = royalroad.Revalidate(ctx)
 		}
-		
-		if currentLine != "" {
-			lines = append(lines, currentLine)
+		if cacheOnly {
+			ctx = royalroad.CacheOnly(ctx)
 		}
-		
-		wrappedParagraphs = append(wrappedParagraphs, strings.Join(lines, "\n"))
-	}
-	
-	return strings.Join(wrappedParagraphs, "\n\n")
-}
-
-func (m *ReaderModel) loadFiction() tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
 		fictionID, err := strconv.Atoi(m.fictionID)
 		if err != nil {
 			return errorMsg(fmt.Errorf("invalid fiction ID: %s", m.fictionID))
 		}
 		
-		fiction, err := m.client.GetFiction(fictionID)
+		fiction, fromCache, err := m.fetchFiction(ctx, fictionID)
 		if err != nil {
 			return errorMsg(err)
 		}
 		
-		return fictionLoadedMsg(fiction)
+		return fictionLoadedMsg{fiction: fiction, fromCache: fromCache}
 	})
 }
 
 func (m *ReaderModel) loadChapter(index int) tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
+	revalidate, cacheOnly := m.revalidate, m.cacheOnly
+	m.revalidate = false
+	return m.requests.run(func(ctx context.Context) tea.Msg {
+		if revalidate {
+			ctx = royalroad.Revalidate(ctx)
+		}
+		if cacheOnly {
+			ctx = royalroad.CacheOnly(ctx)
+		}
 		if m.fiction == nil || index < 0 || index >= len(m.fiction.Chapters) {
 			return errorMsg(fmt.Errorf("invalid chapter index"))
 		}
 		
-		chapterID := m.fiction.Chapters[index].ID
-		chapter, err := m.client.GetChapter(chapterID)
+		chapter, fromCache, err := m.fetchChapter(ctx, m.fiction.Chapters[index].ID)
 		if err != nil {
 			return errorMsg(err)
 		}
 		
-		return chapterLoadedMsg{chapter: chapter, index: index}
+		return chapterLoadedMsg{chapter: chapter, index: index, fromCache: fromCache}
 	})
 }
 
+// fetchFiction loads the fiction from the site, falling back to the cache when
+// offline or when the request fails.
+func (m *ReaderModel) fetchFiction(ctx context.Context, fictionID int) (*royalroad.Fiction, bool, error) {
+	if !m.offline {
+		fiction, err := m.client.GetFiction(ctx, fictionID)
+		if err == nil {
+			// The cached chapter list is left alone: it records what was last
+			// downloaded, which 'download --update' compares against
+			return fiction, false, nil
+		}
+		if m.store == nil || !m.store.HasFiction(fictionID) {
+			return nil, false, err
+		}
+	}
+	
+	if m.store == nil {
+		return nil, true, fmt.Errorf("offline cache is unavailable")
+	}
+	fiction, err := m.store.LoadFiction(fictionID)
+	if err != nil {
+		return nil, true, fmt.Errorf("fiction %d is not available offline; download it first with 'royal-road-cli download %d'", fictionID, fictionID)
+	}
+	return fiction, true, nil
+}
+
+// fetchChapter loads a chapter from the site, falling back to the cache when
+// offline or when the request fails. Chapters of downloaded fictions that are
+// read online are added to the cache as they are read.
+func (m *ReaderModel) fetchChapter(ctx context.Context, chapterID int) (*royalroad.Chapter, bool, error) {
+	if !m.offline {
+		chapter, err := m.client.GetChapter(ctx, chapterID)
+		if err == nil {
+			if m.store != nil && m.store.HasFiction(m.fiction.ID) {
+				_ = m.store.SaveChapter(m.fiction.ID, chapterID, chapter)
+			}
+			return chapter, false, nil
+		}
+		if m.store == nil || !m.store.HasChapter(m.fiction.ID, chapterID) {
+			return nil, false, err
+		}
+	}
+	
+	if m.store == nil {
+		return nil, true, fmt.Errorf("offline cache is unavailable")
+	}
+	chapter, err := m.store.LoadChapter(m.fiction.ID, chapterID)
+	if err != nil {
+		return nil, true, fmt.Errorf("this chapter is not available offline; download it with 'royal-road-cli download %d'", m.fiction.ID)
+	}
+	return chapter, true, nil
+}
 
 func (m *ReaderModel) saveReadingProgress() {
 	if m.fiction == nil || m.config == nil {
@@ -660,7 +1385,10 @@ func (m *ReaderModel) saveReadingProgress() {
 
 	// Calculate progress through current chapter as a percentage
 	var chapterProgress float64
-	if m.totalPages > 0 {
+	if m.scrollMode && len(m.content) > 0 {
+		// The scroll offset, as a fraction so it survives re-wrapping
+		chapterProgress = math.Min(float64(m.viewport.YOffset)/float64(len(m.content)), 1.0)
+	} else if m.totalPages > 0 {
 		chapterProgress = float64(m.currentPage) / float64(m.totalPages)
 		// Ensure we don't go over 1.0
 		if chapterProgress > 1.0 {
@@ -675,10 +1403,147 @@ func (m *ReaderModel) saveReadingProgress() {
 		CurrentChapter:  m.chapterIndex,
 		ChapterTitle:    chapterTitle,
 		ChapterProgress: chapterProgress,
-		LastRead:        time.Now().Format("2006-01-02 15:04"),
+		ChapterWordOffset: m.wordOffset(m.topLine()),
+		LastRead:        time.Now().Format(config.TimeLayout),
 		TotalChapters:   len(m.fiction.Chapters),
+		LiveChapters:    len(m.fiction.Chapters),
+		FictionStatus:   m.fiction.Status,
+	}
+	if len(m.fiction.Chapters) > 0 {
+		entry.LatestChapter = m.fiction.Chapters[len(m.fiction.Chapters)-1].Title
 	}
 
 	m.config.UpdateReadingProgress(entry)
 	m.config.Save() // Save to disk
-}
\ No newline at end of file
+}
+
+// openChapterInPager renders the whole chapter and hands it to the pager.
+func (m *ReaderModel) openChapterInPager() tea.Cmd {
+	chapterTitle := ""
+	if m.fiction != nil && m.chapterIndex < len(m.fiction.Chapters) {
+		chapterTitle = m.fiction.Chapters[m.chapterIndex].Title
+	}
+	
+	text := fmt.Sprintf("%s\nby %s\n\nChapter %d/%d: %s\n\n%s\n",
+		m.fiction.Title, m.fiction.Author.Name,
+		m.chapterIndex+1, len(m.fiction.Chapters), chapterTitle,
+		strings.Join(m.content, "\n"))
+	
+	return openInPager(resolvePager(m.pager), text, m.chapterIndex)
+}
+
+// handleStartPrompt resolves the "ask" NewBookStart setting.
+func (m *ReaderModel) handleStartPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	index := -1
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "1", "f", "enter":
+		index = 0
+	case "l":
+		index = len(m.fiction.Chapters) - 1
+	case "u":
+		// Save it for later instead of starting now
+		fictionID, _ := strconv.Atoi(m.fictionID)
+		message := addToQueue(m.config, fictionID, m.fiction.Title, m.fiction.Author.Name)
+		menuModel := NewMenuModel()
+		menuModel.message = message
+		return menuModel, menuModel.Init()
+	case "m", "esc":
+		menuModel := NewMenuModel()
+		return menuModel, menuModel.Init()
+	}
+	if index < 0 {
+		return m, nil
+	}
+	m.startPrompt = false
+	m.loading = true
+	return m, m.loadChapter(index)
+}
+
+func (m *ReaderModel) startPromptView() string {
+	latest := m.fiction.Chapters[len(m.fiction.Chapters)-1]
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("170")).Render(m.fiction.Title)
+	author := lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("240")).Render("by " + m.fiction.Author.Name)
+	
+	length := ""
+	if m.fiction.Stats.Pages > 0 {
+		wpm := m.wordsPerMinute()
+		minutes := float64(m.fiction.Stats.Pages*royalRoadPageWords) / float64(wpm)
+		length = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(
+			fmt.Sprintf("%d chapters • %s to read at %d wpm", len(m.fiction.Chapters), formatReadingTime(minutes), wpm)) + "\n"
+	}
+	
+	return lipgloss.NewStyle().Padding(2).Render(fmt.Sprintf(
+		"%s\n%s\n%s\nWhere would you like to start?\n\n  [1] Chapter 1: %s\n  [l] Latest, chapter %d: %s\n  [u] Add to the reading queue for later\n\n[esc] back to menu",
+		title, author, length, m.fiction.Chapters[0].Title, len(m.fiction.Chapters), latest.Title))
+}
+
+// pastChapterEnd moves on from the end of the chapter: to the sample prompt,
+// the next chapter or, at the end of a completed book, the finish prompt.
+func (m *ReaderModel) pastChapterEnd() tea.Cmd {
+	m.finishCountingWords()
+	m.markChapterRead()
+	if m.sampleFinished() {
+		m.sample.prompt = true
+	} else if m.fiction != nil && m.chapterIndex < len(m.fiction.Chapters)-1 {
+		// Auto-navigate to next chapter at end of current chapter
+		m.chapterIndex++
+		m.loading = true
+		return m.loadChapter(m.chapterIndex)
+	} else if m.canOfferFinish() {
+		m.finishPrompt = true
+		m.finishAsked = true
+	}
+	return nil
+}
+
+// beforeChapterStart goes back from the start of the chapter to the end of
+// the previous one.
+func (m *ReaderModel) beforeChapterStart() tea.Cmd {
+	if m.fiction == nil || m.chapterIndex == 0 {
+		return nil
+	}
+	m.chapterIndex--
+	m.loading = true
+	m.goToLastPage = true
+	return m.loadChapter(m.chapterIndex)
+}
+
+// canOfferFinish reports whether the reader is on the last page of a completed
+// fiction that hasn't been shelved as finished yet.
+func (m *ReaderModel) canOfferFinish() bool {
+	if m.fiction == nil || m.config == nil || m.finishAsked {
+		return false
+	}
+	if !strings.EqualFold(m.fiction.Status, "completed") {
+		return false
+	}
+	if m.chapterIndex < len(m.fiction.Chapters)-1 || m.currentPage < m.totalPages-1 {
+		return false
+	}
+	entry := m.config.GetEntry(m.fictionID)
+	return entry != nil && entry.CurrentShelf() != config.ShelfFinished
+}
+
+// markFinished moves the fiction to the Finished shelf so it stops being
+// offered for continuing.
+func (m *ReaderModel) markFinished() {
+	m.saveReadingProgress()
+	if m.config.MarkFinished(m.fictionID, time.Now()) {
+		m.config.Save()
+	}
+}
+
+// nextInReadingOrder returns the fiction that follows this one in a saved
+// reading order, but only once the reader is on the last page of the book.
+func (m *ReaderModel) nextInReadingOrder() *config.ReadingOrderItem {
+	if m.config == nil || m.fiction == nil {
+		return nil
+	}
+	if m.chapterIndex < len(m.fiction.Chapters)-1 || m.currentPage < m.totalPages-1 {
+		return nil
+	}
+	_, next := m.config.NextInReadingOrder(m.fictionID)
+	return next
+}
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackowfish/royal-road-cli/internal/render"
+)
+
+// Reading time estimates come from word counts and either the configured
+// speed or, with wordsPerMinute set to 0, the speed measured while reading.
+const (
+	defaultWordsPerMinute = 250
+	measureMinTime        = 10 * time.Minute // Reading needed before the measured speed is trusted
+)
+
+// wordsPerMinute is the reading speed used for estimates.
+func (m *ReaderModel) wordsPerMinute() int {
+	if m.config != nil && m.config.Reading.WordsPerMinute > 0 {
+		return m.config.Reading.WordsPerMinute
+	}
+	words, elapsed := session.words, session.elapsed()
+	if m.config != nil {
+		pastWords, pastTime := m.config.ReadingSpeed()
+		words, elapsed = words+pastWords, elapsed+pastTime
+	}
+	if elapsed < measureMinTime || words == 0 {
+		return defaultWordsPerMinute
+	}
+	return int(float64(words) / elapsed.Minutes())
+}
+
+// countWordsRead credits the session with the words on the lines read since
+// it was last called. Big jumps (search hits, checkpoints, the TOC) aren't
+// reading, so only moves of up to a couple of pages count.
+func (m *ReaderModel) countWordsRead() {
+	if len(m.content) == 0 {
+		return
+	}
+	top := m.topLine()
+	if m.readTop == len(m.content) && top >= len(m.content)-m.linesPerPage {
+		// Still on the last page of a chapter already counted
+		return
+	}
+	if m.readTop >= 0 && top > m.readTop && top-m.readTop <= 2*m.linesPerPage {
+		session.words += countWords(m.content[m.readTop:top])
+	}
+	m.readTop = top
+}
+
+// finishCountingWords credits the rest of the chapter on reaching its end.
+func (m *ReaderModel) finishCountingWords() {
+	m.countWordsRead()
+	if m.readTop >= 0 && len(m.content)-m.readTop <= 2*m.linesPerPage {
+		session.words += countWords(m.content[m.readTop:])
+	}
+	m.readTop = len(m.content)
+}
+
+func countWords(lines []string) int {
+	words := 0
+	for _, line := range lines {
+		words += len(strings.Fields(render.StripANSI(line)))
+	}
+	return words
+}
+
+// chapterTimeLeft estimates the time left in the chapter, like
+// "~14 min left in chapter".
+func (m *ReaderModel) chapterTimeLeft() string {
+	if m.chapterWords == 0 || len(m.content) == 0 {
+		return ""
+	}
+	remaining := 1 - float64(m.topLine())/float64(len(m.content))
+	minutes := float64(m.chapterWords) * remaining / float64(m.wordsPerMinute())
+	return formatReadingTime(minutes) + " left in chapter"
+}
+
+// formatReadingTime renders an estimate like "~14 min" or "~3 h 20 min".
+func formatReadingTime(minutes float64) string {
+	total := int(minutes + 0.5)
+	switch {
+	case total < 1:
+		return "<1 min"
+	case total < 60:
+		return fmt.Sprintf("~%d min", total)
+	case total%60 == 0 || total >= 100*60:
+		return fmt.Sprintf("~%d h", (total+30)/60)
+	}
+	return fmt.Sprintf("~%d h %d min", total/60, total%60)
+}
+
+// royalRoadPageWords is the number of words Royal Road counts as a page in a
+// fiction's stats.
+const royalRoadPageWords = 275
+
+// averageChapterWords estimates a chapter's length from the fiction page's
+// page count, falling back to the chapters read or cached so far.
+func (m *ReaderModel) averageChapterWords() int {
+	if m.fiction == nil || len(m.fiction.Chapters) == 0 {
+		return 0
+	}
+	if m.fiction.Stats.Pages > 0 {
+		return m.fiction.Stats.Pages * royalRoadPageWords / len(m.fiction.Chapters)
+	}
+	if len(m.chapterWordCounts) == 0 {
+		return m.chapterWords
+	}
+	total := 0
+	for _, words := range m.chapterWordCounts {
+		total += words
+	}
+	return total / len(m.chapterWordCounts)
+}
+
+// bookTimeLeft estimates the time to finish the fiction: the rest of this
+// chapter plus the remaining chapters at their average length.
+func (m *ReaderModel) bookTimeLeft() string {
+	average := m.averageChapterWords()
+	if average == 0 || len(m.content) == 0 {
+		return ""
+	}
+	remaining := 1 - float64(m.topLine())/float64(len(m.content))
+	words := float64(m.chapterWords)*remaining + float64(average*(len(m.fiction.Chapters)-m.chapterIndex-1))
+	return formatReadingTime(words/float64(m.wordsPerMinute())) + " left in book"
+}
+
+// recordChapterWords notes the length of the chapter just opened for the TOC.
+func (m *ReaderModel) recordChapterWords() {
+	if m.fiction == nil || m.chapterIndex >= len(m.fiction.Chapters) || m.chapterWords == 0 {
+		return
+	}
+	if m.chapterWordCounts == nil {
+		m.chapterWordCounts = make(map[int]int)
+		if m.tocModel != nil {
+			m.tocModel.SetWordCounts(m.chapterWordCounts)
+		}
+	}
+	m.chapterWordCounts[m.fiction.Chapters[m.chapterIndex].ID] = m.chapterWords
+}
+
+// formatWordCount renders a length like "850 words" or "12.3k words".
+func formatWordCount(words int) string {
+	if words < 1000 {
+		return fmt.Sprintf("%d words", words)
+	}
+	return fmt.Sprintf("%.1fk words", float64(words)/1000)
+}
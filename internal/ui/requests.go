@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// requestScope owns a screen's in-flight network request, so leaving the
+// screen, or starting a newer request, cancels it instead of letting it run
+// to completion for nobody.
+type requestScope struct {
+	cancel context.CancelFunc
+}
+
+// run returns a command that calls fetch with a fresh context, cancelling the
+// scope's previous request. A cancelled request's message is dropped, so a
+// stale result or "context canceled" error never reaches the screen.
+func (r *requestScope) run(fetch func(ctx context.Context) tea.Msg) tea.Cmd {
+	r.stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	return func() tea.Msg {
+		msg := fetch(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		return msg
+	}
+}
+
+// stop cancels the request in flight, if any.
+func (r *requestScope) stop() {
+	if r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+	}
+}
+
+// taskScope owns the work a screen runs alongside its main request, such as
+// a translation, an export or image fetches. Starting one task doesn't cancel
+// the others; leaving the screen cancels them all.
+type taskScope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// context returns the context tasks run under, starting a new one after stop.
+func (t *taskScope) context() context.Context {
+	if t.ctx == nil {
+		t.ctx, t.cancel = context.WithCancel(context.Background())
+	}
+	return t.ctx
+}
+
+// run returns a command that calls task under the scope's context, dropping
+// its message if the scope was stopped meanwhile.
+func (t *taskScope) run(task func(ctx context.Context) tea.Msg) tea.Cmd {
+	ctx := t.context()
+	return func() tea.Msg {
+		msg := task(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		return msg
+	}
+}
+
+// stop cancels every task in flight.
+func (t *taskScope) stop() {
+	if t.cancel != nil {
+		t.cancel()
+		t.ctx, t.cancel = nil, nil
+	}
+}
@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/render"
+)
+
+// RSVP (rapid serial visual presentation) flashes the chapter a word or a
+// few words at a time in the middle of the screen.
+const (
+	rsvpDefaultWPM = 300
+	rsvpStepWPM    = 25
+	rsvpSkipWords  = 10
+)
+
+type rsvpState struct {
+	words  []string
+	lines  []int // Content line each word came from
+	index  int   // First word on screen
+	chunk  int   // Words shown at once
+	wpm    int
+	paused bool
+	seq    int // Bumped on every change so stale ticks are dropped
+}
+
+type rsvpTickMsg struct {
+	reader *ReaderModel
+	seq    int
+}
+
+// startRSVP begins flashing words from the top of the current page.
+func (m *ReaderModel) startRSVP() tea.Cmd {
+	if len(m.content) == 0 {
+		return nil
+	}
+	wpm := rsvpDefaultWPM
+	if m.config != nil && m.config.Reading.RSVPWordsPerMinute > 0 {
+		wpm = m.config.Reading.RSVPWordsPerMinute
+	}
+	m.autoScroll = nil
+	m.rsvp = &rsvpState{chunk: 1, wpm: wpm}
+	m.loadRSVPWords(m.topLine())
+	return m.rsvpTick()
+}
+
+// loadRSVPWords splits the chapter into words, starting at fromLine.
+func (m *ReaderModel) loadRSVPWords(fromLine int) {
+	r := m.rsvp
+	r.words, r.lines, r.index = nil, nil, 0
+	for line, text := range m.content {
+		if line == fromLine {
+			r.index = len(r.words)
+		}
+		for _, word := range strings.Fields(render.StripANSI(text)) {
+			if strings.Trim(word, render.QuotePrefix) == "" {
+				continue
+			}
+			r.words = append(r.words, word)
+			r.lines = append(r.lines, line)
+		}
+	}
+}
+
+// stopRSVP leaves RSVP on the page holding the word it stopped at.
+func (m *ReaderModel) stopRSVP() {
+	r := m.rsvp
+	m.rsvp = nil
+	if r.index < len(r.lines) {
+		m.setTopLine(r.lines[r.index])
+	}
+	if m.config == nil {
+		return
+	}
+	saved := m.config.Reading.RSVPWordsPerMinute
+	if saved <= 0 {
+		saved = rsvpDefaultWPM
+	}
+	if r.wpm != saved {
+		m.config.Reading.RSVPWordsPerMinute = r.wpm
+		m.config.Save()
+	}
+}
+
+// rsvpTick schedules the next chunk, lingering a little longer at the end
+// of a clause or sentence.
+func (m *ReaderModel) rsvpTick() tea.Cmd {
+	r := m.rsvp
+	r.seq++
+	seq := r.seq
+	if r.paused {
+		return nil
+	}
+
+	delay := time.Duration(r.chunk) * time.Minute / time.Duration(r.wpm)
+	if last := m.rsvpChunk(); last != "" {
+		switch last[len(last)-1] {
+		case '.', '!', '?', '"':
+			delay *= 2
+		case ',', ';', ':':
+			delay = delay * 3 / 2
+		}
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return rsvpTickMsg{reader: m, seq: seq}
+	})
+}
+
+// rsvpChunk is the words currently on screen.
+func (m *ReaderModel) rsvpChunk() string {
+	r := m.rsvp
+	if r.index >= len(r.words) {
+		return ""
+	}
+	return strings.Join(r.words[r.index:min(r.index+r.chunk, len(r.words))], " ")
+}
+
+// handleRSVPTick moves to the next chunk, continuing into the next chapter
+// at the end of this one.
+func (m *ReaderModel) handleRSVPTick(msg rsvpTickMsg) tea.Cmd {
+	r := m.rsvp
+	if msg.reader != m || r == nil || msg.seq != r.seq || r.paused || m.loading {
+		return nil
+	}
+	session.touch()
+	if r.index+r.chunk < len(r.words) {
+		r.index += r.chunk
+		return m.rsvpTick()
+	}
+
+	m.setTopLine(len(m.content))
+	cmd := m.pastChapterEnd()
+	if cmd == nil {
+		// End of the book, or a prompt to answer
+		m.stopRSVP()
+	}
+	return cmd
+}
+
+// resumeRSVPAfterLoad restarts RSVP at the top of a newly loaded chapter.
+func (m *ReaderModel) resumeRSVPAfterLoad() tea.Cmd {
+	if m.rsvp == nil {
+		return nil
+	}
+	m.loadRSVPWords(m.topLine())
+	return m.rsvpTick()
+}
+
+// handleRSVPKey handles every key while RSVP is showing apart from ctrl+c.
+func (m *ReaderModel) handleRSVPKey(key string) (tea.Cmd, bool) {
+	r := m.rsvp
+	switch key {
+	case "ctrl+c":
+		m.stopRSVP()
+		return nil, false
+	case "esc", "w", "q":
+		m.stopRSVP()
+		return nil, true
+	case " ", "p":
+		r.paused = !r.paused
+	case "left", "h":
+		r.index = max(r.index-rsvpSkipWords, 0)
+	case "right", "l":
+		r.index = min(r.index+rsvpSkipWords, max(len(r.words)-1, 0))
+	case ">", ".":
+		r.wpm = min(r.wpm+rsvpStepWPM, 2000)
+	case "<", ",":
+		r.wpm = max(r.wpm-rsvpStepWPM, 50)
+	case "1", "2", "3":
+		r.chunk = int(key[0] - '0')
+	default:
+		return nil, true
+	}
+	return m.rsvpTick(), true
+}
+
+func (m *ReaderModel) rsvpView() string {
+	chunk := m.rsvpChunk()
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Bold(true)
+	if m.rsvp.chunk == 1 && chunk != "" {
+		// Color the letter the eye should fix on, about a third of the way in
+		pivot := (utf8.RuneCountInString(chunk) - 1) / 3
+		runes := []rune(chunk)
+		chunk = string(runes[:pivot]) + accent.Render(string(runes[pivot])) + string(runes[pivot+1:])
+	}
+	if m.rsvp.paused {
+		chunk += "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("paused")
+	}
+	return lipgloss.Place(m.termWidth, m.linesPerPage, lipgloss.Center, lipgloss.Center, chunk)
+}
+
+func (m *ReaderModel) rsvpFooter() string {
+	r := m.rsvp
+	return fmt.Sprintf("RSVP %d wpm • word %d/%d • [space] pause • [←/→] back/skip • [</>] speed • [1-3] words • esc exit",
+		r.wpm, min(r.index+1, len(r.words)), len(r.words))
+}
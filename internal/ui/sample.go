@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/render"
+)
+
+// sampleState tracks a time-boxed look at a fiction's opening chapters.
+type sampleState struct {
+	budget   int          // Words the sample should cover
+	words    int          // Words in the chapters opened so far
+	counted  map[int]bool // Chapter indices already added to words
+	prompt   bool         // Decision prompt is showing
+	decision string       // Shelf chosen at the end, or "discard"
+}
+
+// SetSampleMinutes turns the reader into a sampler: it starts at the first
+// chapter and, once roughly minutes' worth of text has been read, asks
+// whether to keep the fiction for later, shelve it, or discard it.
+func (m *ReaderModel) SetSampleMinutes(minutes int) {
+	m.sample = &sampleState{
+		budget:  minutes * m.wordsPerMinute(),
+		counted: make(map[int]bool),
+	}
+	m.startChapter = 0
+}
+
+// SampleDecision reports what was decided at the end of a sample: a shelf
+// name, "discard", or "" if the sample was left without deciding.
+func (m *ReaderModel) SampleDecision() string {
+	if m.sample == nil {
+		return ""
+	}
+	return m.sample.decision
+}
+
+func (m *ReaderModel) countSampleChapter(index int) {
+	if m.sample == nil || m.sample.counted[index] || m.currentChapter == nil {
+		return
+	}
+	m.sample.counted[index] = true
+	m.sample.words += render.WordCount(m.currentChapter.Content)
+}
+
+// sampleSpent reports whether the sample budget has been read, showing the
+// decision prompt in place of moving on to another chapter.
+func (m *ReaderModel) sampleSpent() bool {
+	if m.sample == nil || m.sample.words < m.sample.budget {
+		return false
+	}
+	m.sample.prompt = true
+	return true
+}
+
+// sampleFinished reports whether the sample budget has been read and the
+// reader is at the end of a chapter.
+func (m *ReaderModel) sampleFinished() bool {
+	return m.sample != nil && m.sample.words >= m.sample.budget && m.currentPage >= m.totalPages-1
+}
+
+func (m *ReaderModel) handleSampleDecision(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "r":
+		m.sample.decision = config.ShelfLater
+	case "s":
+		m.sample.decision = config.ShelfReading
+	case "d":
+		m.sample.decision = "discard"
+	case "esc":
+		m.sample.prompt = false
+		return m, nil, true
+	default:
+		return m, nil, false
+	}
+
+	m.saveReadingProgress()
+	if m.config != nil {
+		if m.sample.decision == "discard" {
+			m.config.RemoveEntry(m.fictionID)
+		} else {
+			m.config.SetShelf(m.fictionID, m.sample.decision)
+		}
+		m.config.Save()
+	}
+	return m, tea.Quit, true
+}
+
+func (m *ReaderModel) sampleFooter() string {
+	if m.sample.prompt {
+		return "⏳ Sample done. [r] add to Read Later • [s] shelve as Reading • [d] discard • [esc] keep browsing"
+	}
+	return fmt.Sprintf("⏳ sample %d%%", min(m.sample.words*100/max(m.sample.budget, 1), 100))
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Scroll mode shows the chapter in a viewport that moves a line at a time
+// instead of flipping whole pages. currentPage is kept in step with the
+// viewport so the end-of-chapter logic works the same in both modes.
+
+// topLine is the first content line on screen.
+func (m *ReaderModel) topLine() int {
+	if m.scrollMode {
+		return m.viewport.YOffset
+	}
+	return m.currentPage * m.linesPerPage
+}
+
+// setTopLine moves to the page, or scroll position, showing line.
+func (m *ReaderModel) setTopLine(line int) {
+	if m.scrollMode {
+		m.viewport.SetYOffset(line)
+		m.syncScrollPage()
+		return
+	}
+	if m.linesPerPage > 0 {
+		m.currentPage = min(max(line/m.linesPerPage, 0), max(m.totalPages-1, 0))
+	}
+}
+
+// syncScrollPage sets currentPage from the viewport, counting the bottom of
+// the chapter as its last page.
+func (m *ReaderModel) syncScrollPage() {
+	if m.linesPerPage <= 0 {
+		return
+	}
+	m.currentPage = min(m.viewport.YOffset/m.linesPerPage, max(m.totalPages-2, 0))
+	if m.viewport.AtBottom() {
+		m.currentPage = max(m.totalPages-1, 0)
+	}
+}
+
+// refreshViewport loads the laid-out chapter into the viewport.
+func (m *ReaderModel) refreshViewport() {
+	lines := make([]string, len(m.content))
+	for i := range m.content {
+		lines[i] = m.displayLine(i)
+	}
+	m.viewport.Width = m.termWidth
+	m.viewport.Height = m.linesPerPage
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+	m.viewportFocus = m.shownFocus()
+}
+
+// toggleScrollMode switches between scrolling and paging at the same place
+// and remembers the choice.
+func (m *ReaderModel) toggleScrollMode() {
+	line := m.topLine()
+	m.scrollMode = !m.scrollMode
+	if m.scrollMode {
+		m.viewport = viewport.New(m.termWidth, m.linesPerPage)
+		m.refreshViewport()
+	}
+	m.setTopLine(line)
+	if m.config != nil {
+		m.config.Reading.ScrollMode = m.scrollMode
+		m.config.Save()
+	}
+}
+
+// scrollBy moves the viewport by lines (negative for up), counting every
+// screenful read as a page turned.
+func (m *ReaderModel) scrollBy(lines int) {
+	before := m.viewport.YOffset
+	if lines > 0 {
+		m.viewport.LineDown(lines)
+	} else {
+		m.viewport.LineUp(-lines)
+	}
+	if moved := m.viewport.YOffset - before; moved > 0 {
+		m.scrolledLines += moved
+		for m.linesPerPage > 0 && m.scrolledLines >= m.linesPerPage {
+			m.scrolledLines -= m.linesPerPage
+			session.pageTurned()
+		}
+	}
+	m.syncScrollPage()
+}
+
+// handleScrollKey handles the movement keys in scroll mode. Moving past
+// either end of the chapter continues into the next or previous one, as
+// turning pages does.
+func (m *ReaderModel) handleScrollKey(key string) (tea.Cmd, bool) {
+	switch key {
+	case "down", "j":
+		if m.viewport.AtBottom() {
+			return m.pastChapterEnd(), true
+		}
+		m.scrollBy(1)
+	case "up", "k":
+		if m.viewport.AtTop() {
+			return m.beforeChapterStart(), true
+		}
+		m.scrollBy(-1)
+	case " ", "f", "right", "l", "pgdown":
+		if m.viewport.AtBottom() {
+			return m.pastChapterEnd(), true
+		}
+		m.scrollBy(m.linesPerPage)
+	case "left", "h", "pgup":
+		if m.viewport.AtTop() {
+			return m.beforeChapterStart(), true
+		}
+		m.scrollBy(-m.linesPerPage)
+	case "ctrl+d":
+		m.scrollBy(m.linesPerPage / 2)
+	case "ctrl+u":
+		m.scrollBy(-m.linesPerPage / 2)
+	case "g", "home":
+		m.setTopLine(0)
+	case "G", "end":
+		m.viewport.GotoBottom()
+		m.syncScrollPage()
+	default:
+		return nil, false
+	}
+	return nil, true
+}
@@ -1,11 +1,17 @@
 package ui
 
 import (
+	"context"
 	"fmt"
-	"royal-road-cli/internal/api"
+	"github.com/jackowfish/royal-road-cli/internal/browser"
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,12 +23,18 @@ type searchModel struct {
 	list        list.Model
 	searching   bool
 	err         error
-	client      *api.Client
-	fictions    []api.SearchFiction
+	client      *royalroad.Client
+	config      *config.Config
+	fictions    []royalroad.SearchFiction
 	showResults bool
+	requests    *requestScope // Shared by the copies of the model
+	cacheOnly   bool          // Set by ctrl+l on an error to search the cached results
 }
 
-type searchResultsMsg []api.SearchFiction
+// The input takes every plain key, so the recovery actions use ctrl
+var searchFailureKeys = failureKeys{retry: "enter", browser: "ctrl+o", cache: "ctrl+l"}
+
+type searchResultsMsg []royalroad.SearchFiction
 type searchErrorMsg error
 
 func NewSearchModel() searchModel {
@@ -53,15 +65,19 @@ func NewSearchModel() searchModel {
 
 	l := list.New(items, delegate, termWidth, termHeight-2)
 	l.Title = "🔍 Search Results"
-	l.StatusMessageLifetime = 0
+	l.StatusMessageLifetime = queueStatusLifetime
+	l.AdditionalShortHelpKeys = func() []key.Binding { return []key.Binding{queueKey} }
 	l.SetShowHelp(true)
 	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(false)
 
+	cfg, _ := config.Load()
 	return searchModel{
 		input:  input,
 		list:   l,
-		client: api.NewClient(),
+		client:   network.NewClient(cfg),
+		config:   cfg,
+		requests: &requestScope{},
 	}
 }
 
@@ -74,6 +90,13 @@ func (m searchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if msg.String() == "ctrl+p" {
+			return openQuickSwitcher(m)
+		}
+		if msg.String() == "ctrl+r" {
+			m.requests.stop()
+			return returnToReading(m)
+		}
 		if m.showResults {
 			switch msg.String() {
 			case "esc", "q":
@@ -84,16 +107,35 @@ func (m searchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					readerModel := NewReaderModel(strconv.Itoa(selected.fiction.ID))
 					return readerModel, readerModel.Init()
 				}
+			case "a":
+				if selected, ok := m.list.SelectedItem().(searchFictionItem); ok {
+					return m, m.list.NewStatusMessage(addToQueue(m.config, selected.fiction.ID, selected.fiction.Title, selected.fiction.Author))
+				}
 			}
 			m.list, cmd = m.list.Update(msg)
 			return m, cmd
 		} else {
 			switch msg.String() {
 			case "esc", "q":
+				m.requests.stop()
 				return NewMenuModel(), nil
 			case "enter":
 				if strings.TrimSpace(m.input.Value()) != "" {
 					m.searching = true
+					m.cacheOnly = false
+					return m, m.search()
+				}
+			case searchFailureKeys.browser:
+				if m.err != nil && describeFailure(m.err, m.cacheOnly).browser {
+					if err := browser.Open(searchURL(strings.TrimSpace(m.input.Value()))); err != nil {
+						m.err = fmt.Errorf("opening browser failed: %w", err)
+					}
+					return m, nil
+				}
+			case searchFailureKeys.cache:
+				if m.err != nil && describeFailure(m.err, m.cacheOnly).cache {
+					m.searching = true
+					m.cacheOnly = true
 					return m, m.search()
 				}
 			}
@@ -101,7 +143,8 @@ func (m searchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case searchResultsMsg:
 		m.searching = false
-		m.fictions = []api.SearchFiction(msg)
+		m.err = nil
+		m.fictions = []royalroad.SearchFiction(msg)
 		items := make([]list.Item, len(m.fictions))
 		for i, f := range m.fictions {
 			items[i] = searchFictionItem{fiction: f}
@@ -146,7 +189,9 @@ func (m searchModel) View() string {
 	if m.searching {
 		s.WriteString("Searching...")
 	} else if m.err != nil {
-		s.WriteString(fmt.Sprintf("Error: %v", m.err))
+		s.WriteString(lipgloss.NewStyle().
+			Width(m.list.Width()).
+			Render(describeFailure(m.err, m.cacheOnly).render(m.err, searchFailureKeys, "'esc' to go back")))
 	} else {
 		s.WriteString("Press Enter to search, Esc to go back")
 	}
@@ -156,17 +201,26 @@ func (m searchModel) View() string {
 
 func (m searchModel) search() tea.Cmd {
 	query := strings.TrimSpace(m.input.Value())
-	return func() tea.Msg {
-		fictions, err := m.client.SearchFictions(query)
+	cacheOnly := m.cacheOnly
+	return m.requests.run(func(ctx context.Context) tea.Msg {
+		if cacheOnly {
+			ctx = royalroad.CacheOnly(ctx)
+		}
+		fictions, err := m.client.SearchFictions(ctx, query)
 		if err != nil {
 			return searchErrorMsg(err)
 		}
 		return searchResultsMsg(fictions)
-	}
+	})
+}
+
+// searchURL is the site's own search for query.
+func searchURL(query string) string {
+	return fmt.Sprintf("%s/fictions/search?title=%s", royalroad.DefaultBaseURL, url.QueryEscape(query))
 }
 
 type searchFictionItem struct {
-	fiction api.SearchFiction
+	fiction royalroad.SearchFiction
 }
 
 func (i searchFictionItem) FilterValue() string {
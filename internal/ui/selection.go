@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jackowfish/royal-road-cli/internal/clipboard"
+	"github.com/jackowfish/royal-road-cli/internal/render"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// selectedPassage is the content lines [start, end) that actions such as
+// highlighting work on: the focused paragraph in focus mode, otherwise the
+// page on screen.
+func (m *ReaderModel) selectedPassage() (start, end int, ok bool) {
+	if len(m.content) == 0 {
+		return 0, 0, false
+	}
+	if m.focusMode {
+		if start, end, ok := m.focusedParagraph(); ok {
+			return start, end, true
+		}
+	}
+	start = m.topLine()
+	end = min(start+m.linesPerPage, len(m.content))
+	// Leave out blank lines at either end
+	for start < end && m.blankLine(start) {
+		start++
+	}
+	for end > start && m.blankLine(end-1) {
+		end--
+	}
+	return start, end, start < end
+}
+
+// passageText is the plain text of content lines [start, end), with wrapped
+// lines joined back into paragraphs separated by blank lines.
+func (m *ReaderModel) passageText(start, end int) string {
+	var paragraphs []string
+	var current []string
+	for i := start; i < end && i < len(m.content); i++ {
+		line := strings.TrimSpace(render.StripANSI(m.content[i]))
+		if line == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, strings.Join(current, " "))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, strings.Join(current, " "))
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// chapterURL links to the current chapter on Royal Road.
+func (m *ReaderModel) chapterURL() string {
+	if m.fiction == nil || m.chapterIndex >= len(m.fiction.Chapters) {
+		return ""
+	}
+	return fmt.Sprintf("%s/fiction/chapter/%d", royalroad.DefaultBaseURL, m.fiction.Chapters[m.chapterIndex].ID)
+}
+
+// source is the fiction's title and author.
+func (m *ReaderModel) source() string {
+	if m.fiction.Author.Name == "" {
+		return m.fiction.Title
+	}
+	return m.fiction.Title + " by " + m.fiction.Author.Name
+}
+
+// citation names the source of a passage from the current chapter.
+func (m *ReaderModel) citation() string {
+	chapter := m.fiction.Chapters[m.chapterIndex]
+	return fmt.Sprintf("— %s, Chapter %d: %s\n%s", m.source(), m.chapterIndex+1, chapter.Title, m.chapterURL())
+}
+
+// copySelection copies the selected passage and its citation to the clipboard.
+func (m *ReaderModel) copySelection() {
+	if m.fiction == nil || m.currentChapter == nil {
+		return
+	}
+	start, end, ok := m.selectedPassage()
+	if !ok {
+		return
+	}
+	text := m.passageText(start, end) + "\n\n" + m.citation() + "\n"
+	if err := clipboard.Copy(os.Stdout, text); err != nil {
+		m.setCommandMessage(fmt.Sprintf("copying failed: %v", err), true)
+		return
+	}
+	what := "page"
+	if m.focusMode {
+		what = "paragraph"
+	}
+	m.setCommandMessage("Copied the "+what+" to the clipboard", false)
+}
+
+// captureQuote appends the selected passage and where it came from to the
+// quotes file.
+func (m *ReaderModel) captureQuote() {
+	if m.fiction == nil || m.currentChapter == nil || m.config == nil {
+		return
+	}
+	start, end, ok := m.selectedPassage()
+	if !ok {
+		return
+	}
+	path, err := m.config.QuotesPath()
+	if err != nil {
+		m.setCommandMessage(fmt.Sprintf("capturing the quote failed: %v", err), true)
+		return
+	}
+
+	chapter := m.fiction.Chapters[m.chapterIndex]
+	quote := "> " + strings.ReplaceAll(m.passageText(start, end), "\n", "\n> ")
+	entry := fmt.Sprintf("%s\n>\n> — %s, [Chapter %d: %s](%s) · %s\n\n",
+		quote, m.source(), m.chapterIndex+1, chapter.Title, m.chapterURL(), time.Now().Format("2006-01-02"))
+
+	if err := appendFile(path, entry); err != nil {
+		m.setCommandMessage(fmt.Sprintf("capturing the quote failed: %v", err), true)
+		return
+	}
+	m.setCommandMessage("Quote saved to "+path, false)
+}
+
+func appendFile(path, text string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
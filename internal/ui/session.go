@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// readingSession tracks reading activity for the lifetime of the program,
+// across every reader screen opened along the way. Time only accrues between
+// keypresses that are less than idleThreshold apart, so walking away from the
+// terminal doesn't inflate the numbers.
+type readingSession struct {
+	started       time.Time
+	active        time.Duration
+	reading       bool // whether a reader is currently on screen
+	lastActivity  time.Time
+	idleThreshold time.Duration // zero disables idle detection
+	pages         int
+	words         int // Words read, counted as the reader moves through chapters
+	chapters      int
+	fictions      []string
+}
+
+var session = &readingSession{}
+
+// sessionTickMsg refreshes the in-reader stopwatch. It carries the reader that
+// scheduled it, and that reader's count of ticks at the time, so stale ticks
+// from a closed reader, or from before a tab was switched back to, don't start
+// a second loop.
+type sessionTickMsg struct {
+	reader *ReaderModel
+	seq    int
+}
+
+func sessionTick(reader *ReaderModel) tea.Cmd {
+	reader.sessionSeq++
+	seq := reader.sessionSeq
+	return tea.Tick(30*time.Second, func(time.Time) tea.Msg {
+		return sessionTickMsg{reader: reader, seq: seq}
+	})
+}
+
+func (s *readingSession) resume() {
+	now := time.Now()
+	if s.started.IsZero() {
+		s.started = now
+	}
+	if !s.reading {
+		s.reading = true
+		s.lastActivity = now
+	}
+}
+
+func (s *readingSession) pause() {
+	if !s.reading {
+		return
+	}
+	s.touch()
+	s.reading = false
+}
+
+// touch records user activity, crediting the time since the previous activity
+// unless the gap was long enough to count as idle.
+func (s *readingSession) touch() {
+	if !s.reading {
+		return
+	}
+	now := time.Now()
+	if gap := now.Sub(s.lastActivity); !s.isIdleGap(gap) {
+		s.active += gap
+	}
+	s.lastActivity = now
+}
+
+func (s *readingSession) isIdleGap(gap time.Duration) bool {
+	return s.idleThreshold > 0 && gap > s.idleThreshold
+}
+
+func (s *readingSession) idle() bool {
+	return s.reading && s.isIdleGap(time.Since(s.lastActivity))
+}
+
+func (s *readingSession) elapsed() time.Duration {
+	if !s.reading || s.idle() {
+		return s.active
+	}
+	return s.active + time.Since(s.lastActivity)
+}
+
+func (s *readingSession) pageTurned() {
+	s.pages++
+}
+
+func (s *readingSession) chapterOpened(fictionID string) {
+	s.chapters++
+	for _, id := range s.fictions {
+		if id == fictionID {
+			return
+		}
+	}
+	s.fictions = append(s.fictions, fictionID)
+}
+
+func (s *readingSession) summary() string {
+	minutes := int(s.elapsed().Minutes())
+	duration := fmt.Sprintf("%d min", minutes)
+	if minutes < 1 {
+		duration = "<1 min"
+	}
+
+	chapters := fmt.Sprintf("%d chapters", s.chapters)
+	if s.chapters == 1 {
+		chapters = "1 chapter"
+	}
+
+	return fmt.Sprintf("Read %s, %s (%d pages turned)", duration, chapters, s.pages)
+}
+
+// formatStopwatch renders a duration as "42m" or "1h05m" for the reader footer.
+func formatStopwatch(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
+
+// EndSession stops the stopwatch, records the session in the stats store and
+// returns a one-line summary. It returns "" if no reading happened.
+func EndSession() string {
+	session.pause()
+	if session.started.IsZero() {
+		return ""
+	}
+
+	cfg, err := config.Load()
+	if err == nil {
+		cfg.RecordSession(config.ReadingSession{
+			Start:    session.started.Format(config.TimeLayout),
+			Seconds:  int(session.active.Seconds()),
+			Pages:    session.pages,
+			Chapters: session.chapters,
+			Words:    session.words,
+			Fictions: session.fictions,
+		})
+		_ = cfg.Save()
+	}
+
+	return session.summary()
+}
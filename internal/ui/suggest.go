@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// maxSuggestions is how many completions are shown under the New Book input.
+const maxSuggestions = 5
+
+// fictionSuggestion is a completion candidate for the fiction ID input.
+type fictionSuggestion struct {
+	FictionID string
+	Title     string
+	Author    string
+}
+
+func (s fictionSuggestion) label() string {
+	switch {
+	case s.Title == "":
+		return s.FictionID
+	case s.Author == "":
+		return s.FictionID + "  " + s.Title
+	default:
+		return s.FictionID + "  " + s.Title + " by " + s.Author
+	}
+}
+
+// collectFictionSuggestions lists recently entered IDs first, then anything
+// else in the reading history, each fiction once.
+func collectFictionSuggestions(cfg *config.Config) []fictionSuggestion {
+	known := make(map[string]config.ReadingEntry, len(cfg.ReadingHistory))
+	for _, entry := range cfg.ReadingHistory {
+		known[entry.FictionID] = entry
+	}
+
+	seen := make(map[string]bool)
+	var suggestions []fictionSuggestion
+	add := func(fictionID string) {
+		if seen[fictionID] {
+			return
+		}
+		seen[fictionID] = true
+		entry := known[fictionID]
+		suggestions = append(suggestions, fictionSuggestion{
+			FictionID: fictionID,
+			Title:     entry.FictionTitle,
+			Author:    entry.Author,
+		})
+	}
+	for _, fictionID := range cfg.RecentFictionIDs {
+		add(fictionID)
+	}
+	for _, entry := range cfg.ReadingHistory {
+		add(entry.FictionID)
+	}
+	return suggestions
+}
+
+// suggestionSource adapts suggestions for fuzzy matching on title and author.
+type suggestionSource []fictionSuggestion
+
+func (s suggestionSource) String(i int) string {
+	return s[i].Title + " " + s[i].Author
+}
+
+func (s suggestionSource) Len() int {
+	return len(s)
+}
+
+// matchFictionSuggestions narrows candidates to the query: digits match ID
+// prefixes, anything else is fuzzy-matched against titles and authors.
+func matchFictionSuggestions(candidates []fictionSuggestion, query string) []fictionSuggestion {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+
+	var matches []fictionSuggestion
+	if isDigits(query) {
+		for _, candidate := range candidates {
+			if strings.HasPrefix(candidate.FictionID, query) && candidate.FictionID != query {
+				matches = append(matches, candidate)
+			}
+		}
+	} else {
+		for _, match := range fuzzy.FindFrom(query, suggestionSource(candidates)) {
+			matches = append(matches, candidates[match.Index])
+		}
+	}
+
+	if len(matches) > maxSuggestions {
+		matches = matches[:maxSuggestions]
+	}
+	return matches
+}
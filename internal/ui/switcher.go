@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+type switcherItem struct {
+	kind      string // "history" or "bookmark"
+	title     string
+	detail    string
+	fictionID string
+	chapter   int
+	progress  float64 // Position within the chapter for bookmarks
+	words     int     // Word offset of a bookmark, preferred over progress
+}
+
+type switcherSource []switcherItem
+
+func (s switcherSource) String(i int) string {
+	return s[i].title + " " + s[i].detail
+}
+
+func (s switcherSource) Len() int {
+	return len(s)
+}
+
+// QuickSwitcherModel is a fuzzy finder over everything the user has been
+// reading. It can be opened from any screen and returns to that screen on esc.
+type QuickSwitcherModel struct {
+	input    textinput.Model
+	items    []switcherItem
+	matches  []switcherItem
+	selected int
+	previous tea.Model
+}
+
+const switcherMaxResults = 10
+
+func NewQuickSwitcherModel(previous tea.Model) *QuickSwitcherModel {
+	cfg, _ := config.Load()
+
+	input := textinput.New()
+	input.Placeholder = "Jump to a book..."
+	input.Prompt = "❯ "
+	input.Focus()
+	input.Width = 50
+
+	m := &QuickSwitcherModel{
+		input:    input,
+		items:    switcherItems(cfg),
+		previous: previous,
+	}
+	m.refreshMatches()
+	return m
+}
+
+// openQuickSwitcher is the shared ctrl+p handler for every screen.
+func openQuickSwitcher(from tea.Model) (tea.Model, tea.Cmd) {
+	switcher := NewQuickSwitcherModel(from)
+	return switcher, switcher.Init()
+}
+
+func switcherItems(cfg *config.Config) []switcherItem {
+	if cfg == nil {
+		return nil
+	}
+
+	var items []switcherItem
+	for _, entry := range cfg.ReadingHistory {
+		items = append(items, switcherItem{
+			kind:      "history",
+			title:     entry.FictionTitle,
+			detail:    fmt.Sprintf("by %s • Ch. %d: %s", entry.Author, entry.CurrentChapter+1, entry.ChapterTitle),
+			fictionID: entry.FictionID,
+			chapter:   entry.CurrentChapter,
+		})
+	}
+	for _, bookmark := range cfg.Bookmarks {
+		items = append(items, switcherItem{
+			kind:      "bookmark",
+			title:     bookmark.FictionTitle,
+			detail:    fmt.Sprintf("Ch. %d: %s %s", bookmark.ChapterIndex+1, bookmark.ChapterTitle, bookmark.Label),
+			fictionID: bookmark.FictionID,
+			chapter:   bookmark.ChapterIndex,
+			progress:  bookmark.Progress,
+			words:     bookmark.WordOffset,
+		})
+	}
+	return items
+}
+
+func (m *QuickSwitcherModel) refreshMatches() {
+	query := strings.TrimSpace(m.input.Value())
+	if query == "" {
+		m.matches = m.items
+	} else {
+		m.matches = nil
+		for _, match := range fuzzy.FindFrom(query, switcherSource(m.items)) {
+			m.matches = append(m.matches, m.items[match.Index])
+		}
+	}
+	if len(m.matches) > switcherMaxResults {
+		m.matches = m.matches[:switcherMaxResults]
+	}
+	m.selected = min(m.selected, max(len(m.matches)-1, 0))
+}
+
+func (m *QuickSwitcherModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m *QuickSwitcherModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "ctrl+p":
+			if m.previous != nil {
+				return m.previous, nil
+			}
+			menuModel := NewMenuModel()
+			return menuModel, menuModel.Init()
+		case "up", "ctrl+k":
+			if m.selected > 0 {
+				m.selected--
+			}
+			return m, nil
+		case "down", "ctrl+j", "ctrl+n":
+			if m.selected < len(m.matches)-1 {
+				m.selected++
+			}
+			return m, nil
+		case "enter":
+			if len(m.matches) == 0 {
+				return m, nil
+			}
+			item := m.matches[m.selected]
+			readerModel := NewReaderModel(item.fictionID)
+			// The reader restores a history entry's saved chapter and page itself
+			if item.kind == "bookmark" {
+				readerModel.SetStartChapter(item.chapter)
+				readerModel.SetStartProgress(item.progress)
+				readerModel.SetStartWordOffset(item.words)
+			}
+			return readerModel, readerModel.Init()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.refreshMatches()
+	return m, cmd
+}
+
+func (m *QuickSwitcherModel) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("170")).
+		Render("⚡ Quick Switch")
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("%s\n\n%s\n\n", title, m.input.View()))
+
+	if len(m.items) == 0 {
+		content.WriteString("Nothing to switch to yet — start reading a book first.\n\n")
+	} else if len(m.matches) == 0 {
+		content.WriteString("No matches.\n\n")
+	}
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("170")).
+		Bold(true)
+	detailStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	for i, item := range m.matches {
+		icon := "📖"
+		if item.kind == "bookmark" {
+			icon = "🔖"
+		}
+
+		line := fmt.Sprintf("%s %s", icon, item.title)
+		if i == m.selected {
+			content.WriteString(selectedStyle.Render("▶ " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("  " + detailStyle.Render(item.detail) + "\n")
+	}
+
+	content.WriteString("\n↑/↓ select • [enter] open • [esc] back")
+	return content.String()
+}
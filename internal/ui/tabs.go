@@ -0,0 +1,241 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+// maxTabs is how many books can be open at once; opening another closes the
+// one opened longest ago.
+const maxTabs = 9
+
+// tabLabelWidth is how much of each inactive book's title the tab bar shows.
+const tabLabelWidth = 18
+
+// readerTabs holds every open book in tab order. Each reader keeps its own
+// chapter, page and modes while another one is showing. The tabs last for the
+// whole program: leaving the reader for the menu or another screen keeps
+// them, with their work stopped, so that returnToReading finds them again.
+var readerTabs []*ReaderModel
+
+// activeReader is the tab showing, or shown last, in the reader. Like the
+// tabs, it is kept after the reader is left.
+var activeReader *ReaderModel
+
+// openTab adds m to the tabs, in place of any tab already showing the same
+// fiction.
+func openTab(m *ReaderModel) {
+	for i, tab := range readerTabs {
+		if tab == m {
+			return
+		}
+		if tab.fictionID == m.fictionID {
+			readerTabs[i] = m
+			activeReader = m
+			tab.closeInBackground()
+			return
+		}
+	}
+	activeReader = m
+	readerTabs = append(readerTabs, m)
+	if len(readerTabs) > maxTabs {
+		readerTabs[0].closeInBackground()
+		readerTabs = readerTabs[1:]
+	}
+}
+
+// tabIndex returns m's position among the tabs, or -1 if it isn't one.
+func tabIndex(m *ReaderModel) int {
+	for i, tab := range readerTabs {
+		if tab == m {
+			return i
+		}
+	}
+	return -1
+}
+
+// closeInBackground stops the work of a tab that is going away. Its progress
+// was saved when it was switched away from, and saving again would write
+// back its older copy of the config.
+func (m *ReaderModel) closeInBackground() {
+	m.requests.stop()
+	m.stopTasks()
+	m.stopChapterSearch()
+	m.stopTTS()
+	if m.downloading() {
+		m.background.cancel()
+	}
+}
+
+// leaveTab saves progress and pauses anything that runs on a timer, so the
+// book is exactly where it was left when its tab comes back.
+func (m *ReaderModel) leaveTab() {
+	m.saveReadingProgress()
+	m.autoScroll = nil
+	if m.rsvp != nil {
+		m.stopRSVP()
+	}
+	m.stopTTS()
+	m.stopChapterSearch()
+	m.showChapterSearch = false
+}
+
+// switchTab shows the tab step places along from m, wrapping around.
+func (m *ReaderModel) switchTab(step int) (tea.Model, tea.Cmd) {
+	i := tabIndex(m)
+	if i < 0 || len(readerTabs) < 2 {
+		return m, nil
+	}
+	return m.showTab(readerTabs[(i+step%len(readerTabs)+len(readerTabs))%len(readerTabs)])
+}
+
+// showTab leaves m for next, resized to the current terminal.
+func (m *ReaderModel) showTab(next *ReaderModel) (tea.Model, tea.Cmd) {
+	if m.loading {
+		m.commandMessage = "Wait for the chapter to load before switching tabs"
+		return m, nil
+	}
+	m.leaveTab()
+	// The config m just saved to is the newest copy
+	next.config = m.config
+	if next.termWidth != m.termWidth || next.termHeight != m.termHeight {
+		next.applyWindowSize(tea.WindowSizeMsg{Width: m.termWidth, Height: m.termHeight})
+	}
+	activeReader = next
+	return next, sessionTick(next)
+}
+
+// returnToReading goes back to the book read last, reusing its open tab so
+// the fiction isn't fetched again, or opens the last book in the history.
+func returnToReading(from tea.Model) (tea.Model, tea.Cmd) {
+	cfg, err := config.Load()
+	m := activeReader
+	if m == nil || tabIndex(m) < 0 {
+		if err != nil || cfg.LastFiction == "" {
+			return from, nil
+		}
+		readerModel := NewReaderModel(cfg.LastFiction)
+		return readerModel, readerModel.Init()
+	}
+
+	if err == nil {
+		// Other screens may have saved changes since the reader last did
+		m.config = cfg
+		session.idleThreshold = time.Duration(cfg.Reading.IdleMinutes) * time.Minute
+	}
+	session.resume()
+	if width, height := getTerminalSize(); width != m.termWidth || height != m.termHeight {
+		m.applyWindowSize(tea.WindowSizeMsg{Width: width, Height: height})
+	}
+
+	if m.loading {
+		// Leaving the reader cancelled the load, so start it again
+		cmd := m.loadFiction()
+		if m.fiction != nil {
+			cmd = m.loadChapter(m.chapterIndex)
+		}
+		return m, tea.Batch(cmd, sessionTick(m))
+	}
+	// The history may have moved the book on, e.g. restarted or finished it
+	if entry := m.config.GetEntry(m.fictionID); entry != nil && m.fiction != nil && !m.loading &&
+		entry.CurrentChapter != m.chapterIndex && entry.CurrentChapter < len(m.fiction.Chapters) {
+		m.chapterIndex = entry.CurrentChapter
+		m.loading = true
+		return m, tea.Batch(m.loadChapter(m.chapterIndex), sessionTick(m))
+	}
+	return m, sessionTick(m)
+}
+
+// closeTab closes m's tab and shows the next one.
+func (m *ReaderModel) closeTab() (tea.Model, tea.Cmd, error) {
+	i := tabIndex(m)
+	if i < 0 || len(readerTabs) < 2 {
+		return m, nil, fmt.Errorf("this is the only open book; use :q to quit")
+	}
+	if m.downloading() {
+		// Closing the tab would cancel its download
+		model, cmd := m.leave(func() (tea.Model, tea.Cmd) {
+			model, cmd, _ := m.closeTab()
+			return model, cmd
+		})
+		return model, cmd, nil
+	}
+	model, cmd := m.showTab(readerTabs[(i+1)%len(readerTabs)])
+	if model == tea.Model(m) {
+		return m, nil, nil
+	}
+	readerTabs = append(readerTabs[:i], readerTabs[i+1:]...)
+	m.closeInBackground()
+	return model, cmd, nil
+}
+
+// tabOwner returns the tab a timer or background result belongs to, so a
+// book keeps downloading and searching while another one is showing.
+func tabOwner(msg tea.Msg) *ReaderModel {
+	for _, tab := range readerTabs {
+		switch msg := msg.(type) {
+		case autoScrollTickMsg:
+			if msg.reader == tab {
+				return tab
+			}
+		case rsvpTickMsg:
+			if msg.reader == tab {
+				return tab
+			}
+		case ttsDoneMsg:
+			if msg.reader == tab {
+				return tab
+			}
+		case commandDoneMsg:
+			if msg.reader == tab {
+				return tab
+			}
+		case downloadProgressMsg:
+			if msg.download == tab.background {
+				return tab
+			}
+		case chapterSearchMsg:
+			if msg.search == tab.chapterSearch {
+				return tab
+			}
+		case translationMsg:
+			if msg.state == tab.translation {
+				return tab
+			}
+		}
+	}
+	return nil
+}
+
+// tabBarView lists the open books, the current one in full, or returns ""
+// when only one is open.
+func (m *ReaderModel) tabBarView() string {
+	if len(readerTabs) < 2 || tabIndex(m) < 0 {
+		return ""
+	}
+	activeStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("170"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	labels := make([]string, len(readerTabs))
+	for i, tab := range readerTabs {
+		title := tab.fictionID
+		if tab.fiction != nil {
+			title = tab.fiction.Title
+		}
+		if tab == m {
+			labels[i] = activeStyle.Render(fmt.Sprintf("%d %s", i+1, title))
+			continue
+		}
+		if runes := []rune(title); len(runes) > tabLabelWidth {
+			title = string(runes[:tabLabelWidth-1]) + "…"
+		}
+		labels[i] = dim.Render(fmt.Sprintf("%d %s", i+1, title))
+	}
+	return strings.Join(labels, dim.Render(" │ "))
+}
@@ -2,43 +2,109 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/sahilm/fuzzy"
 
-	"royal-road-cli/internal/api"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
 )
 
+// tocChromeLines is how many lines the TOC uses besides the chapter list: the
+// title, the scroll and offline notes and the more above/below hints.
+const tocChromeLines = 7
+
 type TOCModel struct {
-	fiction       *api.Fiction
+	fiction       *royalroad.Fiction
 	currentIndex  int           // Currently selected chapter in reader
 	selectedIndex int           // Selected chapter in TOC (for navigation)
 	scrollOffset  int           // Current scroll position
 	viewHeight    int           // Height of the TOC viewport
 	visible       bool          // Whether TOC is currently visible
+	cached        map[int]bool  // Chapter IDs available offline
+	offline       bool          // Whether only cached chapters can be opened
+	edited        map[int]bool  // Chapter IDs changed by the author since they were cached
+	words         map[int]int   // Word counts by chapter ID, where known
+	compact       bool          // Titles only, without release dates and word counts
+	filter        string        // Fuzzy filter on chapter titles
+	filtering     bool          // Whether the filter is being typed
+	matches       []int         // Chapters matching the filter, in order; nil when not filtering
+	read          map[int]bool  // Chapter IDs read
+	markRead      func(chapterIDs []int, read bool) // Records chapters marked read or unread
+	rangeStart    int           // Chapter a range being marked starts at; -1 when not marking one
+	width         int           // Columns available; longer titles are truncated (0 for no limit)
+	jump          string        // Chapter number being typed
 }
 
-func NewTOCModel(fiction *api.Fiction, currentIndex int, viewHeight int) *TOCModel {
-	return &TOCModel{
+// NewTOCModel makes a TOC that fits in height lines.
+func NewTOCModel(fiction *royalroad.Fiction, currentIndex int, height int) *TOCModel {
+	m := &TOCModel{
 		fiction:       fiction,
 		currentIndex:  currentIndex,
 		selectedIndex: currentIndex,
 		scrollOffset:  0,
-		viewHeight:    max(viewHeight-4, 10), // Account for header and footer
 		visible:       false,
+		rangeStart:    -1,
+	}
+	m.SetHeight(height)
+	return m
+}
+
+// SetHeight fits the TOC in height lines, e.g. after the terminal is resized.
+func (m *TOCModel) SetHeight(height int) {
+	m.viewHeight = max(height-tocChromeLines, 3)
+	if m.visible && m.fiction != nil {
+		m.ensureVisible()
 	}
 }
 
 func (m *TOCModel) SetVisible(visible bool) {
 	m.visible = visible
 	if visible && m.fiction != nil {
-		// Center the current chapter when TOC becomes visible
+		// Start from the current chapter, centered
+		m.jump = ""
+		m.rangeStart = -1
+		m.selectedIndex = m.currentIndex
+		if m.rowOf(m.currentIndex) < 0 {
+			m.clearFilter()
+		}
 		m.centerOnCurrentChapter()
 	}
 }
 
+// SetCachedChapters marks which chapters are available offline. When offline
+// is set, chapters missing from the cache are dimmed.
+func (m *TOCModel) SetCachedChapters(cached map[int]bool, offline bool) {
+	m.cached = cached
+	m.offline = offline
+}
+
+// SetEditedChapters flags chapters the author has edited since they were cached.
+func (m *TOCModel) SetEditedChapters(edited map[int]bool) {
+	m.edited = edited
+}
+
+// SetWordCounts sets the chapter lengths shown beside the titles.
+func (m *TOCModel) SetWordCounts(words map[int]int) {
+	m.words = words
+}
+
+// SetReadChapters marks the chapters read and enables marking them read or
+// unread, which calls mark with the chapters changed.
+func (m *TOCModel) SetReadChapters(read map[int]bool, mark func(chapterIDs []int, read bool)) {
+	m.read = read
+	m.markRead = mark
+}
+
+// SetWidth sets the columns available for each line.
+func (m *TOCModel) SetWidth(width int) {
+	m.width = width
+}
+
 func (m *TOCModel) SetCurrentChapter(index int) {
 	m.currentIndex = index
 	m.selectedIndex = index
@@ -53,8 +119,119 @@ func (m *TOCModel) centerOnCurrentChapter() {
 	}
 	
 	// Center the current chapter in the viewport
-	idealOffset := m.currentIndex - m.viewHeight/2
-	m.scrollOffset = max(0, min(idealOffset, len(m.fiction.Chapters)-m.viewHeight))
+	idealOffset := m.rowOf(m.currentIndex) - m.viewHeight/2
+	m.scrollOffset = max(0, min(idealOffset, m.rowCount()-m.viewHeight))
+}
+
+// rowCount is the number of chapters listed, all of them unless filtered.
+func (m *TOCModel) rowCount() int {
+	if m.matches != nil {
+		return len(m.matches)
+	}
+	return len(m.fiction.Chapters)
+}
+
+// chapterAt is the chapter listed in a row.
+func (m *TOCModel) chapterAt(row int) int {
+	if m.matches != nil {
+		return m.matches[row]
+	}
+	return row
+}
+
+// rowOf is the row a chapter is listed in, or -1 if the filter hides it.
+func (m *TOCModel) rowOf(index int) int {
+	if m.matches == nil {
+		return index
+	}
+	for row, chapter := range m.matches {
+		if chapter == index {
+			return row
+		}
+	}
+	return -1
+}
+
+// selectRow selects the chapter in a row, clamped to the list.
+func (m *TOCModel) selectRow(row int) {
+	if m.rowCount() == 0 {
+		return
+	}
+	m.selectedIndex = m.chapterAt(max(0, min(row, m.rowCount()-1)))
+	m.ensureVisible()
+}
+
+type tocTitles []royalroad.FictionChapter
+
+func (t tocTitles) String(i int) string {
+	return t[i].Title
+}
+
+func (t tocTitles) Len() int {
+	return len(t)
+}
+
+// applyFilter lists the chapters whose titles fuzzily match the filter, in
+// chapter order, keeping the selection if it still matches.
+func (m *TOCModel) applyFilter() {
+	query := strings.TrimSpace(m.filter)
+	if query == "" {
+		m.matches = nil
+	} else {
+		m.matches = []int{}
+		for _, match := range fuzzy.FindFrom(query, tocTitles(m.fiction.Chapters)) {
+			m.matches = append(m.matches, match.Index)
+		}
+		sort.Ints(m.matches)
+	}
+	m.scrollOffset = 0
+	if m.rowOf(m.selectedIndex) < 0 {
+		m.selectRow(0)
+	} else {
+		m.ensureVisible()
+	}
+}
+
+func (m *TOCModel) clearFilter() {
+	m.filter, m.filtering = "", false
+	m.applyFilter()
+}
+
+// handleFilterKey edits the filter while it's being typed.
+func (m *TOCModel) handleFilterKey(msg tea.KeyMsg) (int, bool) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.clearFilter()
+	case tea.KeyEnter:
+		m.filtering = false
+		if m.rowCount() == 1 {
+			// Only one chapter left; go straight to it
+			m.clearFilterAfterJump()
+			return m.selectedIndex, true
+		}
+	case tea.KeyBackspace:
+		if runes := []rune(m.filter); len(runes) > 0 {
+			m.filter = string(runes[:len(runes)-1])
+			m.applyFilter()
+		}
+	case tea.KeyUp:
+		m.selectRow(m.rowOf(m.selectedIndex) - 1)
+	case tea.KeyDown:
+		m.selectRow(m.rowOf(m.selectedIndex) + 1)
+	case tea.KeySpace:
+		m.filter += " "
+		m.applyFilter()
+	case tea.KeyRunes:
+		m.filter += string(msg.Runes)
+		m.applyFilter()
+	}
+	return -1, false
+}
+
+// clearFilterAfterJump drops the filter once a chapter has been chosen, so
+// the TOC opens on the whole list next time.
+func (m *TOCModel) clearFilterAfterJump() {
+	m.filter, m.filtering, m.matches = "", false, nil
 }
 
 func (m *TOCModel) Update(msg tea.Msg) (int, bool) {
@@ -64,38 +241,76 @@ func (m *TOCModel) Update(msg tea.Msg) (int, bool) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "up", "k":
-			if m.selectedIndex > 0 {
-				m.selectedIndex--
-				m.ensureVisible()
+		if m.filtering {
+			return m.handleFilterKey(msg)
+		}
+		key := msg.String()
+		if len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+			m.typeJumpDigit(key)
+			return -1, false
+		}
+		if m.jump != "" {
+			switch key {
+			case "backspace":
+				m.jump = m.jump[:len(m.jump)-1]
+				m.selectJump()
+				return -1, false
+			case "enter":
+				m.jump = ""
+				m.clearFilterAfterJump()
+				return m.selectedIndex, true
 			}
+			// Any other key abandons the number
+			m.jump = ""
+		}
+		switch key {
+		case "up", "k":
+			m.selectRow(m.rowOf(m.selectedIndex) - 1)
 			return -1, false
 		case "down", "j":
-			if m.selectedIndex < len(m.fiction.Chapters)-1 {
-				m.selectedIndex++
-				m.ensureVisible()
-			}
+			m.selectRow(m.rowOf(m.selectedIndex) + 1)
 			return -1, false
 		case "g", "home":
-			m.selectedIndex = 0
-			m.scrollOffset = 0
+			m.selectRow(0)
 			return -1, false
 		case "G", "end":
-			m.selectedIndex = len(m.fiction.Chapters) - 1
-			m.scrollOffset = max(0, len(m.fiction.Chapters)-m.viewHeight)
+			m.selectRow(m.rowCount() - 1)
+			return -1, false
+		case "/":
+			m.filtering = true
+			return -1, false
+		case "x":
+			m.toggleRead()
+			return -1, false
+		case "X":
+			m.markReadUpTo()
+			return -1, false
+		case "V":
+			if m.markRead != nil {
+				m.rangeStart = m.selectedIndex
+			}
+			return -1, false
+		case "esc":
+			if m.rangeStart >= 0 {
+				m.rangeStart = -1
+				return -1, false
+			}
+			if m.matches != nil {
+				m.clearFilter()
+				return -1, false
+			}
+			return -1, true
+		case "v":
+			m.compact = !m.compact
 			return -1, false
 		case "enter":
 			// Jump to selected chapter
-			return m.selectedIndex, true
-		case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
-			if chapterNum, err := strconv.Atoi(msg.String()); err == nil {
-				if chapterNum >= 1 && chapterNum <= len(m.fiction.Chapters) {
-					return chapterNum - 1, true
-				}
+			if m.rowCount() == 0 {
+				return -1, false
 			}
-			return -1, false
-		case "t", "escape":
+			m.clearFilterAfterJump()
+			return m.selectedIndex, true
+		case "t", "q":
 			// Close TOC
 			return -1, true
 		}
@@ -104,16 +319,88 @@ func (m *TOCModel) Update(msg tea.Msg) (int, bool) {
 	return -1, false
 }
 
+// markedRange is the chapters x acts on: the range being marked, or the
+// selected chapter.
+func (m *TOCModel) markedRange() (from, to int) {
+	from, to = m.selectedIndex, m.selectedIndex
+	if m.rangeStart >= 0 {
+		from, to = min(m.rangeStart, m.selectedIndex), max(m.rangeStart, m.selectedIndex)
+	}
+	return from, to
+}
+
+// toggleRead marks the selected chapters read, or unread if they all
+// already are.
+func (m *TOCModel) toggleRead() {
+	if m.markRead == nil {
+		return
+	}
+	from, to := m.markedRange()
+	var ids []int
+	allRead := true
+	for i := from; i <= to; i++ {
+		if m.rowOf(i) < 0 {
+			// Filtered out of the range
+			continue
+		}
+		ids = append(ids, m.fiction.Chapters[i].ID)
+		allRead = allRead && m.read[m.fiction.Chapters[i].ID]
+	}
+	m.rangeStart = -1
+	if len(ids) > 0 {
+		m.markRead(ids, !allRead)
+	}
+}
+
+// markReadUpTo marks every chapter before the selected one read, for
+// catching up with chapters read elsewhere or skipped.
+func (m *TOCModel) markReadUpTo() {
+	if m.markRead == nil || m.selectedIndex == 0 {
+		return
+	}
+	ids := make([]int, 0, m.selectedIndex)
+	for i := 0; i < m.selectedIndex; i++ {
+		ids = append(ids, m.fiction.Chapters[i].ID)
+	}
+	m.rangeStart = -1
+	m.markRead(ids, true)
+}
+
+// typeJumpDigit adds a digit to the chapter number being typed, moving the
+// selection to it as it goes. Digits that would run past the last chapter
+// start a new number.
+func (m *TOCModel) typeJumpDigit(digit string) {
+	next := strings.TrimLeft(m.jump+digit, "0")
+	if number, _ := strconv.Atoi(next); number > len(m.fiction.Chapters) {
+		next = strings.TrimLeft(digit, "0")
+	}
+	m.jump = next
+	m.selectJump()
+}
+
+// selectJump selects the chapter whose number is being typed, dropping the
+// filter if it hides that chapter.
+func (m *TOCModel) selectJump() {
+	if number, err := strconv.Atoi(m.jump); err == nil && number >= 1 && number <= len(m.fiction.Chapters) {
+		m.selectedIndex = number - 1
+		if m.rowOf(m.selectedIndex) < 0 {
+			m.filter, m.matches = "", nil
+		}
+		m.ensureVisible()
+	}
+}
+
 func (m *TOCModel) ensureVisible() {
 	// Ensure selected item is visible in viewport
-	if m.selectedIndex < m.scrollOffset {
-		m.scrollOffset = m.selectedIndex
-	} else if m.selectedIndex >= m.scrollOffset+m.viewHeight {
-		m.scrollOffset = m.selectedIndex - m.viewHeight + 1
+	row := m.rowOf(m.selectedIndex)
+	if row < m.scrollOffset {
+		m.scrollOffset = row
+	} else if row >= m.scrollOffset+m.viewHeight {
+		m.scrollOffset = row - m.viewHeight + 1
 	}
 	
 	// Ensure scroll offset is within bounds
-	m.scrollOffset = max(0, min(m.scrollOffset, len(m.fiction.Chapters)-m.viewHeight))
+	m.scrollOffset = max(0, min(m.scrollOffset, m.rowCount()-m.viewHeight))
 }
 
 func (m *TOCModel) View() string {
@@ -133,21 +420,41 @@ func (m *TOCModel) View() string {
 	
 	// Calculate visible range
 	start := m.scrollOffset
-	end := min(start+m.viewHeight, len(m.fiction.Chapters))
+	end := min(start+m.viewHeight, m.rowCount())
 	
-	// Show scroll indicator if needed
-	if len(m.fiction.Chapters) > m.viewHeight {
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+	if m.matches != nil {
+		filterInfo := fmt.Sprintf("%d of %d chapters match “%s”", len(m.matches), len(m.fiction.Chapters), m.filter)
+		if len(m.matches) > m.viewHeight {
+			filterInfo += fmt.Sprintf(" (%d-%d)", start+1, end)
+		}
+		content.WriteString(infoStyle.Render(filterInfo))
+		content.WriteString("\n")
+	} else if len(m.fiction.Chapters) > m.viewHeight {
+		// Show scroll indicator if needed
 		scrollInfo := fmt.Sprintf("(%d-%d of %d chapters)", 
 			start+1, end, len(m.fiction.Chapters))
-		infoStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240")).
-			Italic(true)
 		content.WriteString(infoStyle.Render(scrollInfo))
 		content.WriteString("\n")
 	}
 	
+	if len(m.cached) > 0 {
+		cachedCount := 0
+		for _, chapter := range m.fiction.Chapters {
+			if m.cached[chapter.ID] {
+				cachedCount++
+			}
+		}
+		offlineInfo := fmt.Sprintf("↓ available offline (%d/%d)", cachedCount, len(m.fiction.Chapters))
+		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true).Render(offlineInfo))
+		content.WriteString("\n")
+	}
+	
 	// Chapter list
-	for i := start; i < end; i++ {
+	for row := start; row < end; row++ {
+		i := m.chapterAt(row)
 		chapter := m.fiction.Chapters[i]
 		
 		// Determine prefix and styling
@@ -167,14 +474,17 @@ func (m *TOCModel) View() string {
 			style = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("170")).
 				Bold(true)
-		} else if i == m.selectedIndex {
-			// Selected for navigation
+		} else if i == m.selectedIndex || m.inRange(i) {
+			// Selected for navigation, or in the range being marked
 			prefix = "● "
 			style = lipgloss.NewStyle().
 				Background(lipgloss.Color("235"))
 		} else {
 			prefix = "  "
 			style = lipgloss.NewStyle()
+			if m.offline && !m.cached[chapter.ID] {
+				style = style.Foreground(lipgloss.Color("240"))
+			}
 		}
 		
 		// Format chapter number
@@ -183,19 +493,43 @@ func (m *TOCModel) View() string {
 			number = fmt.Sprintf(" %d", i+1)
 		}
 		
-		line := fmt.Sprintf("%s%s. %s", prefix, number, chapter.Title)
-		content.WriteString(style.Render(line))
+		suffix := ""
+		if !m.compact {
+			if !chapter.Release.IsZero() {
+				suffix += " · " + chapter.Release.Local().Format("2006-01-02")
+			}
+			if words := m.words[chapter.ID]; words > 0 {
+				suffix += " · " + formatWordCount(words)
+			}
+		}
+		if m.read[chapter.ID] {
+			suffix += " ✓"
+		}
+		if m.cached[chapter.ID] {
+			suffix += " ↓"
+		}
+		if m.edited[chapter.ID] {
+			suffix += " ✎ edited"
+		}
+		line := fmt.Sprintf("%s%s. ", prefix, number)
+		title := chapter.Title
+		if m.width > 0 {
+			// Measured in columns so wide (e.g. CJK) titles fit too
+			room := m.width - runewidth.StringWidth(line) - runewidth.StringWidth(suffix)
+			title = runewidth.Truncate(title, max(room, 10), "…")
+		}
+		content.WriteString(style.Render(line + title + suffix))
 		content.WriteString("\n")
 	}
 	
 	// Show scroll indicators
-	if len(m.fiction.Chapters) > m.viewHeight {
+	if m.rowCount() > m.viewHeight {
 		content.WriteString("\n")
 		hints := []string{}
 		if m.scrollOffset > 0 {
 			hints = append(hints, "↑ more above")
 		}
-		if end < len(m.fiction.Chapters) {
+		if end < m.rowCount() {
 			hints = append(hints, "↓ more below")
 		}
 		if len(hints) > 0 {
@@ -215,6 +549,32 @@ func (m *TOCModel) FooterView() string {
 	}
 	
 	infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	return infoStyle.Render("TOC: ↑↓/jk navigate • Enter jump to chapter • 1-9 quick jump • t/Esc close")
+	if m.jump != "" {
+		return infoStyle.Render(fmt.Sprintf("Go to chapter: %s_ • Enter jump • Backspace delete • any other key cancels", m.jump))
+	}
+	if m.filtering {
+		return infoStyle.Render(fmt.Sprintf("Filter: %s_ • ↑↓ select • Enter done • Esc clear", m.filter))
+	}
+	if m.rangeStart >= 0 {
+		from, to := m.markedRange()
+		return infoStyle.Render(fmt.Sprintf("Chapters %d-%d • ↑↓ extend • x mark read/unread • Esc cancel", from+1, to+1))
+	}
+	details := "v compact"
+	if m.compact {
+		details = "v dates and lengths"
+	}
+	if m.matches != nil {
+		return infoStyle.Render("TOC: ↑↓/jk navigate • Enter jump to chapter • / edit filter • Esc clear filter • x/V mark read • " + details + " • t close")
+	}
+	return infoStyle.Render("TOC: ↑↓/jk navigate • Enter jump to chapter • type a number to go to it • / filter • x/V/X mark read • " + details + " • t/Esc close")
 }
 
+
+// inRange reports whether a chapter is in the range being marked.
+func (m *TOCModel) inRange(index int) bool {
+	if m.rangeStart < 0 {
+		return false
+	}
+	from, to := m.markedRange()
+	return index >= from && index <= to
+}
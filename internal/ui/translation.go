@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/render"
+	"github.com/jackowfish/royal-road-cli/internal/translate"
+)
+
+// Translations replace the page (or sit beside it in wide terminals) until
+// dismissed.
+
+type translationState struct {
+	original   []string // Paragraphs being translated
+	translated []string
+	target     string
+	loading    bool
+	err        error
+	sideBySide bool
+	offset     int // First line shown when it doesn't fit on one screen
+}
+
+type translationMsg struct {
+	state      *translationState
+	translated []string
+	err        error
+}
+
+// startTranslation translates the selected passage into the configured
+// language.
+func (m *ReaderModel) startTranslation() tea.Cmd {
+	if m.currentChapter == nil {
+		return nil
+	}
+	start, end, ok := m.selectedPassage()
+	if !ok {
+		return nil
+	}
+	var settings config.Translation
+	if m.config != nil {
+		settings = m.config.Translation
+	}
+	translator, err := translate.New(settings)
+	if err != nil {
+		m.setCommandMessage(err.Error(), true)
+		return nil
+	}
+	if m.offline {
+		m.setCommandMessage("translation needs the network", true)
+		return nil
+	}
+
+	target := settings.TargetLanguage
+	if target == "" {
+		target = "en"
+	}
+	state := &translationState{
+		original:   strings.Split(m.passageText(start, end), "\n\n"),
+		target:     target,
+		loading:    true,
+		sideBySide: settings.Display == "side",
+	}
+	m.translation = state
+	return m.tasks.run(func(ctx context.Context) tea.Msg {
+		translated, err := translator.Translate(ctx, state.original, target)
+		return translationMsg{state: state, translated: translated, err: err}
+	})
+}
+
+// stopTasks cancels the reader's translation, export and image fetches. A
+// translation that won't arrive is closed rather than left loading.
+func (m *ReaderModel) stopTasks() {
+	m.tasks.stop()
+	if m.translation != nil && m.translation.loading {
+		m.translation = nil
+	}
+}
+
+func (m *ReaderModel) handleTranslation(msg translationMsg) {
+	if msg.state != m.translation {
+		return
+	}
+	msg.state.loading = false
+	msg.state.translated, msg.state.err = msg.translated, msg.err
+}
+
+// handleTranslationKey scrolls or dismisses the translation; other keys
+// dismiss it and then act as usual.
+func (m *ReaderModel) handleTranslationKey(key string) bool {
+	t := m.translation
+	switch key {
+	case "down", "j":
+		t.offset = min(t.offset+1, m.maxTranslationOffset())
+		return true
+	case "up", "k":
+		t.offset = max(min(t.offset, m.maxTranslationOffset())-1, 0)
+		return true
+	case "v":
+		t.sideBySide = !t.sideBySide
+		t.offset = 0
+		return true
+	case "esc", "T":
+		m.translation = nil
+		return true
+	case "ctrl+c":
+		return false
+	}
+	m.translation = nil
+	return false
+}
+
+func (m *ReaderModel) translationView() string {
+	t := m.translation
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	if t.loading {
+		return dim.Render(fmt.Sprintf("Translating to %s...", t.target))
+	}
+	if t.err != nil {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("❌ " + t.err.Error())
+	}
+
+	lines := m.translationLines()
+	// A resize can leave the offset past the end until the next key
+	offset := min(t.offset, max(len(lines)-m.linesPerPage, 0))
+	end := min(offset+m.linesPerPage, len(lines))
+	return strings.Join(lines[offset:end], "\n")
+}
+
+// translationLines lays out the translation, beside the original when asked
+// for and the terminal is wide enough.
+func (m *ReaderModel) translationLines() []string {
+	t := m.translation
+	translated := strings.Join(t.translated, "\n\n")
+	if t.sideBySide && m.termWidth >= 2*minTextWidth+3 {
+		width := (m.termWidth - 3) / 2
+		dim := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+		left := render.Wrap(strings.Join(t.original, "\n\n"), width)
+		right := render.Wrap(translated, width)
+		joined := lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(width).Render(dim.Render(left)),
+			" │ ",
+			lipgloss.NewStyle().Width(width).Render(right))
+		return strings.Split(joined, "\n")
+	}
+	var lines []string
+	margin := strings.Repeat(" ", m.leftMargin())
+	for _, line := range strings.Split(render.Wrap(translated, m.textWidth()), "\n") {
+		lines = append(lines, margin+line)
+	}
+	return lines
+}
+
+// maxTranslationOffset is the offset that shows the end of the translation.
+func (m *ReaderModel) maxTranslationOffset() int {
+	if m.translation.loading || m.translation.err != nil {
+		return 0
+	}
+	return max(len(m.translationLines())-m.linesPerPage, 0)
+}
+
+func (m *ReaderModel) translationFooter() string {
+	layout := "[v] side by side"
+	if m.translation.sideBySide {
+		layout = "[v] translation only"
+	}
+	return fmt.Sprintf("🌐 Translated to %s • [j/k] scroll • %s • esc back to the original", m.translation.target, layout)
+}
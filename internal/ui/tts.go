@@ -0,0 +1,266 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jackowfish/royal-road-cli/internal/render"
+	"github.com/jackowfish/royal-road-cli/internal/speech"
+)
+
+// Text-to-speech reads the chapter aloud a sentence at a time, dimming
+// everything but the sentence being spoken and turning pages to follow it.
+const (
+	ttsDefaultWPM = 180
+	ttsStepWPM    = 20
+)
+
+type ttsSentence struct {
+	text       string
+	start, end int // First and last content line
+}
+
+type ttsState struct {
+	engine    *speech.Engine
+	sentences []ttsSentence
+	index     int
+	wpm       int
+	paused    bool
+	voices    []string // Loaded the first time the voice is changed
+	cancel    context.CancelFunc
+	seq       int // Bumped for every sentence so a stopped one is ignored
+}
+
+type ttsDoneMsg struct {
+	reader *ReaderModel
+	seq    int
+	err    error
+}
+
+// startTTS starts reading aloud from the top of the current page.
+func (m *ReaderModel) startTTS() tea.Cmd {
+	if len(m.content) == 0 {
+		return nil
+	}
+	var name, voice string
+	if m.config != nil {
+		name, voice = m.config.Reading.TTSEngine, m.config.Reading.TTSVoice
+	}
+	engine, err := speech.New(name, voice)
+	if err != nil {
+		m.setCommandMessage(err.Error(), true)
+		return nil
+	}
+	wpm := ttsDefaultWPM
+	if m.config != nil && m.config.Reading.TTSWordsPerMinute > 0 {
+		wpm = m.config.Reading.TTSWordsPerMinute
+	}
+	m.autoScroll = nil
+	m.tts = &ttsState{engine: engine, wpm: wpm}
+	m.loadTTSSentences(m.topLine())
+	return m.speakSentence()
+}
+
+// loadTTSSentences splits the chapter into sentences, starting with the
+// first one that begins at or after fromLine.
+func (m *ReaderModel) loadTTSSentences(fromLine int) {
+	t := m.tts
+	t.sentences, t.index = nil, -1
+
+	var words []string
+	start := -1
+	finish := func(end int) {
+		if len(words) > 0 {
+			t.sentences = append(t.sentences, ttsSentence{text: strings.Join(words, " "), start: start, end: end})
+		}
+		words, start = nil, -1
+	}
+	for line, text := range m.content {
+		if strings.TrimSpace(text) == "" {
+			// Paragraphs always end a sentence
+			finish(line - 1)
+			continue
+		}
+		for _, word := range strings.Fields(render.StripANSI(text)) {
+			if strings.Trim(word, render.QuotePrefix+"*") == "" {
+				continue
+			}
+			if start < 0 {
+				start = line
+				if line >= fromLine && t.index < 0 {
+					t.index = len(t.sentences)
+				}
+			}
+			words = append(words, word)
+			if endsSentence(word) {
+				finish(line)
+			}
+		}
+	}
+	finish(len(m.content) - 1)
+	if t.index < 0 {
+		t.index = len(t.sentences)
+	}
+}
+
+// endsSentence reports whether word closes a sentence, allowing for closing
+// quotes and brackets after the punctuation.
+func endsSentence(word string) bool {
+	word = strings.TrimRight(word, `"'”’)]`)
+	return strings.HasSuffix(word, ".") || strings.HasSuffix(word, "!") ||
+		strings.HasSuffix(word, "?") || strings.HasSuffix(word, "…")
+}
+
+// speakSentence brings the current sentence into view and speaks it, moving
+// on to the next chapter after the last one.
+func (m *ReaderModel) speakSentence() tea.Cmd {
+	t := m.tts
+	if t.index >= len(t.sentences) {
+		m.setTopLine(len(m.content))
+		cmd := m.pastChapterEnd()
+		if cmd == nil {
+			// End of the book, or a prompt to answer
+			m.stopTTS()
+		}
+		return cmd
+	}
+
+	sentence := t.sentences[t.index]
+	top := m.topLine()
+	if sentence.start < top || sentence.end >= top+m.linesPerPage {
+		if !m.scrollMode && sentence.start >= top+m.linesPerPage {
+			session.pageTurned()
+		}
+		m.setTopLine(sentence.start)
+	}
+	if m.scrollMode {
+		m.refreshViewport()
+	}
+
+	if t.cancel != nil {
+		t.cancel()
+	}
+	t.seq++
+	if t.paused {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	seq, engine, wpm := t.seq, *t.engine, t.wpm
+	return func() tea.Msg {
+		return ttsDoneMsg{reader: m, seq: seq, err: engine.Speak(ctx, sentence.text, wpm)}
+	}
+}
+
+func (m *ReaderModel) handleTTSDone(msg ttsDoneMsg) tea.Cmd {
+	t := m.tts
+	if msg.reader != m || t == nil || msg.seq != t.seq || m.loading {
+		return nil
+	}
+	if msg.err != nil && !errors.Is(msg.err, context.Canceled) {
+		m.stopTTS()
+		m.setCommandMessage(msg.err.Error(), true)
+		return nil
+	}
+	// Listening counts as reading time
+	session.touch()
+	t.index++
+	return m.speakSentence()
+}
+
+// resumeTTSAfterLoad carries on reading at the top of a newly loaded chapter.
+func (m *ReaderModel) resumeTTSAfterLoad() tea.Cmd {
+	if m.tts == nil {
+		return nil
+	}
+	m.loadTTSSentences(m.topLine())
+	return m.speakSentence()
+}
+
+// stopTTS stops speaking, leaving the reader where it got to, and remembers
+// the speed and voice.
+func (m *ReaderModel) stopTTS() {
+	t := m.tts
+	if t == nil {
+		return
+	}
+	if t.cancel != nil {
+		t.cancel()
+	}
+	m.tts = nil
+	if m.scrollMode {
+		m.refreshViewport()
+	}
+	if m.config == nil {
+		return
+	}
+	reading := &m.config.Reading
+	savedWPM := reading.TTSWordsPerMinute
+	if savedWPM <= 0 {
+		savedWPM = ttsDefaultWPM
+	}
+	if t.wpm != savedWPM || t.engine.Voice != reading.TTSVoice {
+		reading.TTSWordsPerMinute = t.wpm
+		reading.TTSVoice = t.engine.Voice
+		m.config.Save()
+	}
+}
+
+// handleTTSKey works the player: pause, skip, speed and voice. Keys it
+// doesn't use act as usual, so p, h and l still turn pages. Each change
+// restarts the sentence; Speak kills the engine it replaces.
+func (m *ReaderModel) handleTTSKey(key string) (tea.Cmd, bool) {
+	t := m.tts
+	switch key {
+	case "S", "esc":
+		m.stopTTS()
+		return nil, true
+	case " ":
+		t.paused = !t.paused
+	case "right":
+		t.index = min(t.index+1, len(t.sentences))
+	case "left":
+		t.index = max(t.index-1, 0)
+	case ">", ".":
+		t.wpm = min(t.wpm+ttsStepWPM, 500)
+	case "<", ",":
+		t.wpm = max(t.wpm-ttsStepWPM, 80)
+	case "V":
+		if t.voices == nil {
+			voices, err := t.engine.Voices()
+			if err != nil || len(voices) == 0 {
+				m.setCommandMessage(fmt.Sprintf("couldn't list %s voices", t.engine.Name), true)
+				return nil, true
+			}
+			t.voices = voices
+		}
+		t.engine.Voice = speech.NextVoice(t.voices, t.engine.Voice)
+	default:
+		return nil, false
+	}
+	// Start the sentence over with the new settings
+	return m.speakSentence(), true
+}
+
+// ttsSentenceLines returns the lines of the sentence being spoken.
+func (m *ReaderModel) ttsSentenceLines() (start, end int, ok bool) {
+	t := m.tts
+	if t == nil || t.index < 0 || t.index >= len(t.sentences) {
+		return 0, 0, false
+	}
+	return t.sentences[t.index].start, t.sentences[t.index].end, true
+}
+
+func (m *ReaderModel) ttsFooter() string {
+	t := m.tts
+	state := "🔊 Reading aloud"
+	if t.paused {
+		state = "⏸ Paused"
+	}
+	return fmt.Sprintf("%s • %s, %s, %d wpm • sentence %d/%d • [space] pause • [←/→] sentence • [</>] speed • [V] voice • [S] stop",
+		state, t.engine.Name, speech.VoiceName(t.engine.Voice), t.wpm, min(t.index+1, len(t.sentences)), len(t.sentences))
+}
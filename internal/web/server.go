@@ -0,0 +1,256 @@
+// Package web serves the offline library as plain HTML pages so cached
+// fictions can be read from a phone or tablet on the same network. Reading
+// positions are written back to the same history the terminal reader uses.
+package web
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/export"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// Server renders cached fictions. Only the cache is read; nothing is fetched
+// from Royal Road.
+type Server struct {
+	store *cache.Store
+	token string     // Sent by the chapter pages with each progress update
+	mu    sync.Mutex // serializes config read-modify-write cycles from progress updates
+}
+
+// NewServer makes a server with a fresh secret for its progress updates, so
+// that a page on another site can't post to /progress and move the reading
+// position.
+func NewServer(store *cache.Store) (*Server, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	return &Server{store: store, token: hex.EncodeToString(token)}, nil
+}
+
+// Handler routes:
+//
+//	/                           library
+//	/fiction/<id>               chapter list
+//	/fiction/<id>/<n>           chapter n (1-based)
+//	/progress                   POST target for reading positions, from chapter pages only
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleLibrary)
+	mux.HandleFunc("/fiction/", s.handleFiction)
+	mux.HandleFunc("/progress", s.handleProgress)
+	return mux
+}
+
+type libraryItem struct {
+	ID       int
+	Title    string
+	Author   string
+	Cached   int
+	Total    int
+	Progress string // e.g. "Ch. 12" for fictions in the history
+	Continue int    // 1-based chapter to continue from, 0 if not started
+	lastRead string
+}
+
+func (s *Server) handleLibrary(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	fictionIDs, err := s.store.Fictions()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cfg, _ := config.Load()
+
+	var items []libraryItem
+	for _, fictionID := range fictionIDs {
+		fiction, err := s.store.LoadFiction(fictionID)
+		if err != nil {
+			continue
+		}
+		item := libraryItem{
+			ID:     fiction.ID,
+			Title:  fiction.Title,
+			Author: fiction.Author.Name,
+			Cached: len(s.store.CachedChapters(fictionID)),
+			Total:  len(fiction.Chapters),
+		}
+		if entry := cfg.GetEntry(strconv.Itoa(fictionID)); entry != nil && entry.LastRead != "" {
+			item.Continue = entry.CurrentChapter + 1
+			item.Progress = "Ch. " + strconv.Itoa(item.Continue)
+			item.lastRead = entry.LastRead
+		}
+		items = append(items, item)
+	}
+	// Most recently read first, then unread fictions by title
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].lastRead != items[j].lastRead {
+			return items[i].lastRead > items[j].lastRead
+		}
+		return items[i].Title < items[j].Title
+	})
+
+	render(w, libraryTemplate, items)
+}
+
+func (s *Server) handleFiction(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/fiction/"), "/"), "/")
+	fictionID, err := strconv.Atoi(parts[0])
+	if err != nil || len(parts) > 2 {
+		http.NotFound(w, r)
+		return
+	}
+	fiction, err := s.store.LoadFiction(fictionID)
+	if err != nil {
+		http.Error(w, "fiction is not in the offline cache", http.StatusNotFound)
+		return
+	}
+
+	if len(parts) == 1 {
+		s.fictionPage(w, fiction)
+		return
+	}
+	number, err := strconv.Atoi(parts[1])
+	if err != nil || number < 1 || number > len(fiction.Chapters) {
+		http.NotFound(w, r)
+		return
+	}
+	s.chapterPage(w, fiction, number-1)
+}
+
+type chapterLink struct {
+	Number  int
+	Title   string
+	Cached  bool
+	Current bool
+}
+
+func (s *Server) fictionPage(w http.ResponseWriter, fiction *royalroad.Fiction) {
+	cfg, _ := config.Load()
+	current := -1
+	if entry := cfg.GetEntry(strconv.Itoa(fiction.ID)); entry != nil && entry.LastRead != "" {
+		current = entry.CurrentChapter
+	}
+
+	cached := s.store.CachedChapters(fiction.ID)
+	links := make([]chapterLink, len(fiction.Chapters))
+	for i, chapter := range fiction.Chapters {
+		links[i] = chapterLink{
+			Number:  i + 1,
+			Title:   chapter.Title,
+			Cached:  cached[chapter.ID],
+			Current: i == current,
+		}
+	}
+
+	render(w, fictionTemplate, map[string]interface{}{
+		"Fiction":  fiction,
+		"Chapters": links,
+		"Continue": current + 1,
+	})
+}
+
+func (s *Server) chapterPage(w http.ResponseWriter, fiction *royalroad.Fiction, index int) {
+	info := fiction.Chapters[index]
+	chapter, err := s.store.LoadChapter(fiction.ID, info.ID)
+	if err != nil {
+		http.Error(w, "chapter is not in the offline cache; download it with 'royal-road-cli download "+strconv.Itoa(fiction.ID)+"'", http.StatusNotFound)
+		return
+	}
+
+	// Reopening the chapter that was last read resumes at the saved position
+	var resume float64
+	cfg, _ := config.Load()
+	if entry := cfg.GetEntry(strconv.Itoa(fiction.ID)); entry != nil && entry.CurrentChapter == index {
+		resume = entry.ChapterProgress
+	}
+
+	data := map[string]interface{}{
+		"Fiction":  fiction,
+		"Number":   index + 1,
+		"Title":    info.Title,
+		"PreNote":  chapter.PreNote,
+		"Content":  template.HTML(export.SanitizeHTML(chapter.Content)),
+		"PostNote": chapter.PostNote,
+		"Resume":   resume,
+		"Token":    s.token,
+	}
+	if index > 0 {
+		data["Previous"] = index
+	}
+	if index < len(fiction.Chapters)-1 {
+		data["Next"] = index + 2
+	}
+	render(w, chapterTemplate, data)
+}
+
+// handleProgress records how far into a chapter the browser has scrolled.
+func (s *Server) handleProgress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.FormValue("token")), []byte(s.token)) != 1 {
+		http.Error(w, "missing or wrong token; reload the chapter", http.StatusForbidden)
+		return
+	}
+	fictionID, err1 := strconv.Atoi(r.FormValue("fiction"))
+	number, err2 := strconv.Atoi(r.FormValue("chapter"))
+	progress, err3 := strconv.ParseFloat(r.FormValue("progress"), 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		http.Error(w, "fiction, chapter and progress are required", http.StatusBadRequest)
+		return
+	}
+
+	fiction, err := s.store.LoadFiction(fictionID)
+	if err != nil || number < 1 || number > len(fiction.Chapters) {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cfg.UpdateReadingProgress(config.ReadingEntry{
+		FictionID:       strconv.Itoa(fiction.ID),
+		FictionTitle:    fiction.Title,
+		Author:          fiction.Author.Name,
+		CurrentChapter:  number - 1,
+		ChapterTitle:    fiction.Chapters[number-1].Title,
+		ChapterProgress: min(max(progress, 0), 1),
+		LastRead:        time.Now().Format(config.TimeLayout),
+		TotalChapters:   len(fiction.Chapters),
+	})
+	if err := cfg.Save(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func render(w http.ResponseWriter, tmpl *template.Template, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tmpl.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
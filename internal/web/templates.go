@@ -0,0 +1,110 @@
+package web
+
+import "html/template"
+
+// pageHead is shared by every page: readable on a phone, dark to match the
+// terminal reader.
+const pageHead = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{block "title" .}}Library{{end}} · royal-road-cli</title>
+<style>
+body { background: #1c1c1c; color: #ddd; font: 1.1rem/1.6 Georgia, serif; max-width: 40em; margin: 0 auto; padding: 1em; }
+a { color: #d75fd7; text-decoration: none; }
+h1, h2 { color: #d75fd7; line-height: 1.25; }
+.dim { color: #888; }
+.note { border-left: 3px solid #585858; padding-left: 1em; color: #aaa; }
+.nav { display: flex; justify-content: space-between; margin: 2em 0; font-family: sans-serif; }
+ul.list { list-style: none; padding: 0; }
+ul.list li { padding: .4em 0; border-bottom: 1px solid #333; }
+li.current a { font-weight: bold; }
+</style>
+</head>
+<body>
+`
+
+const pageFoot = `
+</body>
+</html>
+`
+
+// page wraps body in the shared head and foot. title fills the block in
+// pageHead; it is parsed separately because a block can't be redefined in
+// the same Parse call.
+func page(name, title, body string) *template.Template {
+	t := template.Must(template.New(name).Parse(pageHead + body + pageFoot))
+	return template.Must(t.Parse(`{{define "title"}}` + title + `{{end}}`))
+}
+
+var libraryTemplate = page("library", "Library", `
+<h1>📚 Library</h1>
+{{if not .}}<p class="dim">Nothing downloaded yet. Use <code>royal-road-cli download [fiction-id]</code> first.</p>{{end}}
+<ul class="list">
+{{range .}}<li>
+<a href="/fiction/{{.ID}}">{{.Title}}</a> <span class="dim">by {{.Author}}</span><br>
+<span class="dim">{{.Cached}}/{{.Total}} chapters offline{{if .Progress}} · at {{.Progress}}{{end}}</span>
+{{if .Continue}} · <a href="/fiction/{{.ID}}/{{.Continue}}">continue ›</a>{{end}}
+</li>
+{{end}}</ul>
+`)
+
+var fictionTemplate = page("fiction", "{{.Fiction.Title}}", `
+<p><a href="/">‹ Library</a></p>
+<h1>{{.Fiction.Title}}</h1>
+<p class="dim">by {{.Fiction.Author.Name}}</p>
+{{if .Continue}}<p><a href="/fiction/{{.Fiction.ID}}/{{.Continue}}">Continue at chapter {{.Continue}} ›</a></p>{{end}}
+<ul class="list">
+{{range .Chapters}}<li{{if .Current}} class="current"{{end}}>
+{{if .Cached}}<a href="/fiction/{{$.Fiction.ID}}/{{.Number}}">{{.Number}}. {{.Title}}</a>{{else}}<span class="dim">{{.Number}}. {{.Title}} (not downloaded)</span>{{end}}
+</li>
+{{end}}</ul>
+`)
+
+// The chapter page reports the scroll position back as a fraction of the
+// chapter, the same measure the terminal reader saves, so either one can pick
+// up where the other left off.
+var chapterTemplate = page("chapter", "{{.Title}}", `
+<p><a href="/fiction/{{.Fiction.ID}}">‹ {{.Fiction.Title}}</a></p>
+<h2>{{.Number}}. {{.Title}}</h2>
+{{if .PreNote}}<div class="note">{{.PreNote}}</div>{{end}}
+<article id="chapter">{{.Content}}</article>
+{{if .PostNote}}<div class="note">{{.PostNote}}</div>{{end}}
+<div class="nav">
+<span>{{if .Previous}}<a href="/fiction/{{.Fiction.ID}}/{{.Previous}}">‹ Previous</a>{{end}}</span>
+<span>{{if .Next}}<a href="/fiction/{{.Fiction.ID}}/{{.Next}}">Next ›</a>{{end}}</span>
+</div>
+<script>
+(function () {
+  var fiction = {{.Fiction.ID}}, chapter = {{.Number}}, resume = {{.Resume}}, token = {{.Token}};
+  var article = document.getElementById("chapter");
+
+  function position() {
+    var height = article.offsetHeight || 1;
+    return Math.min(Math.max((window.scrollY - article.offsetTop) / height, 0), 1);
+  }
+
+  function save() {
+    var data = new URLSearchParams({fiction: fiction, chapter: chapter, progress: position().toFixed(3), token: token});
+    navigator.sendBeacon("/progress", data);
+  }
+
+  window.addEventListener("load", function () {
+    if (resume > 0) {
+      window.scrollTo(0, article.offsetTop + resume * article.offsetHeight);
+    }
+    save();
+  });
+
+  var timer;
+  window.addEventListener("scroll", function () {
+    clearTimeout(timer);
+    timer = setTimeout(save, 1000);
+  });
+  document.addEventListener("visibilitychange", function () {
+    if (document.visibilityState === "hidden") save();
+  });
+})();
+</script>
+`)
@@ -1,41 +1,92 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
-	"royal-road-cli/internal/config"
-	"royal-road-cli/internal/ui"
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+	"github.com/jackowfish/royal-road-cli/internal/ui"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
 )
 
+var offline bool
+var pager string
+var httpFlags network.Overrides
+
 var rootCmd = &cobra.Command{
 	Use:   "royal-road-cli",
 	Short: "A CLI client for reading Royal Road novels",
 	Long:  `A terminal-based interface for browsing and reading novels from royalroad.com`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		ui.SetOfflineMode(offline)
+		ui.SetPagerCommand(pager)
+		if httpFlags.Proxy != "" {
+			if _, err := network.ParseProxy(httpFlags.Proxy); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		for _, header := range httpFlags.Headers {
+			if name, _, ok := strings.Cut(header, ":"); !ok || strings.TrimSpace(name) == "" {
+				fmt.Printf("Error: --header must look like \"Name: value\", not %q\n", header)
+				os.Exit(1)
+			}
+		}
+		network.SetOverrides(httpFlags)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		// Show interactive menu when no command is given
-		menuModel := ui.NewMenuModel()
-		p := tea.NewProgram(menuModel, tea.WithAltScreen())
-		if _, err := p.Run(); err != nil {
-			log.Fatal(err)
-		}
+		runProgram(ui.NewMenuModel())
 	},
 }
 
 var readCmd = &cobra.Command{
-	Use:   "read [fiction-id]",
-	Short: "Read a fiction by ID",
-	Args:  cobra.ExactArgs(1),
+	Use:   "read [fiction-id | url | title]",
+	Short: "Read a fiction by ID, URL or title",
+	Long: `Read a fiction by ID, Royal Road URL or title. Titles are looked up in
+your reading history first, then with a site search; when several fictions
+match you choose one from a list.`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeFictionID,
 	Run: func(cmd *cobra.Command, args []string) {
-		fictionID := args[0]
+		arg := strings.TrimSpace(strings.Join(args, " "))
+		if _, err := strconv.Atoi(arg); err == nil {
+			runProgram(ui.NewReaderModel(arg))
+			return
+		}
+		if fictionID, chapterID, ok := royalroad.ParseURL(arg); ok {
+			readerModel := ui.NewReaderModel(strconv.Itoa(fictionID))
+			if chapterID != 0 {
+				readerModel.SetStartChapterID(chapterID)
+			}
+			runProgram(readerModel)
+			return
+		}
 		
-		p := tea.NewProgram(ui.NewReaderModel(fictionID), tea.WithAltScreen())
-		if _, err := p.Run(); err != nil {
-			log.Fatal(err)
+		cfg := loadConfigOrExit()
+		picks, err := titleMatches(cfg, arg)
+		if err != nil {
+			fmt.Printf("Error searching for %q: %v\n", arg, err)
+			os.Exit(1)
+		}
+		switch len(picks) {
+		case 0:
+			fmt.Printf("No fiction found matching %q\n", arg)
+			os.Exit(1)
+		case 1:
+			runProgram(ui.NewReaderModel(picks[0].FictionID))
+		default:
+			runProgram(ui.NewPickModel(fmt.Sprintf("Which fiction did you mean by %q?", arg), picks))
 		}
 	},
 }
@@ -44,16 +95,22 @@ var browseCmd = &cobra.Command{
 	Use:   "browse",
 	Short: "Browse popular fictions",
 	Run: func(cmd *cobra.Command, args []string) {
-		p := tea.NewProgram(ui.NewBrowseModel(), tea.WithAltScreen())
-		if _, err := p.Run(); err != nil {
-			log.Fatal(err)
-		}
+		runProgram(ui.NewBrowseModel())
 	},
 }
 
+var continuePick bool
+
+// continuePickCount is how many recent books continue --pick offers.
+const continuePickCount = 10
+
 var continueCmd = &cobra.Command{
-	Use:   "continue",
+	Use:   "continue [n]",
 	Short: "Continue reading your last book",
+	Long: `Continue reading the book you read most recently. Give a number to continue
+the n-th most recent unfinished book instead, or use --pick to choose from the
+last few you've read.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg, err := config.Load()
 		if err != nil {
@@ -61,7 +118,37 @@ var continueCmd = &cobra.Command{
 			os.Exit(1)
 		}
 		
+		if continuePick {
+			entries := cfg.ContinueEntries()
+			if len(entries) == 0 {
+				fmt.Println("No books in progress. Use 'royal-road-cli' to start reading.")
+				os.Exit(1)
+			}
+			picks := make([]ui.PickItem, 0, continuePickCount)
+			for i, entry := range entries {
+				if i == continuePickCount {
+					break
+				}
+				picks = append(picks, ui.PickItem{
+					FictionID: entry.FictionID,
+					Label:     fmt.Sprintf("%d. %s", i+1, entry.FictionTitle),
+					Detail:    fmt.Sprintf("Chapter %d/%d: %s • last read %s", entry.CurrentChapter+1, entry.TotalChapters, entry.ChapterTitle, entry.LastRead),
+				})
+			}
+			runProgram(ui.NewPickModel("Continue which book?", picks))
+			return
+		}
+		
 		lastEntry := cfg.GetLastReadEntry()
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			entries := cfg.ContinueEntries()
+			if err != nil || n < 1 || n > len(entries) {
+				fmt.Printf("Invalid book number: %s (%d books in progress)\n", args[0], len(entries))
+				os.Exit(1)
+			}
+			lastEntry = &entries[n-1]
+		}
 		if lastEntry == nil {
 			fmt.Println("No reading history found. Use 'royal-road-cli' to start reading.")
 			os.Exit(1)
@@ -70,28 +157,79 @@ var continueCmd = &cobra.Command{
 		fmt.Printf("Continuing: %s by %s\n", lastEntry.FictionTitle, lastEntry.Author)
 		fmt.Printf("Chapter %d/%d: %s\n\n", lastEntry.CurrentChapter+1, lastEntry.TotalChapters, lastEntry.ChapterTitle)
 		
+		// The reader restores the saved chapter and page itself
 		readerModel := ui.NewReaderModel(lastEntry.FictionID)
-		readerModel.SetStartChapter(lastEntry.CurrentChapter)
 		
-		p := tea.NewProgram(readerModel, tea.WithAltScreen())
-		if _, err := p.Run(); err != nil {
-			log.Fatal(err)
-		}
+		runProgram(readerModel)
 	},
 }
 
+var searchJSON bool
+
 var searchCmd = &cobra.Command{
-	Use:   "search",
+	Use:   "search [query]",
 	Short: "Search for fictions by title",
+	Long: `Search for fictions by title. Without a query the interactive search screen
+is opened; with a query the results are printed to stdout as a table, or as
+JSON with --json.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		p := tea.NewProgram(ui.NewSearchModel(), tea.WithAltScreen())
-		if _, err := p.Run(); err != nil {
-			log.Fatal(err)
+		if len(args) == 0 {
+			runProgram(ui.NewSearchModel())
+			return
+		}
+		
+		query := strings.Join(args, " ")
+		results, err := network.NewClient(nil).SearchFictions(context.Background(), query)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error searching: %v\n", err)
+			os.Exit(1)
 		}
+		
+		if searchJSON {
+			if results == nil {
+				results = []royalroad.SearchFiction{}
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(results); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding results: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTITLE\tAUTHOR\tRATING\tCHAPTERS\tFOLLOWERS")
+		for _, f := range results {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%.2f\t%d\t%d\n",
+				f.ID, f.Title, f.Author, f.Stats.Rating, f.Stats.Chapters, f.Stats.Followers)
+		}
+		w.Flush()
 	},
 }
 
+// runProgram runs a TUI screen full-screen and prints the reading session
+// summary once the user quits.
+func runProgram(model tea.Model) {
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		log.Fatal(err)
+	}
+	
+	if summary := ui.EndSession(); summary != "" {
+		fmt.Println(summary)
+	}
+}
+
 func init() {
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Read from the local chapter cache only")
+	rootCmd.PersistentFlags().StringVar(&pager, "pager", "", "Read chapters in an external pager, e.g. \"less -R\"")
+	rootCmd.PersistentFlags().StringVar(&httpFlags.UserAgent, "user-agent", "", "User-Agent header for requests to Royal Road")
+	rootCmd.PersistentFlags().StringVar(&httpFlags.Proxy, "proxy", "", "Send requests through an http://, https:// or socks5:// proxy")
+	rootCmd.PersistentFlags().StringArrayVar(&httpFlags.Headers, "header", nil, "Extra request header, e.g. \"Accept-Language: en\" (repeatable)")
+	searchCmd.Flags().BoolVar(&searchJSON, "json", false, "Print results as JSON")
+	continueCmd.Flags().BoolVar(&continuePick, "pick", false, "Choose from your most recently read books")
+	
 	rootCmd.AddCommand(readCmd)
 	rootCmd.AddCommand(browseCmd)
 	rootCmd.AddCommand(continueCmd)
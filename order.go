@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+var orderCmd = &cobra.Command{
+	Use:   "order",
+	Short: "Manage reading orders for linked series and side stories",
+}
+
+var orderNewCmd = &cobra.Command{
+	Use:   "new [name] [fiction-id...]",
+	Short: "Create a reading order from fiction IDs in the given order",
+	Args:  cobra.MinimumNArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			// The order's name comes first
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeFictionIDs(cmd, args[1:], toComplete)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, fictionID := range args[1:] {
+			if _, err := strconv.Atoi(fictionID); err != nil {
+				fmt.Printf("Invalid fiction ID: %s\n", fictionID)
+				os.Exit(1)
+			}
+		}
+
+		cfg := loadConfigOrExit()
+		client := network.NewClient(cfg)
+
+		order := config.ReadingOrder{Name: args[0]}
+		for _, fictionID := range args[1:] {
+			order.Entries = append(order.Entries, config.ReadingOrderItem{
+				FictionID: fictionID,
+				Title:     lookupFictionTitle(cfg, client, fictionID),
+			})
+		}
+
+		cfg.AddReadingOrder(order)
+		if err := cfg.Save(); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Saved reading order %q with %d fictions\n", order.Name, len(order.Entries))
+	},
+}
+
+var orderListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved reading orders",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfigOrExit()
+		if len(cfg.ReadingOrders) == 0 {
+			fmt.Println("No reading orders saved. Use 'royal-road-cli order new' or 'order import'.")
+			return
+		}
+		for _, order := range cfg.ReadingOrders {
+			fmt.Printf("%s\n", order.Name)
+			for i, item := range order.Entries {
+				fmt.Printf("  %d. %s (%s)\n", i+1, item.Title, item.FictionID)
+			}
+		}
+	},
+}
+
+var orderFormat string
+var orderOutput string
+
+var orderExportCmd = &cobra.Command{
+	Use:   "export [name]",
+	Short: "Export a reading order as JSON or Markdown",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfigOrExit()
+		order := cfg.GetReadingOrder(args[0])
+		if order == nil {
+			fmt.Printf("No reading order named %q\n", args[0])
+			os.Exit(1)
+		}
+
+		var data []byte
+		switch orderFormat {
+		case "json":
+			encoded, err := json.MarshalIndent(order, "", "  ")
+			if err != nil {
+				fmt.Printf("Error encoding reading order: %v\n", err)
+				os.Exit(1)
+			}
+			data = append(encoded, '\n')
+		case "md", "markdown":
+			data = []byte(order.Markdown())
+		default:
+			fmt.Printf("Unknown format %q (use json or md)\n", orderFormat)
+			os.Exit(1)
+		}
+
+		if orderOutput == "" || orderOutput == "-" {
+			os.Stdout.Write(data)
+			return
+		}
+		if err := os.WriteFile(orderOutput, data, 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", orderOutput, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Exported %q to %s\n", order.Name, orderOutput)
+	},
+}
+
+var orderImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import a reading order manifest (JSON or Markdown)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			fmt.Printf("Error reading %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+
+		order, err := config.ParseReadingOrder(data)
+		if err != nil {
+			fmt.Printf("Error parsing %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+
+		cfg := loadConfigOrExit()
+		client := network.NewClient(cfg)
+		for i, item := range order.Entries {
+			if item.Title == "" {
+				order.Entries[i].Title = lookupFictionTitle(cfg, client, item.FictionID)
+			}
+		}
+
+		cfg.AddReadingOrder(*order)
+		if err := cfg.Save(); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Imported reading order %q with %d fictions\n", order.Name, len(order.Entries))
+	},
+}
+
+func loadConfigOrExit() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+// lookupFictionTitle prefers the title recorded in history and only hits the
+// site for fictions that have never been opened.
+func lookupFictionTitle(cfg *config.Config, client *royalroad.Client, fictionID string) string {
+	for _, entry := range cfg.ReadingHistory {
+		if entry.FictionID == fictionID {
+			return entry.FictionTitle
+		}
+	}
+
+	id, err := strconv.Atoi(fictionID)
+	if err != nil {
+		return fictionID
+	}
+	fiction, err := client.GetFiction(context.Background(), id)
+	if err != nil || fiction.Title == "" {
+		return fictionID
+	}
+	return fiction.Title
+}
+
+func init() {
+	orderExportCmd.Flags().StringVarP(&orderFormat, "format", "f", "json", "Output format: json or md")
+	orderExportCmd.Flags().StringVarP(&orderOutput, "output", "o", "", "Output file (default stdout)")
+
+	orderCmd.AddCommand(orderNewCmd)
+	orderCmd.AddCommand(orderListCmd)
+	orderCmd.AddCommand(orderExportCmd)
+	orderCmd.AddCommand(orderImportCmd)
+	rootCmd.AddCommand(orderCmd)
+}
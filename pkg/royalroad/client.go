@@ -1,6 +1,8 @@
-package api
+package royalroad
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,47 +15,214 @@ import (
 	"github.com/PuerkitoBio/goquery"
 )
 
-const baseURL = "https://www.royalroad.com"
+// DefaultBaseURL is the site a Client talks to unless WithBaseURL is given.
+const DefaultBaseURL = "https://www.royalroad.com"
 
+// DefaultUserAgent identifies the client unless WithUserAgent is given; Go's
+// own default is often blocked.
+const DefaultUserAgent = "royal-road-cli (+https://github.com/jackowfish/royal-road-cli)"
+
+// Client scrapes fictions and chapters from Royal Road. It is safe for
+// concurrent use.
 type Client struct {
 	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+	headers    http.Header
+	cache      *HTTPCache
+	limiter    *RateLimiter
+	retry      RetryPolicy
+	jar        http.CookieJar
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces the underlying HTTP client, e.g. to add a proxy or a
+// custom transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets the timeout for each request. The default is 30 seconds.
+// A client given with WithHTTPClient is copied rather than changed.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		httpClient := *c.httpClient
+		httpClient.Timeout = timeout
+		c.httpClient = &httpClient
+	}
+}
+
+// WithBaseURL points the client at another host, such as a mirror or a test
+// server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
 }
 
-func NewClient() *Client {
-	return &Client{
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithHeader adds a header to every request, e.g. Accept-Language.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Add(key, value)
+	}
+}
+
+// WithCookieJar keeps the cookies the site sets, and sends them back, in jar.
+// It applies to the HTTP client from WithHTTPClient too.
+func WithCookieJar(jar http.CookieJar) Option {
+	return func(c *Client) {
+		c.jar = jar
+	}
+}
+
+// NewClient creates a client with a 30 second timeout talking to
+// DefaultBaseURL as DefaultUserAgent, adjusted by any options.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		baseURL:   DefaultBaseURL,
+		userAgent: DefaultUserAgent,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	if c.jar != nil {
+		httpClient := *c.httpClient
+		httpClient.Jar = c.jar
+		c.httpClient = &httpClient
+	}
+	return c
 }
 
-func (c *Client) get(path string) (*goquery.Document, error) {
-	resp, err := c.httpClient.Get(baseURL + path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+// do sends a request, letting prepare add headers first if it isn't nil.
+// Transient failures are retried according to the client's RetryPolicy. Under
+// CacheOnly nothing is sent.
+func (c *Client) do(ctx context.Context, method, path string, prepare func(*http.Request)) (*http.Response, error) {
+	return c.doURL(ctx, method, c.baseURL+path, prepare)
+}
+
+// doURL is do for an absolute URL, which may be on another host.
+func (c *Client) doURL(ctx context.Context, method, rawURL string, prepare func(*http.Request)) (*http.Response, error) {
+	if cacheOnly(ctx) {
+		return nil, fmt.Errorf("%w: %s isn't cached", ErrOffline, rawURL)
 	}
-	defer resp.Body.Close()
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		for key, values := range c.headers {
+			req.Header[key] = values
+		}
+		if c.userAgent != "" {
+			req.Header.Set("User-Agent", c.userAgent)
+		}
+		if prepare != nil {
+			prepare(req)
+		}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, &RequestError{Op: "make request", URL: req.URL.String(), Err: err}
+		}
+		resp, err := c.httpClient.Do(req)
+		if attempt >= c.retry.MaxRetries || !transient(ctx, resp, err) {
+			if err != nil {
+				return nil, &RequestError{Op: "make request", URL: req.URL.String(), Err: err}
+			}
+			return resp, nil
+		}
+
+		delay := c.retry.delay(attempt, resp)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, &RequestError{Op: "make request", URL: req.URL.String(), Err: err}
+		}
 	}
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (c *Client) get(ctx context.Context, path string) (*goquery.Document, error) {
+	body, err := c.fetch(ctx, path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		return nil, fmt.Errorf("%w: failed to parse HTML: %v", ErrParse, err)
 	}
 
 	return doc, nil
 }
 
-func (c *Client) GetFiction(id int) (*Fiction, error) {
+// fetch downloads a page, going through the HTTP cache if there is one.
+func (c *Client) fetch(ctx context.Context, path string) ([]byte, error) {
+	var cached *cachedPage
+	if c.cache != nil {
+		cached = c.cache.load(c.baseURL + path)
+		if cached != nil && (cached.fresh(c.cache.ttl) && !mustRevalidate(ctx) || cacheOnly(ctx)) {
+			return cached.body, nil
+		}
+	}
+
+	var prepare func(*http.Request)
+	if cached != nil {
+		prepare = cached.conditional
+	}
+	resp, err := c.do(ctx, http.MethodGet, path, prepare)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		cached.StoredAt = time.Now()
+		c.cache.store(cached)
+		return cached.body, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		start, _ := io.ReadAll(io.LimitReader(resp.Body, challengePeek))
+		return nil, statusError(resp, start)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &RequestError{Op: "read response body", URL: resp.Request.URL.String(), Err: err}
+	}
+	if c.cache != nil {
+		c.cache.store(&cachedPage{
+			URL:          c.baseURL + path,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			StoredAt:     time.Now(),
+			body:         body,
+		})
+	}
+	return body, nil
+}
+
+// GetFiction fetches a fiction's page: metadata, stats and the chapter list.
+// A fiction that doesn't exist yields an error matching ErrNotFound.
+func (c *Client) GetFiction(ctx context.Context, id int) (*Fiction, error) {
 	path := fmt.Sprintf("/fiction/%d", id)
-	doc, err := c.get(path)
+	doc, err := c.get(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get fiction page: %w", err)
 	}
@@ -61,9 +230,50 @@ func (c *Client) GetFiction(id int) (*Fiction, error) {
 	return c.parseFiction(doc, id)
 }
 
-func (c *Client) GetChapter(chapterID int) (*Chapter, error) {
+// FictionExists checks whether a fiction ID exists with a lightweight HEAD
+// request instead of downloading and parsing the whole fiction page.
+func (c *Client) FictionExists(ctx context.Context, id int) (bool, error) {
+	resp, err := c.do(ctx, http.MethodHead, fmt.Sprintf("/fiction/%d", id), nil)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, statusError(resp, nil)
+	}
+}
+
+// GetImage downloads an image linked from a chapter, usually from another
+// host, reading at most maxBytes of it. It goes through the client's
+// transport, headers and retries like every other request, but not the HTTP
+// cache.
+func (c *Client) GetImage(ctx context.Context, imageURL string, maxBytes int64) ([]byte, error) {
+	resp, err := c.doURL(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp, nil)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes))
+	if err != nil {
+		return nil, &RequestError{Op: "read response body", URL: resp.Request.URL.String(), Err: err}
+	}
+	return data, nil
+}
+
+// GetChapter fetches a chapter's content and author notes by chapter ID.
+func (c *Client) GetChapter(ctx context.Context, chapterID int) (*Chapter, error) {
 	path := fmt.Sprintf("/fiction/0/_/chapter/%d/_", chapterID)
-	doc, err := c.get(path)
+	doc, err := c.get(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chapter page: %w", err)
 	}
@@ -71,9 +281,10 @@ func (c *Client) GetChapter(chapterID int) (*Chapter, error) {
 	return c.parseChapter(doc)
 }
 
-func (c *Client) GetPopularFictions() ([]PopularFiction, error) {
+// GetPopularFictions lists the best rated fictions.
+func (c *Client) GetPopularFictions(ctx context.Context) ([]PopularFiction, error) {
 	path := "/fictions/best-rated"
-	doc, err := c.get(path)
+	doc, err := c.get(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get popular fictions: %w", err)
 	}
@@ -81,9 +292,10 @@ func (c *Client) GetPopularFictions() ([]PopularFiction, error) {
 	return c.parsePopularFictions(doc)
 }
 
-func (c *Client) SearchFictions(title string) ([]SearchFiction, error) {
+// SearchFictions searches fictions by title.
+func (c *Client) SearchFictions(ctx context.Context, title string) ([]SearchFiction, error) {
 	path := fmt.Sprintf("/fictions/search?title=%s&globalFilters=true", url.QueryEscape(title))
-	doc, err := c.get(path)
+	doc, err := c.get(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search fictions: %w", err)
 	}
@@ -95,6 +307,9 @@ func (c *Client) parseFiction(doc *goquery.Document, id int) (*Fiction, error) {
 	fiction := &Fiction{ID: id}
 
 	fiction.Title = doc.Find("div.fic-title h1").Text()
+	if strings.TrimSpace(fiction.Title) == "" {
+		return nil, fmt.Errorf("%w: no fiction title found", ErrParse)
+	}
 	fiction.Image, _ = doc.Find("div.fic-header img").Attr("src")
 
 	labels := doc.Find("span.bg-blue-hoki")
@@ -220,7 +435,11 @@ func (c *Client) parseChapter(doc *goquery.Document) (*Chapter, error) {
 		chapter.PostNote = strings.TrimSpace(notes.Eq(1).Find("p").Text())
 	}
 
-	content, err := doc.Find("div.chapter-inner.chapter-content").Html()
+	contentDiv := doc.Find("div.chapter-inner.chapter-content")
+	if contentDiv.Length() == 0 {
+		return nil, fmt.Errorf("%w: no chapter content found", ErrParse)
+	}
+	content, err := contentDiv.Html()
 	if err == nil {
 		chapter.Content = strings.TrimSpace(content)
 	}
@@ -271,6 +490,9 @@ func (c *Client) parsePopularFictions(doc *goquery.Document) ([]PopularFiction,
 		fictions = append(fictions, fiction)
 	})
 
+	if len(fictions) == 0 {
+		return nil, fmt.Errorf("%w: no fictions found", ErrParse)
+	}
 	return fictions, nil
 }
 
@@ -377,6 +599,28 @@ func (c *Client) extractChapterID(url string) int {
 	return -1
 }
 
+var fictionURLRegex = regexp.MustCompile(`royalroad\.com/fiction/(\d+)(?:/[^/?#]*/chapter/(\d+))?`)
+
+// ParseURL extracts the fiction ID, and the chapter ID if present, from a
+// Royal Road URL such as
+// https://www.royalroad.com/fiction/21220/mother-of-learning/chapter/301778/1-good-morning-brother.
+// chapterID is 0 for fiction URLs.
+func ParseURL(raw string) (fictionID int, chapterID int, ok bool) {
+	matches := fictionURLRegex.FindStringSubmatch(raw)
+	if matches == nil {
+		return 0, 0, false
+	}
+
+	fictionID, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	if matches[2] != "" {
+		chapterID, _ = strconv.Atoi(matches[2])
+	}
+	return fictionID, chapterID, true
+}
+
 func parseRelativeTime(timeText string) (time.Time, error) {
 	now := time.Now()
 	
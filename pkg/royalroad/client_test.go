@@ -0,0 +1,41 @@
+package royalroad
+
+import "testing"
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		raw         string
+		wantFiction int
+		wantChapter int
+		wantOK      bool
+	}{
+		{raw: "https://www.royalroad.com/fiction/21220/mother-of-learning", wantFiction: 21220, wantOK: true},
+		{raw: "https://www.royalroad.com/fiction/21220", wantFiction: 21220, wantOK: true},
+		{raw: "royalroad.com/fiction/21220/mother-of-learning/", wantFiction: 21220, wantOK: true},
+		{
+			raw:         "https://www.royalroad.com/fiction/21220/mother-of-learning/chapter/301778/1-good-morning-brother",
+			wantFiction: 21220,
+			wantChapter: 301778,
+			wantOK:      true,
+		},
+		{raw: "https://www.royalroad.com/fiction/21220/mother-of-learning/chapter/301778", wantFiction: 21220, wantChapter: 301778, wantOK: true},
+		{raw: "  https://www.royalroad.com/fiction/21220/mother-of-learning?page=2#top ", wantFiction: 21220, wantOK: true},
+		{raw: "https://www.royalroad.com/fiction/21220/mother-of-learning/reviews", wantFiction: 21220, wantOK: true},
+		{raw: "https://www.royalroad.com/fictions/best-rated", wantOK: false},
+		{raw: "https://www.royalroad.com/profile/12345", wantOK: false},
+		{raw: "https://example.com/fiction/21220", wantOK: false},
+		{raw: "21220", wantOK: false},
+		{raw: "", wantOK: false},
+		{raw: "https://www.royalroad.com/fiction/99999999999999999999", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			fictionID, chapterID, ok := ParseURL(tt.raw)
+			if ok != tt.wantOK || fictionID != tt.wantFiction || chapterID != tt.wantChapter {
+				t.Errorf("ParseURL(%q) = %d, %d, %v, want %d, %d, %v",
+					tt.raw, fictionID, chapterID, ok, tt.wantFiction, tt.wantChapter, tt.wantOK)
+			}
+		})
+	}
+}
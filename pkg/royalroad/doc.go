@@ -0,0 +1,25 @@
+// Package royalroad is a scraper for royalroad.com. It fetches fiction
+// metadata, chapter lists and chapter content, popular lists and search
+// results, and is usable on its own without the royal-road-cli TUI:
+//
+//	client := royalroad.NewClient(royalroad.WithTimeout(10 * time.Second))
+//	fiction, err := client.GetFiction(ctx, 21220)
+//	if errors.Is(err, royalroad.ErrNotFound) {
+//		// no such fiction
+//	}
+//	chapter, err := client.GetChapter(ctx, fiction.Chapters[0].ID)
+//
+// WithRetries retries 429s, 5xx responses and timeouts with exponential
+// backoff, and WithRateLimiter spaces requests out, optionally across several
+// clients. WithHTTPCache keeps fetched pages on disk and revalidates them
+// with ETag and Last-Modified; wrap a context with Revalidate to skip its TTL.
+// WithCookieJar keeps cookies, such as a Cloudflare clearance, between
+// requests.
+//
+// Every request takes a context for cancellation and deadlines; wrap it with
+// CacheOnly to read from the HTTP cache without sending anything. Failures
+// match one of the typed errors with errors.Is: ErrNotFound, ErrRateLimited
+// and ErrChallenge for HTTP errors (a *StatusError), ErrOffline when the
+// site couldn't be reached (a *RequestError), and ErrParse when a page
+// can't be read.
+package royalroad
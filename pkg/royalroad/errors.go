@@ -0,0 +1,110 @@
+package royalroad
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNotFound matches errors for fictions or chapters that don't exist.
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited matches errors for requests Royal Road refused because
+	// too many were made.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrChallenge matches errors for requests Cloudflare answered with a
+	// challenge page instead of passing them on to the site.
+	ErrChallenge = errors.New("blocked by a Cloudflare challenge")
+	// ErrOffline matches errors for requests the site never answered: no
+	// connection, a DNS failure or a timeout, or, under CacheOnly, a page that
+	// isn't cached.
+	ErrOffline = errors.New("can't reach Royal Road")
+	// ErrParse matches errors for pages that don't have the expected layout,
+	// usually because the site changed or served an interstitial.
+	ErrParse = errors.New("unexpected page layout")
+	// ErrUnexpectedPage is the old name of ErrParse.
+	//
+	// Deprecated: use ErrParse.
+	ErrUnexpectedPage = ErrParse
+)
+
+// RequestError is returned when a request failed before the site answered,
+// or while its answer was being read. It matches ErrOffline unless the
+// request was cancelled.
+type RequestError struct {
+	Op  string // "make request" or "read response body"
+	URL string
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("failed to %s: %v", e.Op, e.Err)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RequestError) Is(target error) bool {
+	return target == ErrOffline && !errors.Is(e.Err, context.Canceled)
+}
+
+// StatusError is returned for any non-200 response. Use errors.Is with
+// ErrNotFound, ErrRateLimited or ErrChallenge to check for the common cases.
+type StatusError struct {
+	StatusCode int
+	URL        string
+	Challenge  bool // Cloudflare served a challenge rather than the page
+}
+
+func (e *StatusError) Error() string {
+	if e.Challenge {
+		return fmt.Sprintf("blocked by a Cloudflare challenge (status %d); open the page in a browser and reuse its cookies", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrChallenge:
+		return e.Challenge
+	}
+	return false
+}
+
+// challengePeek is how much of a failed response is read to recognize a
+// challenge page.
+const challengePeek = 64 << 10
+
+// statusError describes a failed response. body, the start of the response
+// if it was read, helps recognize challenge pages that lack the header.
+func statusError(resp *http.Response, body []byte) *StatusError {
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		URL:        resp.Request.URL.String(),
+		Challenge:  isChallenge(resp, body),
+	}
+}
+
+// isChallenge reports whether Cloudflare answered instead of the site: it
+// marks challenges with a cf-mitigated header, and older interstitials by
+// their page.
+func isChallenge(resp *http.Response, body []byte) bool {
+	if resp.Header.Get("Cf-Mitigated") == "challenge" {
+		return true
+	}
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+	if !strings.EqualFold(resp.Header.Get("Server"), "cloudflare") {
+		return false
+	}
+	return bytes.Contains(body, []byte("challenge-platform")) || bytes.Contains(body, []byte("<title>Just a moment...</title>"))
+}
@@ -0,0 +1,64 @@
+package royalroad
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestIsChallenge(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header map[string]string
+		body   string
+		want   bool
+	}{
+		{name: "cf-mitigated header", status: http.StatusForbidden, header: map[string]string{"Cf-Mitigated": "challenge"}, want: true},
+		{name: "cf-mitigated on any status", status: http.StatusOK, header: map[string]string{"Cf-Mitigated": "challenge"}, want: true},
+		{
+			name:   "challenge platform script",
+			status: http.StatusForbidden,
+			header: map[string]string{"Server": "cloudflare"},
+			body:   `<script src="/cdn-cgi/challenge-platform/h/b/orchestrate/jsch/v1"></script>`,
+			want:   true,
+		},
+		{
+			name:   "interstitial title",
+			status: http.StatusServiceUnavailable,
+			header: map[string]string{"Server": "Cloudflare"},
+			body:   "<html><head><title>Just a moment...</title></head></html>",
+			want:   true,
+		},
+		{
+			name:   "Cloudflare error page",
+			status: http.StatusForbidden,
+			header: map[string]string{"Server": "cloudflare"},
+			body:   "<title>Access denied</title>",
+		},
+		{
+			name:   "challenge page from another server",
+			status: http.StatusForbidden,
+			header: map[string]string{"Server": "nginx"},
+			body:   "<title>Just a moment...</title>",
+		},
+		{
+			name:   "other status",
+			status: http.StatusNotFound,
+			header: map[string]string{"Server": "cloudflare"},
+			body:   "<title>Just a moment...</title>",
+		},
+		{name: "plain forbidden", status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: make(http.Header)}
+			for key, value := range tt.header {
+				resp.Header.Set(key, value)
+			}
+			if got := isChallenge(resp, []byte(tt.body)); got != tt.want {
+				t.Errorf("isChallenge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
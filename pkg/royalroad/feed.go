@@ -0,0 +1,98 @@
+package royalroad
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// FeedItem is one chapter announced in a fiction's RSS feed.
+type FeedItem struct {
+	ChapterID int       `json:"chapterId"`
+	Title     string    `json:"title"`
+	Link      string    `json:"link"`
+	Published time.Time `json:"published"`
+}
+
+type rssDocument struct {
+	Channel struct {
+		Items []struct {
+			Title   string `xml:"title"`
+			Link    string `xml:"link"`
+			GUID    string `xml:"guid"`
+			PubDate string `xml:"pubDate"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// GetFictionFeed fetches a fiction's syndication feed, which lists only its
+// most recent chapters. It is a small XML document, much cheaper than the
+// fiction page, and is enough to tell whether anything new has been published.
+func (c *Client) GetFictionFeed(ctx context.Context, id int) ([]FeedItem, error) {
+	resp, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/fiction/syndication/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		start, _ := io.ReadAll(io.LimitReader(resp.Body, challengePeek))
+		return nil, statusError(resp, start)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &RequestError{Op: "read response body", URL: resp.Request.URL.String(), Err: err}
+	}
+	return parseFeed(body)
+}
+
+func parseFeed(data []byte) ([]FeedItem, error) {
+	var doc rssDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: invalid feed: %v", ErrParse, err)
+	}
+
+	items := make([]FeedItem, 0, len(doc.Channel.Items))
+	for _, item := range doc.Channel.Items {
+		link := item.Link
+		if link == "" {
+			link = item.GUID
+		}
+		chapterID := feedChapterID(link)
+		if chapterID == 0 {
+			continue
+		}
+		published, _ := time.Parse(time.RFC1123Z, item.PubDate)
+		if published.IsZero() {
+			published, _ = time.Parse(time.RFC1123, item.PubDate)
+		}
+		items = append(items, FeedItem{
+			ChapterID: chapterID,
+			Title:     item.Title,
+			Link:      link,
+			Published: published,
+		})
+	}
+	return items, nil
+}
+
+// Feed links use the short /fiction/chapter/<id> form rather than the full
+// chapter URL with slugs; accept both.
+var shortChapterURLRegex = regexp.MustCompile(`/fiction/chapter/(\d+)`)
+
+func feedChapterID(link string) int {
+	if _, chapterID, ok := ParseURL(link); ok && chapterID != 0 {
+		return chapterID
+	}
+	if matches := shortChapterURLRegex.FindStringSubmatch(link); matches != nil {
+		chapterID, _ := strconv.Atoi(matches[1])
+		return chapterID
+	}
+	return 0
+}
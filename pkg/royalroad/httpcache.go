@@ -0,0 +1,180 @@
+package royalroad
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// HTTPCache keeps fetched pages on disk. A page requested again within the
+// TTL is answered from disk without a request; after that it is revalidated
+// with If-None-Match and If-Modified-Since, so an unchanged page costs a 304
+// instead of a full download. It is safe for concurrent use.
+type HTTPCache struct {
+	dir string
+	ttl time.Duration
+	mu  sync.Mutex
+}
+
+// cachedPage is the metadata stored beside each page's gzipped body.
+type cachedPage struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	StoredAt     time.Time `json:"storedAt"`
+
+	body []byte
+}
+
+// NewHTTPCache stores pages under dir, serving them without revalidation for
+// ttl after they were fetched.
+func NewHTTPCache(dir string, ttl time.Duration) *HTTPCache {
+	return &HTTPCache{dir: dir, ttl: ttl}
+}
+
+// WithHTTPCache answers repeated GET requests from cache.
+func WithHTTPCache(cache *HTTPCache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+type revalidateKey struct{}
+
+// Revalidate returns a context whose requests skip the cache's TTL: cached
+// pages are still revalidated, but never served without asking the site.
+// Use it where the latest version matters, such as checking for new chapters.
+func Revalidate(ctx context.Context) context.Context {
+	return context.WithValue(ctx, revalidateKey{}, true)
+}
+
+func mustRevalidate(ctx context.Context) bool {
+	revalidate, _ := ctx.Value(revalidateKey{}).(bool)
+	return revalidate
+}
+
+type cacheOnlyKey struct{}
+
+// CacheOnly returns a context whose requests are answered from the HTTP cache
+// however old the copy, and never sent: a page that isn't cached fails with
+// an error matching ErrOffline. It lets a reader carry on while the site is
+// unreachable or blocking requests.
+func CacheOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheOnlyKey{}, true)
+}
+
+func cacheOnly(ctx context.Context) bool {
+	only, _ := ctx.Value(cacheOnlyKey{}).(bool)
+	return only
+}
+
+// Clear removes every cached page.
+func (h *HTTPCache) Clear() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return os.RemoveAll(h.dir)
+}
+
+// Prune removes pages that haven't been fetched or revalidated for maxAge,
+// keeping the cache from growing without bound.
+func (h *HTTPCache) Prune(maxAge time.Duration) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries, err := os.ReadDir(h.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	cutoff := time.Now().Add(-maxAge)
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && info.ModTime().Before(cutoff) {
+			os.Remove(filepath.Join(h.dir, entry.Name()))
+		}
+	}
+	return nil
+}
+
+func (h *HTTPCache) path(url, extension string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(h.dir, hex.EncodeToString(sum[:16])+extension)
+}
+
+// load returns the cached copy of url, or nil if there is none.
+func (h *HTTPCache) load(url string) *cachedPage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	data, err := os.ReadFile(h.path(url, ".json"))
+	if err != nil {
+		return nil
+	}
+	page := &cachedPage{}
+	if json.Unmarshal(data, page) != nil || page.URL != url {
+		return nil
+	}
+
+	file, err := os.Open(h.path(url, ".html.gz"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil
+	}
+	if page.body, err = io.ReadAll(reader); err != nil {
+		return nil
+	}
+	return page
+}
+
+func (p *cachedPage) fresh(ttl time.Duration) bool {
+	return time.Since(p.StoredAt) < ttl
+}
+
+// conditional adds the validators of the cached copy to req.
+func (p *cachedPage) conditional(req *http.Request) {
+	if p.ETag != "" {
+		req.Header.Set("If-None-Match", p.ETag)
+	}
+	if p.LastModified != "" {
+		req.Header.Set("If-Modified-Since", p.LastModified)
+	}
+}
+
+// store saves a freshly downloaded page, or restarts the TTL of a cached one
+// the site said is unchanged. Failures only cost a future download.
+func (h *HTTPCache) store(page *cachedPage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := os.MkdirAll(h.dir, 0755); err != nil {
+		return
+	}
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	writer.Write(page.body)
+	if writer.Close() != nil {
+		return
+	}
+	meta, err := json.Marshal(page)
+	if err != nil {
+		return
+	}
+	if os.WriteFile(h.path(page.URL, ".html.gz"), compressed.Bytes(), 0644) != nil {
+		return
+	}
+	os.WriteFile(h.path(page.URL, ".json"), meta, 0644)
+}
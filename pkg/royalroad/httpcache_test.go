@@ -0,0 +1,83 @@
+package royalroad
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPCache(t *testing.T) {
+	tests := []struct {
+		name         string
+		ttl          time.Duration
+		ctx          func(context.Context) context.Context
+		changed      bool // The page changes between the two fetches
+		wantRequests int32
+		want304      int32
+		wantBody     string
+	}{
+		{name: "fresh copy served without a request", ttl: time.Hour, wantRequests: 1, wantBody: "v1"},
+		{name: "stale copy revalidated with a 304", ttl: 0, wantRequests: 2, want304: 1, wantBody: "v1"},
+		{name: "stale copy replaced when the page changed", ttl: 0, changed: true, wantRequests: 2, wantBody: "v2"},
+		{name: "Revalidate skips the TTL", ttl: time.Hour, ctx: Revalidate, wantRequests: 2, want304: 1, wantBody: "v1"},
+		{name: "CacheOnly serves a stale copy", ttl: 0, ctx: CacheOnly, changed: true, wantRequests: 1, wantBody: "v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests, notModified atomic.Int32
+			var version atomic.Value
+			version.Store("v1")
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				etag := `"` + version.Load().(string) + `"`
+				if r.Header.Get("If-None-Match") == etag {
+					notModified.Add(1)
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", etag)
+				w.Write([]byte(version.Load().(string)))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithHTTPCache(NewHTTPCache(t.TempDir(), tt.ttl)))
+			if _, err := client.fetch(context.Background(), "/page"); err != nil {
+				t.Fatalf("first fetch: %v", err)
+			}
+			if tt.changed {
+				version.Store("v2")
+			}
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx(ctx)
+			}
+			body, err := client.fetch(ctx, "/page")
+			if err != nil {
+				t.Fatalf("second fetch: %v", err)
+			}
+
+			if string(body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+			if got := notModified.Load(); got != tt.want304 {
+				t.Errorf("304 responses = %d, want %d", got, tt.want304)
+			}
+		})
+	}
+}
+
+func TestHTTPCacheOnlyMiss(t *testing.T) {
+	client := NewClient(WithBaseURL("http://127.0.0.1:0"), WithHTTPCache(NewHTTPCache(t.TempDir(), time.Hour)))
+	_, err := client.fetch(CacheOnly(context.Background()), "/page")
+	if !errors.Is(err, ErrOffline) {
+		t.Fatalf("fetch of an uncached page under CacheOnly: error = %v, want ErrOffline", err)
+	}
+}
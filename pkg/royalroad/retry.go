@@ -0,0 +1,128 @@
+package royalroad
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxRetryAfter caps how long a Retry-After header can make a request wait.
+const maxRetryAfter = time.Minute
+
+// RetryPolicy controls how requests that failed for a transient reason (429,
+// a 5xx status or a timeout) are retried. Cloudflare challenges aren't.
+type RetryPolicy struct {
+	MaxRetries int           // Attempts after the first (0 disables retries)
+	BaseDelay  time.Duration // Wait before the first retry, doubled for each one after
+	MaxDelay   time.Duration // Longest wait between attempts
+}
+
+// DefaultRetryPolicy retries three times, waiting about 1, 2 and 4 seconds.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+}
+
+// WithRetries retries transient failures with exponential backoff and jitter.
+// Without it every request is tried once.
+func WithRetries(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// RateLimiter spaces requests out to at most a fixed number per second. One
+// limiter can be shared by several clients so that together they stay under
+// the limit.
+type RateLimiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time
+}
+
+// NewRateLimiter allows perSecond requests a second; 0 or less means no limit.
+func NewRateLimiter(perSecond float64) *RateLimiter {
+	if perSecond <= 0 {
+		return &RateLimiter{}
+	}
+	return &RateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// WithRateLimiter makes every request, retries included, wait its turn with
+// limiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// Wait blocks until the caller may send its next request, or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.interval == 0 {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	return sleep(ctx, time.Until(start))
+}
+
+// sleep waits for d, returning early with ctx's error if it is cancelled.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// transient reports whether a request that got resp or err is worth trying
+// again.
+func transient(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+	if resp.Header.Get("Cf-Mitigated") == "challenge" {
+		// Asking again only gets the same challenge
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// delay returns how long to wait before retry number attempt (0-based):
+// the server's Retry-After if it sent one, otherwise an exponential backoff
+// with jitter so that clients that failed together don't retry together.
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxRetryAfter)
+		}
+	}
+	backoff := p.BaseDelay << attempt
+	if p.MaxDelay > 0 && (backoff > p.MaxDelay || backoff <= 0) {
+		backoff = p.MaxDelay
+	}
+	// Somewhere between half and all of the backoff
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
@@ -0,0 +1,100 @@
+package royalroad
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// timeoutError is a net.Error, as a client timeout returns.
+type timeoutError struct{ timeout bool }
+
+func (e timeoutError) Error() string   { return "i/o timeout" }
+func (e timeoutError) Timeout() bool   { return e.timeout }
+func (e timeoutError) Temporary() bool { return false }
+
+func TestTransient(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+		header map[string]string
+		err    error
+		want   bool
+	}{
+		{name: "rate limited", status: http.StatusTooManyRequests, want: true},
+		{name: "server error", status: http.StatusInternalServerError, want: true},
+		{name: "bad gateway", status: http.StatusBadGateway, want: true},
+		{name: "ok", status: http.StatusOK},
+		{name: "not found", status: http.StatusNotFound},
+		{name: "forbidden", status: http.StatusForbidden},
+		{name: "challenge", status: http.StatusServiceUnavailable, header: map[string]string{"Cf-Mitigated": "challenge"}},
+		{name: "timeout", err: timeoutError{timeout: true}, want: true},
+		{name: "network error that isn't a timeout", err: timeoutError{}},
+		{name: "other error", err: errors.New("connection refused")},
+		{name: "cancelled", ctx: cancelled, status: http.StatusTooManyRequests},
+		{name: "cancelled timeout", ctx: cancelled, err: timeoutError{timeout: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.status, Header: make(http.Header)}
+				for key, value := range tt.header {
+					resp.Header.Set(key, value)
+				}
+			}
+			if got := transient(ctx, resp, tt.err); got != tt.want {
+				t.Errorf("transient() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		attempt    int
+		retryAfter string
+		min, max   time.Duration
+	}{
+		{name: "first retry", policy: policy, attempt: 0, min: 500 * time.Millisecond, max: time.Second},
+		{name: "doubles", policy: policy, attempt: 2, min: 2 * time.Second, max: 4 * time.Second},
+		{name: "capped by MaxDelay", policy: policy, attempt: 5, min: 2500 * time.Millisecond, max: 5 * time.Second},
+		{name: "overflowing shift", policy: policy, attempt: 70, min: 2500 * time.Millisecond, max: 5 * time.Second},
+		{name: "no MaxDelay", policy: RetryPolicy{BaseDelay: time.Second}, attempt: 6, min: 32 * time.Second, max: 64 * time.Second},
+		{name: "Retry-After", policy: policy, attempt: 0, retryAfter: "7", min: 7 * time.Second, max: 7 * time.Second},
+		{name: "Retry-After of zero", policy: policy, attempt: 2, retryAfter: "0", min: 0, max: 0},
+		{name: "Retry-After capped", policy: policy, attempt: 0, retryAfter: "3600", min: maxRetryAfter, max: maxRetryAfter},
+		{name: "negative Retry-After ignored", policy: policy, attempt: 0, retryAfter: "-1", min: 500 * time.Millisecond, max: time.Second},
+		{name: "HTTP date Retry-After ignored", policy: policy, attempt: 0, retryAfter: "Wed, 21 Oct 2015 07:28:00 GMT", min: 500 * time.Millisecond, max: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: make(http.Header)}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+			// The jitter is random, so try a few times
+			for i := 0; i < 20; i++ {
+				if got := tt.policy.delay(tt.attempt, resp); got < tt.min || got > tt.max {
+					t.Fatalf("delay(%d) = %v, want between %v and %v", tt.attempt, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
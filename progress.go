@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+)
+
+var progressJSON bool
+
+// progressReport is the stable, script-friendly view of a history entry.
+type progressReport struct {
+	FictionID      string  `json:"fictionId"`
+	Title          string  `json:"title"`
+	Author         string  `json:"author"`
+	Chapter        int     `json:"chapter"` // 1-based
+	ChapterTitle   string  `json:"chapterTitle"`
+	TotalChapters  int     `json:"totalChapters"`
+	ChapterPercent float64 `json:"chapterPercent"`
+	FictionPercent float64 `json:"fictionPercent"`
+	Shelf          string  `json:"shelf"`
+	LastRead       string  `json:"lastRead"` // RFC 3339, empty if unknown
+}
+
+var progressCmd = &cobra.Command{
+	Use:               "progress [fiction-id]",
+	Short:             "Show reading progress for a fiction (default: the last one read)",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFictionID,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfigOrExit()
+
+		var entry *config.ReadingEntry
+		if len(args) == 1 {
+			entry = cfg.GetEntry(args[0])
+		} else if len(cfg.ReadingHistory) > 0 {
+			entry = &cfg.ReadingHistory[0]
+		}
+		if entry == nil {
+			if progressJSON {
+				fmt.Println("null")
+			} else {
+				fmt.Println("No reading progress found.")
+			}
+			os.Exit(1)
+		}
+
+		report := newProgressReport(*entry)
+		if progressJSON {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				fmt.Printf("Error encoding progress: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+			return
+		}
+
+		fmt.Printf("%s by %s\n", report.Title, report.Author)
+		fmt.Printf("Chapter %d/%d: %s (%.0f%% through chapter)\n", report.Chapter, report.TotalChapters, report.ChapterTitle, report.ChapterPercent)
+		fmt.Printf("%.1f%% of the fiction • %s • last read %s\n", report.FictionPercent, report.Shelf, entry.LastRead)
+	},
+}
+
+func newProgressReport(entry config.ReadingEntry) progressReport {
+	report := progressReport{
+		FictionID:      entry.FictionID,
+		Title:          entry.FictionTitle,
+		Author:         entry.Author,
+		Chapter:        entry.CurrentChapter + 1,
+		ChapterTitle:   entry.ChapterTitle,
+		TotalChapters:  entry.TotalChapters,
+		ChapterPercent: entry.ChapterProgress * 100,
+		Shelf:          entry.CurrentShelf(),
+	}
+	if entry.TotalChapters > 0 {
+		report.FictionPercent = (float64(entry.CurrentChapter) + entry.ChapterProgress) / float64(entry.TotalChapters) * 100
+	}
+	if lastRead, err := time.ParseInLocation(config.TimeLayout, entry.LastRead, time.Local); err == nil {
+		report.LastRead = lastRead.Format(time.RFC3339)
+	}
+	return report
+}
+
+func init() {
+	progressCmd.Flags().BoolVar(&progressJSON, "json", false, "Print progress as JSON")
+	rootCmd.AddCommand(progressCmd)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+	"github.com/jackowfish/royal-road-cli/internal/ui"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+// titleMatches finds the fictions a title given to read could mean: books in
+// the reading history first, then a site search. An exact title match in the
+// search results wins over the rest.
+func titleMatches(cfg *config.Config, title string) ([]ui.PickItem, error) {
+	var picks []ui.PickItem
+	for _, entry := range cfg.FindByTitle(title) {
+		picks = append(picks, ui.PickItem{
+			FictionID: entry.FictionID,
+			Label:     entry.FictionTitle,
+			Detail:    fmt.Sprintf("by %s • in your history, chapter %d/%d", entry.Author, entry.CurrentChapter+1, entry.TotalChapters),
+		})
+	}
+	if len(picks) > 0 || offline {
+		return picks, nil
+	}
+
+	results, err := network.NewClient(cfg).SearchFictions(context.Background(), title)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if strings.EqualFold(result.Title, title) {
+			results = []royalroad.SearchFiction{result}
+			break
+		}
+	}
+	for _, result := range results {
+		picks = append(picks, ui.PickItem{
+			FictionID: strconv.Itoa(result.ID),
+			Label:     result.Title,
+			Detail:    fmt.Sprintf("by %s • %d chapters • %.2f rating", result.Author, result.Stats.Chapters, result.Stats.Rating),
+		})
+	}
+	return picks, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/ui"
+)
+
+var sampleMinutes int
+
+var sampleCmd = &cobra.Command{
+	Use:   "sample [fiction-id]",
+	Short: "Read the opening chapters for a few minutes, then decide whether to keep the fiction",
+	Long: `Open a fiction at chapter 1 and read roughly --minutes worth of it. At the
+end of the chapter that uses up the time you're asked to add it to Read Later,
+shelve it as Reading, or discard it from your history.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFictionID,
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := strconv.Atoi(args[0]); err != nil {
+			fmt.Printf("Invalid fiction ID: %s\n", args[0])
+			os.Exit(1)
+		}
+		if sampleMinutes < 1 {
+			fmt.Println("--minutes must be at least 1")
+			os.Exit(1)
+		}
+
+		readerModel := ui.NewReaderModel(args[0])
+		readerModel.SetSampleMinutes(sampleMinutes)
+		runProgram(readerModel)
+
+		switch readerModel.SampleDecision() {
+		case config.ShelfLater:
+			fmt.Println("Added to Read Later.")
+		case config.ShelfReading:
+			fmt.Println("Shelved as Reading.")
+		case "discard":
+			fmt.Println("Discarded.")
+		}
+	},
+}
+
+func init() {
+	sampleCmd.Flags().IntVar(&sampleMinutes, "minutes", 10, "Roughly how long to sample for")
+	rootCmd.AddCommand(sampleCmd)
+}
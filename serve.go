@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/web"
+)
+
+var (
+	serveWeb  bool
+	serveAddr string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the offline library to other devices",
+	Long: `Serve the offline library to other devices. With --web, cached fictions are
+rendered as HTML pages; reading positions are saved to the same history as the
+terminal reader. Only this machine can connect by default; --addr :8080 lets
+any browser on the same network read them.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !serveWeb {
+			fmt.Println("Choose what to serve: royal-road-cli serve --web")
+			os.Exit(1)
+		}
+
+		server, err := web.NewServer(openCacheOrExit())
+		if err != nil {
+			fmt.Printf("Error starting server: %v\n", err)
+			os.Exit(1)
+		}
+		listener, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			fmt.Printf("Error listening on %s: %v\n", serveAddr, err)
+			os.Exit(1)
+		}
+
+		addr := listener.Addr().(*net.TCPAddr)
+		fmt.Printf("Serving the offline library on http://localhost:%d\n", addr.Port)
+		if addr.IP.IsUnspecified() {
+			for _, ip := range lanAddresses() {
+				fmt.Printf("  on your network: http://%s:%d\n", ip, addr.Port)
+			}
+		}
+		fmt.Println("Press ctrl+c to stop.")
+
+		if err := http.Serve(listener, server.Handler()); err != nil {
+			fmt.Printf("Error serving: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// lanAddresses lists this machine's non-loopback IPv4 addresses so the
+// library can be opened from a phone.
+func lanAddresses() []string {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+	var ips []string
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+			ips = append(ips, ipNet.IP.String())
+		}
+	}
+	return ips
+}
+
+func init() {
+	serveCmd.Flags().BoolVar(&serveWeb, "web", false, "serve cached fictions as HTML pages")
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8080", "address to listen on (use :8080 to open it to the local network)")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/download"
+	"github.com/jackowfish/royal-road-cli/internal/hooks"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+var updateAllCmd = &cobra.Command{
+	Use:   "update-all",
+	Short: "Fetch newly published chapters for every downloaded fiction",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		store := openCacheOrExit()
+		fictionIDs, err := store.Fictions()
+		if err != nil {
+			fmt.Printf("Error reading cache: %v\n", err)
+			os.Exit(1)
+		}
+		if len(fictionIDs) == 0 {
+			fmt.Println("No downloaded fictions. Use 'royal-road-cli download [fiction-id]' first.")
+			return
+		}
+
+		downloader := download.New(network.NewClient(nil), store, downloadOptions)
+
+		failed, total := 0, 0
+		for _, fictionID := range fictionIDs {
+			fetched, err := updateFiction(downloader, fictionID)
+			total += fetched
+			if err != nil {
+				fmt.Printf("Error updating fiction %d: %v\n", fictionID, err)
+				failed++
+			}
+		}
+
+		enforceCacheQuota(store)
+
+		fmt.Printf("\n%d new chapters across %d fictions", total, len(fictionIDs))
+		if failed > 0 {
+			fmt.Printf(" (%d failed)\n", failed)
+			os.Exit(1)
+		}
+		fmt.Println()
+	},
+}
+
+// updateFiction downloads the chapters published since a fiction was last
+// downloaded and returns how many there were.
+func updateFiction(downloader *download.Downloader, fictionID int) (int, error) {
+	update, err := downloader.CheckUpdate(context.Background(), fictionID)
+	if err != nil {
+		return 0, err
+	}
+
+	fiction := update.Fiction
+	if len(update.NewChapters) == 0 {
+		fmt.Printf("%s: up to date\n", fiction.Title)
+		// Still store the refreshed metadata
+		return 0, downloader.ApplyUpdate(context.Background(), update, nil)
+	}
+
+	fmt.Printf("%s: %d new chapters\n", fiction.Title, len(update.NewChapters))
+	err = downloader.ApplyUpdate(context.Background(), update, printProgress)
+	fmt.Println()
+	runNewChapterHooks(fiction, update.NewChapters)
+	if err != nil {
+		return len(update.NewChapters), fmt.Errorf("some chapters failed to download: %w", err)
+	}
+	return len(update.NewChapters), nil
+}
+
+// runNewChapterHooks runs the configured new-chapters hooks for one fiction.
+// Hook failures are reported but never fail the command that found the
+// chapters.
+func runNewChapterHooks(fiction *royalroad.Fiction, chapters []int) {
+	cfg, err := config.Load()
+	if err != nil || len(cfg.Hooks.NewChapters) == 0 {
+		return
+	}
+	event := hooks.NewChapters{Fiction: fiction, Chapters: chapters}
+	for _, err := range hooks.Run(cfg.Hooks.NewChapters, event.Env()) {
+		fmt.Fprintf(os.Stderr, "Error running %v\n", err)
+	}
+}
+
+func init() {
+	addDownloadFlags(updateAllCmd.Flags())
+	rootCmd.AddCommand(updateAllCmd)
+}
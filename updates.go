@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/download"
+	"github.com/jackowfish/royal-road-cli/internal/feed"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+)
+
+var (
+	updatesRSS   bool
+	updatesLimit int
+)
+
+var updatesCmd = &cobra.Command{
+	Use:   "updates",
+	Short: "List recently published chapters across your library",
+	Long: `List recently published chapters of every fiction in your reading history
+(except dropped ones) and every downloaded fiction, newest first. With --rss an
+RSS 2.0 feed is written to stdout instead, e.g.
+
+  royal-road-cli updates --rss > feed.xml`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfigOrExit()
+		store, _ := cache.Open()
+		client := network.NewClient(cfg)
+		delay := download.DefaultOptions().Delay
+
+		var entries []feed.Entry
+		for i, fiction := range libraryFictions(cfg, store) {
+			if i > 0 {
+				time.Sleep(delay)
+			}
+			items, err := client.GetFictionFeed(context.Background(), fiction.id)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error fetching updates for %s: %v\n", fiction.title, err)
+				continue
+			}
+			for _, item := range items {
+				entries = append(entries, feed.Entry{
+					FictionID:    fiction.id,
+					FictionTitle: fiction.title,
+					ChapterTitle: item.Title,
+					Link:         item.Link,
+					Published:    item.Published,
+				})
+			}
+		}
+
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Published.After(entries[j].Published)
+		})
+		if updatesLimit > 0 && len(entries) > updatesLimit {
+			entries = entries[:updatesLimit]
+		}
+
+		if updatesRSS {
+			if err := feed.WriteRSS(os.Stdout, "Royal Road library updates", entries, time.Now()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing feed: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		if len(entries) == 0 {
+			fmt.Println("No recent chapters found.")
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "PUBLISHED\tFICTION\tCHAPTER")
+		for _, entry := range entries {
+			published := "?"
+			if !entry.Published.IsZero() {
+				published = entry.Published.Local().Format(config.TimeLayout)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", published, entry.FictionTitle, entry.ChapterTitle)
+		}
+		w.Flush()
+	},
+}
+
+type libraryFiction struct {
+	id    int
+	title string
+}
+
+// libraryFictions lists the fictions worth watching for new chapters: the
+// reading history apart from dropped books, then any other downloaded fiction.
+func libraryFictions(cfg *config.Config, store *cache.Store) []libraryFiction {
+	seen := make(map[int]bool)
+	var fictions []libraryFiction
+	for _, entry := range cfg.ReadingHistory {
+		id, err := strconv.Atoi(entry.FictionID)
+		if err != nil || seen[id] || entry.Shelf == config.ShelfDropped {
+			continue
+		}
+		seen[id] = true
+		fictions = append(fictions, libraryFiction{id: id, title: entry.FictionTitle})
+	}
+
+	if store == nil {
+		return fictions
+	}
+	fictionIDs, _ := store.Fictions()
+	for _, id := range fictionIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		title := strconv.Itoa(id)
+		if fiction, err := store.LoadFiction(id); err == nil {
+			title = fiction.Title
+		}
+		fictions = append(fictions, libraryFiction{id: id, title: title})
+	}
+	return fictions
+}
+
+func init() {
+	updatesCmd.Flags().BoolVar(&updatesRSS, "rss", false, "write an RSS 2.0 feed to stdout")
+	updatesCmd.Flags().IntVar(&updatesLimit, "limit", 50, "maximum number of chapters (0 for all)")
+	rootCmd.AddCommand(updatesCmd)
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jackowfish/royal-road-cli/internal/cache"
+	"github.com/jackowfish/royal-road-cli/internal/config"
+	"github.com/jackowfish/royal-road-cli/internal/download"
+	"github.com/jackowfish/royal-road-cli/internal/network"
+	"github.com/jackowfish/royal-road-cli/internal/notify"
+	"github.com/jackowfish/royal-road-cli/pkg/royalroad"
+)
+
+var (
+	watchInterval time.Duration
+	watchNoNotify bool
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Keep checking your library for new chapters and notify on the desktop",
+	Long: `Keep checking every fiction in your reading history (except dropped ones)
+and every downloaded fiction for new chapters, showing a desktop notification
+and running the newChapters hooks for each one found. Only each fiction's RSS
+feed is fetched unless something new turns up.
+
+Chapters already published when watching starts are only reported for
+downloaded fictions that are missing them. Run it in the background, e.g.
+
+  royal-road-cli watch --interval 30m &`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if watchInterval < time.Minute {
+			fmt.Println("Error: --interval must be at least 1m")
+			os.Exit(1)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		cfg := loadConfigOrExit()
+		watcher := &libraryWatcher{
+			client: network.NewClient(cfg),
+			config: cfg,
+			known:  make(map[int]map[int]bool),
+		}
+		watcher.store, _ = cache.Open()
+
+		fmt.Printf("Watching for new chapters every %s (ctrl+c to stop)\n", watchInterval)
+		for {
+			watcher.check(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchInterval):
+			}
+		}
+	},
+}
+
+// libraryWatcher remembers which chapters of each fiction it has seen so
+// every new chapter is only announced once.
+type libraryWatcher struct {
+	client *royalroad.Client
+	store  *cache.Store
+	config *config.Config       // Last config loaded without error
+	known  map[int]map[int]bool // fiction ID -> chapter IDs
+}
+
+func (w *libraryWatcher) check(ctx context.Context) {
+	// Reloaded for fictions added since the last check; a config that can't
+	// be read right now (e.g. mid-save) leaves the last one in use
+	if cfg, err := config.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Error loading config: %v\n", time.Now().Format(time.Kitchen), err)
+	} else {
+		w.config = cfg
+	}
+	delay := download.DefaultOptions().Delay
+
+	for i, fiction := range libraryFictions(w.config, w.store) {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(delay):
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err := w.checkFiction(ctx, fiction); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "%s Error checking %s: %v\n", time.Now().Format(time.Kitchen), fiction.title, err)
+		}
+	}
+}
+
+func (w *libraryWatcher) checkFiction(ctx context.Context, fiction libraryFiction) error {
+	items, err := w.client.GetFictionFeed(ctx, fiction.id)
+	if err != nil {
+		return err
+	}
+
+	known := w.known[fiction.id]
+	if known == nil {
+		known = make(map[int]bool)
+		w.known[fiction.id] = known
+		var cached *royalroad.Fiction
+		if w.store != nil {
+			cached, _ = w.store.LoadFiction(fiction.id)
+		}
+		if cached == nil {
+			// Nothing to compare with yet: the current feed is the baseline
+			for _, item := range items {
+				known[item.ChapterID] = true
+			}
+			return nil
+		}
+		for _, chapter := range cached.Chapters {
+			known[chapter.ID] = true
+		}
+	}
+
+	unseen := false
+	for _, item := range items {
+		if !known[item.ChapterID] {
+			unseen = true
+			break
+		}
+	}
+	if !unseen {
+		return nil
+	}
+
+	// Only now fetch the full page, for chapter numbers and the hooks
+	live, err := w.client.GetFiction(royalroad.Revalidate(ctx), fiction.id)
+	if err != nil {
+		return err
+	}
+	var chapters []int
+	for i, chapter := range live.Chapters {
+		if !known[chapter.ID] {
+			chapters = append(chapters, i)
+			known[chapter.ID] = true
+		}
+	}
+	if len(chapters) == 0 {
+		return nil
+	}
+
+	latest := live.Chapters[chapters[len(chapters)-1]]
+	fmt.Printf("%s %s: %d new (latest: %s)\n", time.Now().Format(time.Kitchen), live.Title, len(chapters), latest.Title)
+	if !watchNoNotify {
+		body := latest.Title
+		if len(chapters) > 1 {
+			body = fmt.Sprintf("%d new chapters, latest: %s", len(chapters), latest.Title)
+		}
+		if err := notify.Send("New chapter: "+live.Title, body); err != nil {
+			fmt.Fprintf(os.Stderr, "Error showing notification: %v\n", err)
+		}
+	}
+	runNewChapterHooks(live, chapters)
+	recordNotified(fiction.id, len(live.Chapters))
+	return nil
+}
+
+// recordNotified notes in the history that the hooks have run for the first
+// count chapters, so check-updates doesn't run them again. The config is
+// loaded afresh because the reader may have saved it since the last check.
+func recordNotified(fictionID, count int) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return
+	}
+	entry := cfg.GetEntry(strconv.Itoa(fictionID))
+	if entry == nil || entry.NotifiedChapters >= count {
+		return
+	}
+	entry.NotifiedChapters = count
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving config: %v\n", err)
+	}
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 30*time.Minute, "time between checks")
+	watchCmd.Flags().BoolVar(&watchNoNotify, "no-notify", false, "print new chapters (and run hooks) without desktop notifications")
+	rootCmd.AddCommand(watchCmd)
+}